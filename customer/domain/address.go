@@ -1,7 +1,6 @@
 package customer
 
 import (
-	"errors"
 	"regexp"
 	"strings"
 	"time"
@@ -46,7 +45,7 @@ type Address struct {
 // If multiple fields are invalid, all violations are collected and returned as a
 // single joined error, allowing callers to inspect every failure via [errors.Is].
 func NewAddress(cep, street, number, complement, district, city, state, country string) (*Address, error) {
-	if err := errors.Join(
+	if err := errs.Join(
 		guard.CheckNotNullOrWhiteSpace(street, ErrInvalidStreet),
 		guard.CheckNotNullOrWhiteSpace(number, ErrInvalidNumber),
 		guard.CheckNotNullOrWhiteSpace(district, ErrInvalidDistrict),
@@ -59,7 +58,7 @@ func NewAddress(cep, street, number, complement, district, city, state, country
 	}
 
 	return &Address{
-		ID:         kernel.NewID().String(),
+		ID:         kernel.GenerateID(),
 		cep:        cep,
 		street:     street,
 		number:     number,
@@ -68,7 +67,7 @@ func NewAddress(cep, street, number, complement, district, city, state, country
 		city:       city,
 		state:      state,
 		country:    country,
-		CreatedAt:  time.Now().UTC(),
+		CreatedAt:  kernel.Now(),
 	}, nil
 }
 
@@ -104,7 +103,7 @@ func (a *Address) IsZero() bool {
 }
 
 func (a *Address) updateTimestamp() {
-	a.UpdatedAt = new(time.Now().UTC())
+	a.UpdatedAt = new(kernel.Now())
 }
 
 func checkValidState(state string) error {
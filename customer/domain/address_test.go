@@ -7,6 +7,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/customer/domain"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -156,6 +157,20 @@ func TestNewAddress(t *testing.T) {
 	}
 }
 
+func TestNewAddress_MaxViolations(t *testing.T) {
+	t.Cleanup(func() { _ = errs.SetMaxViolations(errs.DefaultMaxViolations) })
+	require.NoError(t, errs.SetMaxViolations(2))
+
+	_, err := customer.NewAddress("invalid", "", "", "", "", "", "XX", "")
+
+	entries := errs.MarshalChain(err)
+	require.Len(t, entries, 3, "the cap plus the marker")
+	assert.ErrorIs(t, err, customer.ErrInvalidStreet)
+	assert.ErrorIs(t, err, customer.ErrInvalidNumber)
+	assert.NotErrorIs(t, err, customer.ErrInvalidDistrict, "violations past the cap should be dropped")
+	assert.Equal(t, "...and 5 more", entries[2]["message"])
+}
+
 func TestAddress_Equals(t *testing.T) {
 	baseAddr := kernel.Must(customer.NewAddress(
 		"12345-678", "Street", "123", "",
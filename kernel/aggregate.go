@@ -1,6 +1,14 @@
 package kernel
 
-import "time"
+import (
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+// ErrNilAggregate is returned by aggregate methods called on a nil pointer, so misuse
+// surfaces as a handled error instead of a panic.
+var ErrNilAggregate = errs.New("KERNEL.NIL_AGGREGATE", "operation called on a nil aggregate")
 
 // DomainEvent is the interface that all domain events must implement.
 // EventID returns a unique event identifier used for deduplication in [AggregateRoot].
@@ -9,27 +17,64 @@ type DomainEvent interface {
 	OccurredAt() time.Time
 }
 
-// AggregateRoot is an embeddable struct that manages the collection of domain events
-// raised by an aggregate. Embed it in any aggregate root to gain event-sourcing support.
+// AggregateRoot is an embeddable struct that carries the identity, the last update
+// timestamp and the buffer of domain events raised by an aggregate. Embed it in any
+// aggregate root to share these concerns instead of redeclaring them.
 type AggregateRoot struct {
-	events map[string]DomainEvent
+	ID        string     `json:"id"`
+	UpdatedAt *time.Time `json:"updated_at"`
+
+	events []DomainEvent
+}
+
+// NewAggregateRoot returns an [AggregateRoot] initialized with a freshly generated ID.
+func NewAggregateRoot() AggregateRoot {
+	return AggregateRoot{ID: GenerateID()}
 }
 
 // AddDomainEvent registers a domain event, keyed by its EventID to prevent duplicates.
+// Events are kept in the order they were raised.
 func (o *AggregateRoot) AddDomainEvent(event DomainEvent) {
-	if o.events == nil {
-		o.events = make(map[string]DomainEvent)
+	for _, e := range o.events {
+		if e.EventID() == event.EventID() {
+			return
+		}
 	}
-	o.events[event.EventID()] = event
+	o.events = append(o.events, event)
 }
 
 // RemoveDomainEvent removes a previously registered domain event by its EventID.
 func (o *AggregateRoot) RemoveDomainEvent(event DomainEvent) {
-	delete(o.events, event.EventID())
+	for i, e := range o.events {
+		if e.EventID() == event.EventID() {
+			o.events = append(o.events[:i], o.events[i+1:]...)
+			return
+		}
+	}
 }
 
 // ClearDomainEvent discards all pending domain events, typically called after events
 // have been dispatched.
 func (o *AggregateRoot) ClearDomainEvent() {
-	o.events = make(map[string]DomainEvent)
+	o.events = nil
+}
+
+// DomainEvents returns a copy of the pending domain events in the order they were raised,
+// without draining the buffer.
+func (o *AggregateRoot) DomainEvents() []DomainEvent {
+	return append([]DomainEvent(nil), o.events...)
+}
+
+// PullDomainEvents returns the pending domain events in the order they were raised and
+// empties the buffer, so each event is handed to the dispatcher exactly once.
+func (o *AggregateRoot) PullDomainEvents() []DomainEvent {
+	events := o.events
+	o.events = nil
+	return events
+}
+
+// UpdateTimestamp sets UpdatedAt to the current UTC time. Call it from every mutating
+// method of the embedding aggregate.
+func (o *AggregateRoot) UpdateTimestamp() {
+	o.UpdatedAt = new(Now())
 }
@@ -0,0 +1,92 @@
+package kernel_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	kernel.Event
+}
+
+func newTestEvent(id string) testEvent {
+	return testEvent{Event: kernel.Event{ID: id}}
+}
+
+func TestNewAggregateRoot(t *testing.T) {
+	got := kernel.NewAggregateRoot()
+
+	assert.NotEmpty(t, got.ID, "ID should be generated")
+	assert.Nil(t, got.UpdatedAt, "UpdatedAt should be nil on creation")
+	assert.Empty(t, got.DomainEvents(), "no events should be pending on creation")
+}
+
+func TestAggregateRoot_AddDomainEvent(t *testing.T) {
+	t.Run("should keep events in the order they were raised", func(t *testing.T) {
+		var ar kernel.AggregateRoot
+
+		ar.AddDomainEvent(newTestEvent("evt-1"))
+		ar.AddDomainEvent(newTestEvent("evt-2"))
+
+		got := ar.DomainEvents()
+		require.Len(t, got, 2)
+		assert.Equal(t, "evt-1", got[0].EventID())
+		assert.Equal(t, "evt-2", got[1].EventID())
+	})
+
+	t.Run("should ignore an event already registered with the same EventID", func(t *testing.T) {
+		var ar kernel.AggregateRoot
+
+		ar.AddDomainEvent(newTestEvent("evt-1"))
+		ar.AddDomainEvent(newTestEvent("evt-1"))
+
+		assert.Len(t, ar.DomainEvents(), 1)
+	})
+}
+
+func TestAggregateRoot_RemoveDomainEvent(t *testing.T) {
+	var ar kernel.AggregateRoot
+	ar.AddDomainEvent(newTestEvent("evt-1"))
+	ar.AddDomainEvent(newTestEvent("evt-2"))
+
+	ar.RemoveDomainEvent(newTestEvent("evt-1"))
+
+	got := ar.DomainEvents()
+	require.Len(t, got, 1)
+	assert.Equal(t, "evt-2", got[0].EventID())
+}
+
+func TestAggregateRoot_ClearDomainEvent(t *testing.T) {
+	var ar kernel.AggregateRoot
+	ar.AddDomainEvent(newTestEvent("evt-1"))
+
+	ar.ClearDomainEvent()
+
+	assert.Empty(t, ar.DomainEvents())
+}
+
+func TestAggregateRoot_PullDomainEvents(t *testing.T) {
+	var ar kernel.AggregateRoot
+	ar.AddDomainEvent(newTestEvent("evt-1"))
+	ar.AddDomainEvent(newTestEvent("evt-2"))
+
+	got := ar.PullDomainEvents()
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "evt-1", got[0].EventID())
+	assert.Equal(t, "evt-2", got[1].EventID())
+	assert.Empty(t, ar.DomainEvents(), "buffer should be drained after pull")
+	assert.Empty(t, ar.PullDomainEvents(), "a second pull should return nothing")
+}
+
+func TestAggregateRoot_UpdateTimestamp(t *testing.T) {
+	var ar kernel.AggregateRoot
+
+	ar.UpdateTimestamp()
+
+	require.NotNil(t, ar.UpdatedAt)
+	assert.Equal(t, "UTC", ar.UpdatedAt.Location().String())
+}
@@ -0,0 +1,67 @@
+package kernel
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts the source of the current time so that time-dependent domain rules
+// can be exercised deterministically in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+var clock Clock = systemClock{}
+
+// Now returns the current UTC time according to the installed [Clock].
+// Domain code must call it instead of [time.Now].
+func Now() time.Time {
+	return clock.Now().UTC()
+}
+
+// SetClock installs c as the package-level [Clock] and returns a function that restores
+// the previous one. It is meant for tests; pair it with t.Cleanup. Not safe for concurrent use.
+func SetClock(c Clock) (restore func()) {
+	previous := clock
+	clock = c
+	return func() { clock = previous }
+}
+
+// FrozenClock is a [Clock] that always returns the same instant until it is moved with
+// [FrozenClock.Advance] or [FrozenClock.Set]. It is safe for concurrent use.
+type FrozenClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFrozenClock returns a [FrozenClock] stopped at now.
+func NewFrozenClock(now time.Time) *FrozenClock {
+	return &FrozenClock{now: now}
+}
+
+// Now returns the instant the clock is stopped at.
+func (c *FrozenClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *FrozenClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set stops the clock at now.
+func (c *FrozenClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
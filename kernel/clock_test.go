@@ -0,0 +1,35 @@
+package kernel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetClock(t *testing.T) {
+	at := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.FixedZone("BRT", -3*60*60))
+
+	restore := kernel.SetClock(kernel.NewFrozenClock(at))
+
+	assert.Equal(t, at.UTC(), kernel.Now(), "Now should come from the installed clock in UTC")
+	assert.Equal(t, "UTC", kernel.Now().Location().String())
+
+	restore()
+
+	assert.NotEqual(t, at.UTC(), kernel.Now(), "restore should reinstall the previous clock")
+}
+
+func TestFrozenClock(t *testing.T) {
+	at := time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC)
+	c := kernel.NewFrozenClock(at)
+
+	assert.Equal(t, at, c.Now())
+
+	c.Advance(time.Hour)
+	assert.Equal(t, at.Add(time.Hour), c.Now())
+
+	c.Set(at)
+	assert.Equal(t, at, c.Now())
+}
@@ -0,0 +1,37 @@
+package errs
+
+// MarshalChain flattens the error tree of err into one entry per [DomainError] found,
+// depth first and in join order, each holding its "code" and "message". It follows
+// both single wrapping (Unwrap() error) and [errors.Join] (Unwrap() []error), so every
+// validation failure shows up as a separate field in structured logs. Errors that are
+// not a [DomainError] are traversed but produce no entry. Returns nil when err is nil
+// or holds no [DomainError].
+func MarshalChain(err error) []map[string]string {
+	var entries []map[string]string
+	walkChain(err, func(e *DomainError) {
+		entries = append(entries, map[string]string{
+			"code":    string(e.Code),
+			"message": e.Message,
+		})
+	})
+	return entries
+}
+
+func walkChain(err error, visit func(*DomainError)) {
+	if err == nil {
+		return
+	}
+
+	if e, ok := err.(*DomainError); ok {
+		visit(e)
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		walkChain(u.Unwrap(), visit)
+	case interface{ Unwrap() []error }:
+		for _, inner := range u.Unwrap() {
+			walkChain(inner, visit)
+		}
+	}
+}
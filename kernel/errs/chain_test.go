@@ -0,0 +1,65 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalChain(t *testing.T) {
+	errA := errs.New("TEST.A", "first failure")
+	errB := errs.New("TEST.B", "second failure")
+	errC := errs.New("TEST.C", "third failure")
+
+	tests := []struct {
+		name string
+		err  error
+		want []map[string]string
+	}{
+		// ==================== Success cases ==================== //
+		{
+			name: "should produce one entry per error of a join",
+			err:  errors.Join(errA, errB, errC),
+			want: []map[string]string{
+				{"code": "TEST.A", "message": "first failure"},
+				{"code": "TEST.B", "message": "second failure"},
+				{"code": "TEST.C", "message": "third failure"},
+			},
+		},
+		{
+			name: "should produce a single entry for a single error",
+			err:  errA,
+			want: []map[string]string{
+				{"code": "TEST.A", "message": "first failure"},
+			},
+		},
+		{
+			name: "should follow wrapped errors and skip non-domain ones",
+			err:  fmt.Errorf("saving order: %w", errB.Wrap(errors.New("connection reset"))),
+			want: []map[string]string{
+				{"code": "TEST.B", "message": "second failure"},
+			},
+		},
+		// ==================== Failure cases ==================== //
+		{
+			name: "should return nil for a nil error",
+			err:  nil,
+			want: nil,
+		},
+		{
+			name: "should return nil when no domain error is present",
+			err:  errors.New("plain error"),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errs.MarshalChain(tt.err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
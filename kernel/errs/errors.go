@@ -6,6 +6,11 @@ package errs
 import (
 	"errors"
 	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
 )
 
 // ErrorCode is a string identifier for a domain error.
@@ -13,6 +18,16 @@ import (
 // (e.g. "ORDER_ITEM.NEGATIVE_DISCOUNT").
 type ErrorCode string
 
+// Kind classifies a [DomainError] by what went wrong with the request, so adapters can
+// answer it without parsing its [ErrorCode] (see [HTTPStatus]).
+type Kind int
+
+const (
+	KindInvalid  Kind = iota // the request breaks a rule or fails validation; the default
+	KindNotFound             // the request names something that does not exist
+	KindConflict             // the request clashes with the current state, e.g. the order status
+)
+
 // DomainError represents a business rule or domain invariant violation.
 // It carries a structured [ErrorCode] for programmatic matching and a human-readable
 // Message for logging or display. An optional Err field allows wrapping lower-level
@@ -21,6 +36,49 @@ type DomainError struct {
 	Code    ErrorCode // e.g. "ORDER_ITEM.NEGATIVE_DISCOUNT"
 	Message string    // human-readable description of the violation
 	Err     error     // optional underlying error for wrapping
+
+	kind     Kind   // set by the constructor, see [DomainError.Kind]
+	location string // file:line where the error was created, see [SetCaptureLocation]
+}
+
+var captureLocation atomic.Bool
+
+// SetCaptureLocation turns on or off recording, in [New] and both Wrap variants, of the
+// file:line each [DomainError] is created at, reported by [DomainError.Location]. It is
+// off by default so creating errors costs nothing extra; turn it on while debugging. It
+// returns a function that restores the previous setting.
+func SetCaptureLocation(enabled bool) (restore func()) {
+	previous := captureLocation.Swap(enabled)
+	return func() { captureLocation.Store(previous) }
+}
+
+// Kind returns the [Kind] e was created with: [KindNotFound] for errors made with
+// [NewNotFound], [KindConflict] for [NewConflict] and [KindInvalid] otherwise.
+func (e *DomainError) Kind() Kind {
+	return e.kind
+}
+
+// Location returns the file:line where e was created, or "" when location capture was
+// off at the time (see [SetCaptureLocation]). It only tells where errors built at
+// runtime come from: package-level sentinels are created when their package is
+// initialized, before capture can be turned on, and returning one records nothing, so
+// their Location is always "". Wrap a sentinel at the point of failure, e.g. with
+// [DomainError.Wrap], to record where it happened.
+func (e *DomainError) Location() string {
+	return e.location
+}
+
+// callerLocation returns the file:line of the caller of the function calling it, or ""
+// when capture is off.
+func callerLocation() string {
+	if !captureLocation.Load() {
+		return ""
+	}
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return ""
+	}
+	return file + ":" + strconv.Itoa(line)
 }
 
 // Error returns a formatted string representation of the error.
@@ -53,21 +111,57 @@ func (e *DomainError) Is(target error) bool {
 }
 
 // Wrap returns a shallow copy of e with Err set to err.
-// The copy preserves the original Code and Message, while [errors.Unwrap]
+// The copy preserves the original Code, Message and [Kind], while [errors.Unwrap]
 // will traverse to err. Use this to attach a lower-level cause to a sentinel error.
 func (e *DomainError) Wrap(err error) *DomainError {
-	return &DomainError{Code: e.Code, Message: e.Message, Err: err}
+	return &DomainError{Code: e.Code, Message: e.Message, Err: err, kind: e.kind, location: callerLocation()}
 }
 
 // New creates a [DomainError] with the given code and human-readable message.
 // Use this to define package-level sentinel errors for domain invariant violations.
+// Every error it creates is recorded for [Sentinels]. Its [Kind] is [KindInvalid]; use
+// [NewNotFound] or [NewConflict] for the other kinds.
 func New(code ErrorCode, message string) *DomainError {
-	return &DomainError{Code: code, Message: message}
+	return register(&DomainError{Code: code, Message: message, location: callerLocation()})
+}
+
+// NewNotFound is like [New], for an error of [KindNotFound].
+func NewNotFound(code ErrorCode, message string) *DomainError {
+	return register(&DomainError{Code: code, Message: message, kind: KindNotFound, location: callerLocation()})
+}
+
+// NewConflict is like [New], for an error of [KindConflict].
+func NewConflict(code ErrorCode, message string) *DomainError {
+	return register(&DomainError{Code: code, Message: message, kind: KindConflict, location: callerLocation()})
+}
+
+// register records e for [Sentinels] and returns it.
+func register(e *DomainError) *DomainError {
+	sentinelsMu.Lock()
+	defer sentinelsMu.Unlock()
+	sentinels = append(sentinels, e)
+	return e
+}
+
+var (
+	sentinelsMu sync.Mutex
+	sentinels   []*DomainError
+)
+
+// Sentinels returns every [DomainError] created with [New], [NewNotFound] or
+// [NewConflict] so far, in creation order.
+// Package-level sentinels are created when their package is initialized, so a test can
+// check the whole error catalog of the packages it links in, e.g. that no two errors
+// share an [ErrorCode], without listing them by hand.
+func Sentinels() []*DomainError {
+	sentinelsMu.Lock()
+	defer sentinelsMu.Unlock()
+	return slices.Clone(sentinels)
 }
 
 // Wrap creates a [DomainError] with the given code and message, wrapping err
 // as the underlying cause. Use this when a domain rule violation originates
 // from a lower-level error that should remain accessible via [errors.Unwrap].
 func Wrap(code ErrorCode, message string, err error) *DomainError {
-	return &DomainError{Code: code, Message: message, Err: err}
+	return &DomainError{Code: code, Message: message, Err: err, location: callerLocation()}
 }
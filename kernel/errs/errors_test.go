@@ -120,3 +120,60 @@ func TestDomainError_Wrap(t *testing.T) {
 	assert.Equal(t, underlying, wrapped.Err)
 	assert.Nil(t, sentinel.Err, "original sentinel should not be modified")
 }
+
+func TestDomainError_Location(t *testing.T) {
+	t.Run("should record where the error was created when capture is on", func(t *testing.T) {
+		t.Cleanup(errs.SetCaptureLocation(true))
+		sentinel := errs.New("TEST.CODE", "test message")
+
+		tests := []struct {
+			name string
+			err  *errs.DomainError
+		}{
+			{name: "New", err: errs.New("TEST.CODE", "test message")},
+			{name: "NewNotFound", err: errs.NewNotFound("TEST.CODE", "test message")},
+			{name: "NewConflict", err: errs.NewConflict("TEST.CODE", "test message")},
+			{name: "Wrap", err: errs.Wrap("TEST.CODE", "test message", fmt.Errorf("cause"))},
+			{name: "DomainError.Wrap", err: sentinel.Wrap(fmt.Errorf("cause"))},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Regexp(t, `errors_test\.go:\d+$`, tt.err.Location())
+			})
+		}
+	})
+
+	t.Run("should leave package-level sentinels without a location", func(t *testing.T) {
+		t.Cleanup(errs.SetCaptureLocation(true))
+
+		assert.Empty(t, errs.ErrMoreViolations.Location(), "sentinels are created before capture is turned on")
+		assert.Regexp(t, `errors_test\.go:\d+$`, errs.ErrMoreViolations.Wrap(fmt.Errorf("cause")).Location())
+	})
+
+	t.Run("should record nothing when capture is off", func(t *testing.T) {
+		err := errs.New("TEST.CODE", "test message")
+
+		assert.Empty(t, err.Location())
+		assert.Empty(t, err.Wrap(fmt.Errorf("cause")).Location())
+	})
+}
+
+func TestSentinels(t *testing.T) {
+	t.Run("should list the errors created with every constructor", func(t *testing.T) {
+		sentinel := errs.New("TEST.SENTINEL", "test message")
+		notFound := errs.NewNotFound("TEST.SENTINEL_NOT_FOUND", "test message")
+		conflict := errs.NewConflict("TEST.SENTINEL_CONFLICT", "test message")
+
+		got := errs.Sentinels()
+
+		assert.Subset(t, got, []*errs.DomainError{sentinel, notFound, conflict})
+	})
+
+	t.Run("should not list wrapped errors", func(t *testing.T) {
+		wrapped := errs.Wrap("TEST.WRAPPED", "test message", fmt.Errorf("cause"))
+
+		got := errs.Sentinels()
+
+		assert.NotContains(t, got, wrapped)
+	})
+}
@@ -0,0 +1,50 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Response is the body HTTP adapters write for a failed request.
+type Response struct {
+	Errors []map[string]string `json:"errors"` // one "code"/"message" entry per DomainError, see MarshalChain
+}
+
+// HTTPStatus returns the HTTP status code an adapter should answer err with, derived
+// from the [Kind] of the first [DomainError] in the chain:
+//   - [KindNotFound] maps to 404 Not Found;
+//   - [KindConflict] maps to 409 Conflict;
+//   - [KindInvalid] is a rejected request and maps to 400 Bad Request.
+//
+// Errors holding no [DomainError] map to 500 Internal Server Error, and nil to 200 OK.
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	var domErr *DomainError
+	if !errors.As(err, &domErr) {
+		return http.StatusInternalServerError
+	}
+
+	switch domErr.Kind() {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// ToResponse builds the [Response] body for err, listing every [DomainError] in its
+// chain (see [MarshalChain]). An error holding no [DomainError] is reported as a single
+// opaque INTERNAL entry.
+func ToResponse(err error) Response {
+	entries := MarshalChain(err)
+	if len(entries) == 0 {
+		// keep the details of unexpected errors away from API clients.
+		entries = []map[string]string{{"code": "INTERNAL", "message": "internal error"}}
+	}
+	return Response{Errors: entries}
+}
@@ -0,0 +1,91 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "should return 200 for a nil error",
+			err:  nil,
+			want: http.StatusOK,
+		},
+		{
+			name: "should return 400 for a validation failure",
+			err:  errs.New("ORDER.INVALID_CUSTOMER_ID", "invalid"),
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "should return 404 for a missing resource",
+			err:  errs.NewNotFound("TEST.NOT_FOUND", "not found"),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "should return 409 for a conflict with the current state",
+			err:  errs.NewConflict("TEST.NOT_PENDING", "not pending"),
+			want: http.StatusConflict,
+		},
+		{
+			name: "should go by the kind rather than the code",
+			err:  errs.New("TEST.ALREADY_EXISTS", "exists"),
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "should use the first domain error of a join",
+			err:  errors.Join(errs.NewConflict("TEST.CONFLICT", "conflict"), errs.New("TEST.INVALID", "invalid")),
+			want: http.StatusConflict,
+		},
+		{
+			name: "should look through wrapping",
+			err:  fmt.Errorf("loading: %w", errs.NewNotFound("TEST.NOT_FOUND", "not found")),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "should keep the kind of a wrapped sentinel",
+			err:  errs.NewConflict("TEST.CONFLICT", "conflict").Wrap(errors.New("cause")),
+			want: http.StatusConflict,
+		},
+		{
+			name: "should return 500 for an error that is not a domain error",
+			err:  errors.New("connection reset"),
+			want: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errs.HTTPStatus(tt.err))
+		})
+	}
+}
+
+func TestToResponse(t *testing.T) {
+	t.Run("should list every domain error of the chain", func(t *testing.T) {
+		err := errors.Join(errs.New("TEST.A", "first failure"), errs.New("TEST.B", "second failure"))
+
+		got := errs.ToResponse(err)
+
+		assert.Equal(t, errs.Response{Errors: []map[string]string{
+			{"code": "TEST.A", "message": "first failure"},
+			{"code": "TEST.B", "message": "second failure"},
+		}}, got)
+	})
+
+	t.Run("should hide the details of an error that is not a domain error", func(t *testing.T) {
+		got := errs.ToResponse(errors.New("dial tcp 10.0.0.1: connection refused"))
+
+		assert.Equal(t, errs.Response{Errors: []map[string]string{
+			{"code": "INTERNAL", "message": "internal error"},
+		}}, got)
+	})
+}
@@ -0,0 +1,76 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+var (
+	ErrMoreViolations       = New("ERRORS.MORE_VIOLATIONS", "more violations were omitted")
+	ErrInvalidMaxViolations = New("ERRORS.INVALID_MAX_VIOLATIONS", "maximum violations must be greater than zero")
+)
+
+// DefaultMaxViolations is how many violations [Join] keeps, unless overridden with
+// [SetMaxViolations].
+const DefaultMaxViolations = 20
+
+var maxViolations atomic.Int64
+
+func init() {
+	maxViolations.Store(DefaultMaxViolations)
+}
+
+// MaxViolations returns the configured maximum of violations kept by [Join].
+func MaxViolations() int {
+	return int(maxViolations.Load())
+}
+
+// SetMaxViolations configures how many violations [Join] keeps; n must be strictly
+// positive, otherwise [ErrInvalidMaxViolations] is returned.
+func SetMaxViolations(n int) error {
+	if n <= 0 {
+		return ErrInvalidMaxViolations
+	}
+	maxViolations.Store(int64(n))
+	return nil
+}
+
+// Join behaves like [errors.Join] for validation failures, bounding the size of the
+// result. Errors built with [errors.Join] or Join are flattened into their violations,
+// so each counts on its own, while other errors wrapping several, such as a
+// [fmt.Errorf] with more than one %w, are kept whole so their context is not lost. Past
+// [MaxViolations] the rest are dropped and replaced by a single [ErrMoreViolations]
+// marker reading "...and N more". Returns nil when every error is nil.
+func Join(errs ...error) error {
+	var violations []error
+	for _, err := range errs {
+		violations = appendViolations(violations, err)
+	}
+
+	limit := MaxViolations()
+	if omitted := len(violations) - limit; omitted > 0 {
+		violations = append(violations[:limit:limit],
+			&DomainError{Code: ErrMoreViolations.Code, Message: fmt.Sprintf("...and %d more", omitted)})
+	}
+	return errors.Join(violations...)
+}
+
+// joinErrorType is the unexported type of the errors returned by [errors.Join].
+var joinErrorType = reflect.TypeOf(errors.Join(ErrMoreViolations))
+
+// appendViolations appends err to violations, unfolding errors joined with [errors.Join].
+func appendViolations(violations []error, err error) []error {
+	if err == nil {
+		return violations
+	}
+	if reflect.TypeOf(err) != joinErrorType {
+		return append(violations, err)
+	}
+	joined := err.(interface{ Unwrap() []error })
+	for _, inner := range joined.Unwrap() {
+		violations = appendViolations(violations, inner)
+	}
+	return violations
+}
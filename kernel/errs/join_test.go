@@ -0,0 +1,93 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func violations(n int) []error {
+	list := make([]error, n)
+	for i := range list {
+		list[i] = errs.New(errs.ErrorCode(fmt.Sprintf("TEST.V%d", i+1)), "violation")
+	}
+	return list
+}
+
+func TestJoin(t *testing.T) {
+	t.Run("should keep every violation up to the cap", func(t *testing.T) {
+		list := violations(errs.DefaultMaxViolations)
+
+		err := errs.Join(list...)
+
+		assert.Len(t, errs.MarshalChain(err), errs.DefaultMaxViolations)
+		assert.NotErrorIs(t, err, errs.ErrMoreViolations)
+	})
+
+	t.Run("should cap the violations and append the marker when exceeded", func(t *testing.T) {
+		list := violations(errs.DefaultMaxViolations + 5)
+
+		err := errs.Join(list...)
+
+		entries := errs.MarshalChain(err)
+		require.Len(t, entries, errs.DefaultMaxViolations+1, "the cap plus the marker")
+		assert.Equal(t, "ERRORS.MORE_VIOLATIONS", entries[errs.DefaultMaxViolations]["code"])
+		assert.Equal(t, "...and 5 more", entries[errs.DefaultMaxViolations]["message"])
+		assert.ErrorIs(t, err, errs.ErrMoreViolations)
+		assert.ErrorIs(t, err, list[errs.DefaultMaxViolations-1], "the last violation within the cap should be kept")
+		assert.NotErrorIs(t, err, list[errs.DefaultMaxViolations], "violations past the cap should be dropped")
+	})
+
+	t.Run("should count the violations of nested joined errors", func(t *testing.T) {
+		t.Cleanup(func() { _ = errs.SetMaxViolations(errs.DefaultMaxViolations) })
+		require.NoError(t, errs.SetMaxViolations(3))
+		list := violations(4)
+
+		err := errs.Join(errors.Join(list[0], list[1]), nil, errors.Join(list[2], list[3]))
+
+		entries := errs.MarshalChain(err)
+		require.Len(t, entries, 4)
+		assert.Equal(t, "...and 1 more", entries[3]["message"])
+	})
+
+	t.Run("should keep an error wrapping several with its context as one violation", func(t *testing.T) {
+		t.Cleanup(func() { _ = errs.SetMaxViolations(errs.DefaultMaxViolations) })
+		require.NoError(t, errs.SetMaxViolations(1))
+		list := violations(2)
+		wrapped := fmt.Errorf("address: %w, %w", list[0], list[1])
+
+		err := errs.Join(wrapped, list[0])
+
+		assert.ErrorContains(t, err, "address: ")
+		assert.ErrorIs(t, err, list[1], "the wrapped error should be kept whole")
+		assert.ErrorIs(t, err, errs.ErrMoreViolations)
+	})
+
+	t.Run("should return nil when every error is nil", func(t *testing.T) {
+		assert.NoError(t, errs.Join(nil, nil))
+	})
+}
+
+func TestSetMaxViolations(t *testing.T) {
+	t.Cleanup(func() { _ = errs.SetMaxViolations(errs.DefaultMaxViolations) })
+
+	t.Run("should configure a positive maximum", func(t *testing.T) {
+		err := errs.SetMaxViolations(5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, errs.MaxViolations())
+	})
+
+	t.Run("should reject a non-positive maximum and keep the current one", func(t *testing.T) {
+		require.NoError(t, errs.SetMaxViolations(5))
+
+		err := errs.SetMaxViolations(0)
+
+		assert.ErrorIs(t, err, errs.ErrInvalidMaxViolations)
+		assert.Equal(t, 5, errs.MaxViolations())
+	})
+}
@@ -0,0 +1,61 @@
+package guard
+
+import "strings"
+
+// brazilianDDDs holds the area codes (DDD) assigned by Anatel.
+var brazilianDDDs = map[string]struct{}{
+	"11": {}, "12": {}, "13": {}, "14": {}, "15": {}, "16": {}, "17": {}, "18": {}, "19": {},
+	"21": {}, "22": {}, "24": {}, "27": {}, "28": {},
+	"31": {}, "32": {}, "33": {}, "34": {}, "35": {}, "37": {}, "38": {},
+	"41": {}, "42": {}, "43": {}, "44": {}, "45": {}, "46": {}, "47": {}, "48": {}, "49": {},
+	"51": {}, "53": {}, "54": {}, "55": {},
+	"61": {}, "62": {}, "63": {}, "64": {}, "65": {}, "66": {}, "67": {}, "68": {}, "69": {},
+	"71": {}, "73": {}, "74": {}, "75": {}, "77": {}, "79": {},
+	"81": {}, "82": {}, "83": {}, "84": {}, "85": {}, "86": {}, "87": {}, "88": {}, "89": {},
+	"91": {}, "92": {}, "93": {}, "94": {}, "95": {}, "96": {}, "97": {}, "98": {}, "99": {},
+}
+
+// CheckBrazilianPhone returns err if value is not a Brazilian phone number, or nil when
+// it is. Spaces, parentheses, hyphens and dots are ignored, so "(11) 91234-5678" and
+// "11912345678" are both accepted; any other non-digit character is rejected. The digits
+// must start with a valid DDD followed by either a 9-digit mobile number starting with 9
+// or an 8-digit landline number starting with 2 to 5.
+func CheckBrazilianPhone(value string, err error) error {
+	digits, ok := phoneDigits(value)
+	if !ok || (len(digits) != 10 && len(digits) != 11) {
+		return err
+	}
+
+	if _, ok := brazilianDDDs[digits[:2]]; !ok {
+		return err
+	}
+
+	first := digits[2]
+	switch len(digits) {
+	case 11:
+		if first != '9' {
+			return err
+		}
+	case 10:
+		if first < '2' || first > '5' {
+			return err
+		}
+	}
+	return nil
+}
+
+// phoneDigits strips the formatting characters of a phone number, reporting false when
+// value holds anything but digits and formatting.
+func phoneDigits(value string) (string, bool) {
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case strings.ContainsRune(" ()-.", r):
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
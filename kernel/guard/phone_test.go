@@ -0,0 +1,81 @@
+package guard_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckBrazilianPhone(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr error
+	}{
+		// ==================== Success cases ==================== //
+		{
+			name:    "should return nil for a formatted mobile number",
+			value:   "(11) 91234-5678",
+			wantErr: nil,
+		},
+		{
+			name:    "should return nil for a mobile number given as digits",
+			value:   "11912345678",
+			wantErr: nil,
+		},
+		{
+			name:    "should return nil for a formatted landline number",
+			value:   "(21) 3456-7890",
+			wantErr: nil,
+		},
+		{
+			name:    "should return nil for a landline number given as digits",
+			value:   "4834567890",
+			wantErr: nil,
+		},
+		// ==================== Failure cases ==================== //
+		{
+			name:    "should return error when the DDD does not exist",
+			value:   "(20) 91234-5678",
+			wantErr: sentinelErr,
+		},
+		{
+			name:    "should return error when the number is too short",
+			value:   "119123456",
+			wantErr: sentinelErr,
+		},
+		{
+			name:    "should return error when the number is too long",
+			value:   "119123456789",
+			wantErr: sentinelErr,
+		},
+		{
+			name:    "should return error when an 11-digit number is not a mobile",
+			value:   "11812345678",
+			wantErr: sentinelErr,
+		},
+		{
+			name:    "should return error when a 10-digit number is not a landline",
+			value:   "1191234567",
+			wantErr: sentinelErr,
+		},
+		{
+			name:    "should return error when value holds letters",
+			value:   "11 9123A-5678",
+			wantErr: sentinelErr,
+		},
+		{
+			name:    "should return error when value is empty",
+			value:   "",
+			wantErr: sentinelErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.CheckBrazilianPhone(tt.value, sentinelErr)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
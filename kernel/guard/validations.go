@@ -1,11 +1,29 @@
 package guard
 
 import (
+	"math"
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
 )
 
+// CheckAllOf runs every check in order and returns all of their failures joined with
+// [errs.Join], which caps how many are kept, or nil when every check passes. It lets
+// callers compose several conditions on the same field (e.g. non-blank and matching a
+// pattern) while still reporting each violation, so every failure can be inspected via
+// [errors.Is].
+func CheckAllOf(checks ...func() error) error {
+	failures := make([]error, 0, len(checks))
+	for _, check := range checks {
+		failures = append(failures, check())
+	}
+	return errs.Join(failures...)
+}
+
 // CheckMatchRegex returns err if value does not match the regular expression regex,
 // or nil when the value matches.
 func CheckMatchRegex(value string, regex *regexp.Regexp, err error) error {
@@ -24,6 +42,15 @@ func CheckNotNullOrWhiteSpace(value string, err error) error {
 	return nil
 }
 
+// CheckLength returns err if value holds fewer than min or more than max characters, or
+// nil otherwise. Characters are counted as runes, so accented text is measured as read.
+func CheckLength(value string, min, max int, err error) error {
+	if n := utf8.RuneCountInString(value); n < min || n > max {
+		return err
+	}
+	return nil
+}
+
 // CheckNotZeroOrNegative returns err if value is zero or negative (≤ 0),
 // or nil when value is strictly positive.
 func CheckNotZeroOrNegative(value float64, err error) error {
@@ -33,6 +60,36 @@ func CheckNotZeroOrNegative(value float64, err error) error {
 	return nil
 }
 
+// CheckNonNegative returns err if value is negative (< 0), or nil when value is zero or
+// positive. Unlike [CheckNotZeroOrNegative] it accepts zero, for amounts such as
+// discounts where zero is a legitimate value.
+func CheckNonNegative(value float64, err error) error {
+	if value < 0 {
+		return err
+	}
+	return nil
+}
+
+// CheckFinite returns err if value is NaN or infinite, or nil when value is a finite
+// number. NaN slips through ordered comparisons such as [CheckNotZeroOrNegative], so
+// amounts fed from external input should be checked with it first.
+func CheckFinite(value float64, err error) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return err
+	}
+	return nil
+}
+
+// CheckBefore returns err if a is after b, or nil when a is before or equal to b.
+// The bound is inclusive, so it validates ranges such as [from, to] where from == to
+// describes a single instant.
+func CheckBefore(a, b time.Time, err error) error {
+	if a.After(b) {
+		return err
+	}
+	return nil
+}
+
 // CheckNotNil returns err if value is nil, or nil when value is non-nil.
 // It is the inverse of [CheckNil] and is intended for validating pointer or interface
 // fields that must be set (e.g. a required transaction code).
@@ -78,3 +135,14 @@ func CheckNotZeroValue(value isZero, err error) error {
 	}
 	return nil
 }
+
+// CheckValidEnum returns err if v is not one of the values defined by its enum type, or
+// nil when it is. It replaces the parse-or-reject dance constructors would otherwise
+// repeat for every enum parameter; note the zero value is valid for enums that define
+// it, such as the NotInformed values.
+func CheckValidEnum[T interface{ IsValid() bool }](v T, err error) error {
+	if !v.IsValid() {
+		return err
+	}
+	return nil
+}
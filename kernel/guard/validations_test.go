@@ -1,16 +1,68 @@
 package guard_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"testing"
+	"time"
 
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var sentinelErr = fmt.Errorf("sentinel error")
 
+func TestCheckAllOf(t *testing.T) {
+	errFirst := fmt.Errorf("first error")
+	errThird := fmt.Errorf("third error")
+	pass := func() error { return nil }
+
+	t.Run("should return nil when every check passes", func(t *testing.T) {
+		err := guard.CheckAllOf(pass, pass, pass)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return nil when no checks are given", func(t *testing.T) {
+		err := guard.CheckAllOf()
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should join every failure when two of three checks fail", func(t *testing.T) {
+		err := guard.CheckAllOf(
+			func() error { return errFirst },
+			pass,
+			func() error { return errThird },
+		)
+
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, errFirst), "first failure should be present")
+		assert.True(t, errors.Is(err, errThird), "third failure should be present")
+	})
+
+	t.Run("should cap the failures kept when more checks fail than allowed", func(t *testing.T) {
+		t.Cleanup(func() { _ = errs.SetMaxViolations(errs.DefaultMaxViolations) })
+		require.NoError(t, errs.SetMaxViolations(1))
+
+		err := guard.CheckAllOf(
+			func() error { return errFirst },
+			pass,
+			func() error { return errThird },
+		)
+
+		assert.ErrorIs(t, err, errFirst)
+		assert.NotErrorIs(t, err, errThird, "failures past the cap should be dropped")
+		assert.ErrorIs(t, err, errs.ErrMoreViolations)
+	})
+}
+
 func TestCheckMatchRegex(t *testing.T) {
 	digitRegex := regexp.MustCompile(`^\d+$`)
 
@@ -84,6 +136,43 @@ func TestCheckNotNullOrWhiteSpace(t *testing.T) {
 	}
 }
 
+func TestCheckLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr error
+	}{
+		// ==================== Success cases ==================== //
+		{
+			name:    "should return nil when value is empty and min is zero",
+			value:   "",
+			wantErr: nil,
+		},
+		{
+			name:    "should return nil when value is exactly max runes long",
+			value:   "ação!",
+			wantErr: nil,
+		},
+		// ==================== Failure cases ==================== //
+		{
+			name:    "should return error when value is longer than max",
+			value:   "ações!",
+			wantErr: sentinelErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.CheckLength(tt.value, 0, 5, sentinelErr)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+
+	t.Run("should return error when value is shorter than min", func(t *testing.T) {
+		assert.Equal(t, sentinelErr, guard.CheckLength("ab", 3, 5, sentinelErr))
+	})
+}
+
 func TestCheckNotZeroOrNegative(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -122,6 +211,115 @@ func TestCheckNotZeroOrNegative(t *testing.T) {
 	}
 }
 
+func TestCheckNonNegative(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   float64
+		wantErr error
+	}{
+		// ==================== Success cases ==================== //
+		{
+			name:    "should return nil when value is positive",
+			value:   1.0,
+			wantErr: nil,
+		},
+		{
+			name:    "should return nil when value is zero",
+			value:   0.0,
+			wantErr: nil,
+		},
+		// ==================== Failure cases ==================== //
+		{
+			name:    "should return error when value is negative",
+			value:   -0.01,
+			wantErr: sentinelErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.CheckNonNegative(tt.value, sentinelErr)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestCheckFinite(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   float64
+		wantErr error
+	}{
+		// ==================== Success cases ==================== //
+		{
+			name:    "should return nil when value is finite",
+			value:   -12.5,
+			wantErr: nil,
+		},
+		// ==================== Failure cases ==================== //
+		{
+			name:    "should return error when value is NaN",
+			value:   math.NaN(),
+			wantErr: sentinelErr,
+		},
+		{
+			name:    "should return error when value is positive infinity",
+			value:   math.Inf(1),
+			wantErr: sentinelErr,
+		},
+		{
+			name:    "should return error when value is negative infinity",
+			value:   math.Inf(-1),
+			wantErr: sentinelErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.CheckFinite(tt.value, sentinelErr)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestCheckBefore(t *testing.T) {
+	base := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		a, b    time.Time
+		wantErr error
+	}{
+		// ==================== Success cases ==================== //
+		{
+			name:    "should return nil when a is before b",
+			a:       base,
+			b:       base.Add(time.Second),
+			wantErr: nil,
+		},
+		{
+			name:    "should return nil when a equals b since the bound is inclusive",
+			a:       base,
+			b:       base,
+			wantErr: nil,
+		},
+		// ==================== Failure cases ==================== //
+		{
+			name:    "should return error when a is after b",
+			a:       base.Add(time.Second),
+			b:       base,
+			wantErr: sentinelErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.CheckBefore(tt.a, tt.b, sentinelErr)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
 func TestCheckNotNil(t *testing.T) {
 	var typedNilPtr *string
 
@@ -191,3 +389,21 @@ func TestCheckNil(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckValidEnum(t *testing.T) {
+	t.Run("should return nil for a defined rounding mode", func(t *testing.T) {
+		assert.NoError(t, guard.CheckValidEnum(kernel.RoundingHalfEven, sentinelErr))
+	})
+
+	t.Run("should return error for an uninitialized rounding mode", func(t *testing.T) {
+		assert.Equal(t, sentinelErr, guard.CheckValidEnum(kernel.RoundingMode{}, sentinelErr))
+	})
+
+	t.Run("should return nil for a defined sex", func(t *testing.T) {
+		assert.NoError(t, guard.CheckValidEnum(types.SexFemale, sentinelErr))
+	})
+
+	t.Run("should return nil for an uninitialized sex, which means not informed", func(t *testing.T) {
+		assert.NoError(t, guard.CheckValidEnum(types.Sex{}, sentinelErr))
+	})
+}
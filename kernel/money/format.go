@@ -0,0 +1,87 @@
+// Package money formats monetary amounts for display. Amounts are still float64 across
+// the domain, so the functions here take a float amount in the currency of the locale.
+package money
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrUnsupportedLocale = errs.New("MONEY.UNSUPPORTED_LOCALE", "locale is not supported for money formatting")
+
+// localeFormat describes how a locale writes an amount: the currency symbol, whether a
+// space follows it, and the thousands and decimal separators.
+type localeFormat struct {
+	symbol    string
+	space     bool
+	thousands string
+	decimal   string
+}
+
+var locales = map[string]localeFormat{
+	"pt-BR": {symbol: "R$", space: true, thousands: ".", decimal: ","},
+	"en-US": {symbol: "$", thousands: ",", decimal: "."},
+}
+
+// DefaultLocale is the locale [Format] falls back to, unless overridden with
+// [SetDefaultLocale].
+const DefaultLocale = "pt-BR"
+
+var defaultLocale = kernel.NewSetting(DefaultLocale)
+
+// CurrentDefaultLocale returns the configured fallback locale.
+func CurrentDefaultLocale() string {
+	return defaultLocale.Load()
+}
+
+// SetDefaultLocale configures the locale [Format] uses for empty or unsupported locales.
+// locale must be supported, otherwise [ErrUnsupportedLocale] is returned.
+func SetDefaultLocale(locale string) error {
+	if _, ok := locales[locale]; !ok {
+		return ErrUnsupportedLocale
+	}
+	defaultLocale.Store(locale)
+	return nil
+}
+
+// Format writes amount with the currency symbol, digit grouping and decimal separator of
+// locale ("pt-BR" or "en-US"), e.g. 1234.56 as "R$ 1.234,56" or "$1,234.56". The amount
+// is first rounded to cents with [kernel.RoundCents], and negative amounts are prefixed
+// with a minus sign. An empty or unsupported locale falls back to the default locale.
+func Format(amount float64, locale string) string {
+	f, ok := locales[locale]
+	if !ok {
+		f = locales[defaultLocale.Load()]
+	}
+
+	cents := int64(math.Round(kernel.RoundCents(math.Abs(amount)) * 100))
+
+	var b strings.Builder
+	if amount < 0 && cents != 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(f.symbol)
+	if f.space {
+		b.WriteByte(' ')
+	}
+
+	units := strconv.FormatInt(cents/100, 10)
+	for i, digit := range units {
+		if i > 0 && (len(units)-i)%3 == 0 {
+			b.WriteString(f.thousands)
+		}
+		b.WriteRune(digit)
+	}
+
+	b.WriteString(f.decimal)
+	frac := strconv.FormatInt(cents%100, 10)
+	if len(frac) == 1 {
+		b.WriteByte('0')
+	}
+	b.WriteString(frac)
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount float64
+		locale string
+		want   string
+	}{
+		// ==================== Success cases ==================== //
+		{name: "should format pt-BR with dot grouping and comma decimals", amount: 1234.56, locale: "pt-BR", want: "R$ 1.234,56"},
+		{name: "should format en-US with comma grouping and dot decimals", amount: 1234.56, locale: "en-US", want: "$1,234.56"},
+		{name: "should group every three digits", amount: 1234567.8, locale: "pt-BR", want: "R$ 1.234.567,80"},
+		{name: "should not group amounts below a thousand", amount: 999.99, locale: "en-US", want: "$999.99"},
+		{name: "should pad cents with a leading zero", amount: 0.05, locale: "pt-BR", want: "R$ 0,05"},
+		{name: "should format zero", amount: 0, locale: "en-US", want: "$0.00"},
+		{name: "should prefix negative amounts with a minus sign", amount: -1234.5, locale: "pt-BR", want: "-R$ 1.234,50"},
+		{name: "should round to cents before formatting", amount: 10.005, locale: "en-US", want: "$10.01"},
+		{name: "should not sign an amount that rounds to zero", amount: -0.001, locale: "en-US", want: "$0.00"},
+		// ==================== Failure cases ==================== //
+		{name: "should default to pt-BR for an empty locale", amount: 1234.56, locale: "", want: "R$ 1.234,56"},
+		{name: "should default to pt-BR for an unsupported locale", amount: 1234.56, locale: "fr-FR", want: "R$ 1.234,56"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := money.Format(tt.amount, tt.locale)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetDefaultLocale(t *testing.T) {
+	t.Cleanup(func() { _ = money.SetDefaultLocale(money.DefaultLocale) })
+
+	t.Run("should default to pt-BR", func(t *testing.T) {
+		assert.Equal(t, "pt-BR", money.CurrentDefaultLocale())
+	})
+
+	t.Run("should use the configured locale as fallback", func(t *testing.T) {
+		err := money.SetDefaultLocale("en-US")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "$1,234.56", money.Format(1234.56, ""))
+	})
+
+	t.Run("should return an error for an unsupported locale", func(t *testing.T) {
+		err := money.SetDefaultLocale("fr-FR")
+
+		assert.ErrorIs(t, err, money.ErrUnsupportedLocale)
+		assert.Equal(t, "en-US", money.CurrentDefaultLocale(), "locale should be unchanged")
+	})
+}
@@ -0,0 +1,44 @@
+package kernel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Retry calls fn until it succeeds or attempts calls have been made, waiting
+// backoff(n) after the n-th failed attempt (n starts at 1); a nil backoff retries
+// immediately. A non-positive attempts still makes one call.
+//
+// When every attempt fails, the last error is returned wrapped with the number of
+// attempts made. Retry stops early when ctx is done, returning ctx.Err() joined with
+// the last error of fn, if any.
+func Retry(ctx context.Context, attempts int, backoff func(int) time.Duration, fn func() error) error {
+	attempts = max(attempts, 1)
+
+	var err error
+	for n := 1; ; n++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return errors.Join(ctxErr, err)
+		}
+		if err = fn(); err == nil {
+			return nil
+		}
+		if n == attempts {
+			return fmt.Errorf("after %d attempts: %w", n, err)
+		}
+
+		var wait time.Duration
+		if backoff != nil {
+			wait = backoff(n)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
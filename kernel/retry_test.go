@@ -0,0 +1,91 @@
+package kernel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTransient = errors.New("transient failure")
+
+func TestRetry(t *testing.T) {
+	t.Run("should stop retrying once fn succeeds", func(t *testing.T) {
+		calls := 0
+		var waits []int
+
+		err := kernel.Retry(context.Background(), 3, func(n int) time.Duration {
+			waits = append(waits, n)
+			return 0
+		}, func() error {
+			calls++
+			if calls == 1 {
+				return errTransient
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls, "fn should succeed on the second attempt")
+		assert.Equal(t, []int{1}, waits, "backoff should be asked once, after the first failure")
+	})
+
+	t.Run("should return the last error with the attempt count when attempts run out", func(t *testing.T) {
+		calls := 0
+
+		err := kernel.Retry(context.Background(), 3, nil, func() error {
+			calls++
+			return errTransient
+		})
+
+		assert.ErrorIs(t, err, errTransient)
+		assert.EqualError(t, err, "after 3 attempts: transient failure")
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("should call fn once when attempts is not positive", func(t *testing.T) {
+		calls := 0
+
+		err := kernel.Retry(context.Background(), 0, nil, func() error {
+			calls++
+			return errTransient
+		})
+
+		assert.ErrorIs(t, err, errTransient)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should abort when the context is cancelled while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+
+		err := kernel.Retry(ctx, 5, func(int) time.Duration {
+			cancel()
+			return time.Hour
+		}, func() error {
+			calls++
+			return errTransient
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, err, errTransient, "the last error of fn should be kept")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should not call fn when the context is already cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		calls := 0
+
+		err := kernel.Retry(ctx, 3, nil, func() error {
+			calls++
+			return nil
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, calls)
+	})
+}
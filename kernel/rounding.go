@@ -0,0 +1,110 @@
+package kernel
+
+import (
+	"math"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrInvalidRoundingMode = errs.New("KERNEL.INVALID_ROUNDING_MODE", "invalid rounding mode")
+
+// RoundingMode selects how monetary amounts are rounded to cents by [RoundCents].
+type RoundingMode struct{ value int }
+
+var (
+	RoundingHalfUp   = RoundingMode{1} // RoundingHalfUp rounds halves away from zero (2.675 → 2.68).
+	RoundingHalfEven = RoundingMode{2} // RoundingHalfEven rounds halves to the even cent, i.e. banker's rounding (2.665 → 2.66).
+	RoundingTruncate = RoundingMode{3} // RoundingTruncate drops fractions of a cent (2.679 → 2.67).
+)
+
+var roundingModeToString = map[RoundingMode]string{
+	RoundingHalfUp:   "half_up",
+	RoundingHalfEven: "half_even",
+	RoundingTruncate: "truncate",
+}
+
+// String returns the string representation of the RoundingMode.
+func (m RoundingMode) String() string {
+	if str, ok := roundingModeToString[m]; ok {
+		return str
+	}
+	return "unknown"
+}
+
+// MarshalText provides support for logging and any marshal needs.
+func (m RoundingMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidRoundingMode] and leave m unchanged instead of decoding to an uninitialized RoundingMode.
+func (m *RoundingMode) UnmarshalText(text []byte) error {
+	for v, str := range roundingModeToString {
+		if str == string(text) {
+			*m = v
+			return nil
+		}
+	}
+	return ErrInvalidRoundingMode
+}
+
+// Equals checks if two RoundingMode values are equal.
+func (m RoundingMode) Equals(other RoundingMode) bool {
+	return m.value == other.value
+}
+
+// IsValid reports whether the RoundingMode is one of the defined values; the zero value is not.
+func (m RoundingMode) IsValid() bool {
+	_, ok := roundingModeToString[m]
+	return ok
+}
+
+// ParseRoundingMode converts an int to the corresponding RoundingMode value.
+// If the input does not match any known rounding mode, it returns an error and an empty RoundingMode value.
+func ParseRoundingMode(value int) (RoundingMode, error) {
+	m := RoundingMode{value: value}
+	if _, ok := roundingModeToString[m]; !ok {
+		return RoundingMode{}, ErrInvalidRoundingMode
+	}
+	return m, nil
+}
+
+// DefaultRoundingMode is the rounding applied unless overridden with [SetRoundingMode].
+var DefaultRoundingMode = RoundingHalfUp
+
+var roundingMode = NewSetting(DefaultRoundingMode)
+
+// CurrentRoundingMode returns the configured rounding mode.
+func CurrentRoundingMode() RoundingMode {
+	return roundingMode.Load()
+}
+
+// SetRoundingMode configures the rounding applied by [RoundCents] to every calculated
+// amount.
+func SetRoundingMode(m RoundingMode) error {
+	if _, ok := roundingModeToString[m]; !ok {
+		return ErrInvalidRoundingMode
+	}
+	roundingMode.Store(m)
+	return nil
+}
+
+// RoundCents rounds amount to cents following the configured [RoundingMode]. Domain
+// code must call it for every calculated amount (taxes, fees, percentage discounts).
+//
+// Amounts such as 2.675 have no exact binary representation (it is stored as
+// 2.67499…), so the amount in cents is first snapped to a millionth of a cent to drop
+// that noise; otherwise 2.675 would round down under every mode.
+func RoundCents(amount float64) float64 {
+	cents := math.Round(amount*100*1e6) / 1e6
+	mode := roundingMode.Load()
+	switch {
+	case mode.Equals(RoundingHalfEven):
+		cents = math.RoundToEven(cents)
+	case mode.Equals(RoundingTruncate):
+		cents = math.Trunc(cents)
+	default:
+		cents = math.Round(cents)
+	}
+	return cents / 100
+}
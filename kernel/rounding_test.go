@@ -0,0 +1,145 @@
+package kernel_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundingMode_String(t *testing.T) {
+	// ==================== Success cases ==================== //
+	tests := []struct {
+		name string
+		mode kernel.RoundingMode
+		want string
+	}{
+		{name: "should return 'half_up' for RoundingHalfUp", mode: kernel.RoundingHalfUp, want: "half_up"},
+		{name: "should return 'half_even' for RoundingHalfEven", mode: kernel.RoundingHalfEven, want: "half_even"},
+		{name: "should return 'truncate' for RoundingTruncate", mode: kernel.RoundingTruncate, want: "truncate"},
+		// ==================== Failure cases ==================== //
+		{name: "should return 'unknown' for an unrecognized mode value", mode: kernel.RoundingMode{}, want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.mode.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRoundingMode_MarshalText(t *testing.T) {
+	got, err := kernel.RoundingHalfEven.MarshalText()
+
+	require.NoError(t, err)
+	assert.Equal(t, "half_even", string(got))
+}
+
+func TestRoundingMode_UnmarshalText(t *testing.T) {
+	// ==================== Success cases ==================== //
+	successTests := []struct {
+		name string
+		text string
+		want kernel.RoundingMode
+	}{
+		{name: "should unmarshal 'half_up' to RoundingHalfUp", text: "half_up", want: kernel.RoundingHalfUp},
+		{name: "should unmarshal 'truncate' to RoundingTruncate", text: "truncate", want: kernel.RoundingTruncate},
+	}
+	for _, tt := range successTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got kernel.RoundingMode
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	failureTests := []struct {
+		name string
+		text string
+	}{
+		{name: "should return an error for a misspelled rounding mode", text: "half_up_typo"},
+		{name: "should return an error for the 'unknown' placeholder", text: "unknown"},
+		{name: "should return an error for an empty string", text: ""},
+	}
+	for _, tt := range failureTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := kernel.RoundingTruncate
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			assert.ErrorIs(t, err, kernel.ErrInvalidRoundingMode)
+			assert.Equal(t, kernel.RoundingTruncate, got, "value should be unchanged")
+		})
+	}
+}
+
+func TestRoundingMode_Equals(t *testing.T) {
+	assert.True(t, kernel.RoundingTruncate.Equals(kernel.RoundingTruncate))
+	assert.False(t, kernel.RoundingHalfUp.Equals(kernel.RoundingHalfEven))
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	// ==================== Success cases ==================== //
+	t.Run("should parse 2 to RoundingHalfEven", func(t *testing.T) {
+		got, err := kernel.ParseRoundingMode(2)
+
+		require.NoError(t, err)
+		assert.Equal(t, kernel.RoundingHalfEven, got)
+	})
+
+	// ==================== Failure cases ==================== //
+	t.Run("should return an error for an out-of-range value", func(t *testing.T) {
+		got, err := kernel.ParseRoundingMode(999)
+
+		assert.ErrorIs(t, err, kernel.ErrInvalidRoundingMode)
+		assert.Equal(t, kernel.RoundingMode{}, got)
+	})
+}
+
+func TestSetRoundingMode(t *testing.T) {
+	t.Cleanup(func() { _ = kernel.SetRoundingMode(kernel.DefaultRoundingMode) })
+
+	t.Run("should default to half-up", func(t *testing.T) {
+		assert.Equal(t, kernel.RoundingHalfUp, kernel.CurrentRoundingMode())
+	})
+
+	t.Run("should return an error and keep the current mode when the mode is unknown", func(t *testing.T) {
+		err := kernel.SetRoundingMode(kernel.RoundingMode{})
+
+		assert.ErrorIs(t, err, kernel.ErrInvalidRoundingMode)
+		assert.Equal(t, kernel.RoundingHalfUp, kernel.CurrentRoundingMode())
+	})
+}
+
+func TestRoundCents(t *testing.T) {
+	t.Cleanup(func() { _ = kernel.SetRoundingMode(kernel.DefaultRoundingMode) })
+
+	tests := []struct {
+		name   string
+		mode   kernel.RoundingMode
+		amount float64
+		want   float64
+	}{
+		{name: "should round 2.675 to 2.68 half-up", mode: kernel.RoundingHalfUp, amount: 2.675, want: 2.68},
+		{name: "should round 2.675 to 2.68 half-even", mode: kernel.RoundingHalfEven, amount: 2.675, want: 2.68},
+		{name: "should truncate 2.675 to 2.67", mode: kernel.RoundingTruncate, amount: 2.675, want: 2.67},
+		{name: "should round 2.665 to the even cent 2.66 half-even", mode: kernel.RoundingHalfEven, amount: 2.665, want: 2.66},
+		{name: "should keep an exact amount under truncate", mode: kernel.RoundingTruncate, amount: 2.68, want: 2.68},
+		{name: "should round a negative half away from zero half-up", mode: kernel.RoundingHalfUp, amount: -2.675, want: -2.68},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, kernel.SetRoundingMode(tt.mode))
+
+			got := kernel.RoundCents(tt.amount)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package kernel
+
+import "sync/atomic"
+
+// Setting holds a process-wide configuration value, such as a rounding mode or a limit,
+// that requests may read while it is being changed: loads and stores are atomic. A value
+// held by reference, such as a map or slice, must not be changed once stored; store a
+// new one instead.
+type Setting[T any] struct {
+	v atomic.Pointer[T]
+}
+
+// NewSetting returns a [Setting] holding initial.
+func NewSetting[T any](initial T) *Setting[T] {
+	s := &Setting[T]{}
+	s.Store(initial)
+	return s
+}
+
+// Load returns the current value, or the zero value of T when none was stored.
+func (s *Setting[T]) Load() T {
+	if p := s.v.Load(); p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+// Store replaces the current value with v.
+func (s *Setting[T]) Store(v T) {
+	s.v.Store(&v)
+}
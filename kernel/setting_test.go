@@ -0,0 +1,38 @@
+package kernel_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetting(t *testing.T) {
+	t.Run("should return the initial value until another is stored", func(t *testing.T) {
+		s := kernel.NewSetting(10)
+
+		assert.Equal(t, 10, s.Load())
+		s.Store(20)
+		assert.Equal(t, 20, s.Load())
+	})
+
+	t.Run("should return the zero value when none was stored", func(t *testing.T) {
+		var s kernel.Setting[string]
+
+		assert.Empty(t, s.Load())
+	})
+
+	t.Run("should allow loads while the value is being stored", func(t *testing.T) {
+		s := kernel.NewSetting(0)
+
+		var wg sync.WaitGroup
+		for i := range 50 {
+			wg.Go(func() { s.Store(i) })
+			wg.Go(func() { _ = s.Load() })
+		}
+		wg.Wait()
+
+		assert.GreaterOrEqual(t, s.Load(), 0)
+	})
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
+)
+
+var ErrInvalidEmail = errs.New("EMAIL.INVALID", "invalid email address")
+
+// MaxEmailLength is the maximum number of characters of an [Email] (RFC 5321).
+const MaxEmailLength = 254
+
+// Regular expression for a plausible email address: a local part, an "@" and a domain
+// with at least one dot. It is lenient on purpose; only the mail server can tell whether
+// the address exists.
+var emailRegex = regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9\-]+(\.[a-z0-9\-]+)*\.[a-z]{2,}$`)
+
+// Email is an immutable value object holding an email address in its canonical form:
+// without surrounding whitespace and in lowercase. The zero value is not a valid Email.
+type Email struct{ value string }
+
+// NewEmail returns s as a canonical [Email]. s is trimmed and lowercased, then must look
+// like an address ("name@example.com") of at most [MaxEmailLength] characters, otherwise
+// [ErrInvalidEmail] is returned.
+func NewEmail(s string) (Email, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if err := guard.CheckLength(s, 1, MaxEmailLength, ErrInvalidEmail); err != nil {
+		return Email{}, err
+	}
+	if err := guard.CheckMatchRegex(s, emailRegex, ErrInvalidEmail); err != nil {
+		return Email{}, err
+	}
+	return Email{value: s}, nil
+}
+
+// String returns the canonical address.
+func (e Email) String() string {
+	return e.value
+}
+
+// MarshalText provides support for logging and any marshal needs.
+func (e Email) MarshalText() ([]byte, error) {
+	return []byte(e.value), nil
+}
+
+// UnmarshalText parses text as [NewEmail] does. Invalid addresses return
+// [ErrInvalidEmail] and leave e unchanged.
+func (e *Email) UnmarshalText(text []byte) error {
+	parsed, err := NewEmail(string(text))
+	if err != nil {
+		return err
+	}
+	*e = parsed
+	return nil
+}
+
+// Equals checks if two Email values hold the same canonical address.
+func (e Email) Equals(other Email) bool {
+	return e.value == other.value
+}
+
+// IsZero reports whether the Email is uninitialized.
+func (e Email) IsZero() bool {
+	return e.value == ""
+}
@@ -0,0 +1,76 @@
+package types_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEmail(t *testing.T) {
+	// ==================== Success cases ==================== //
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "A@B.COM", want: "a@b.com"},
+		{input: "  Maria.Silva+pedidos@Exemplo.com.br ", want: "maria.silva+pedidos@exemplo.com.br"},
+	}
+	for _, tt := range tests {
+		t.Run("should normalize "+tt.input, func(t *testing.T) {
+			got, err := types.NewEmail(tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	for _, input := range []string{"", "   ", "plain", "a@b", "@b.com", "a@@b.com", "a b@c.com", strings.Repeat("a", 250) + "@b.com"} {
+		t.Run("should reject "+input, func(t *testing.T) {
+			got, err := types.NewEmail(input)
+
+			assert.ErrorIs(t, err, types.ErrInvalidEmail)
+			assert.True(t, got.IsZero())
+		})
+	}
+}
+
+func TestEmail_Equals(t *testing.T) {
+	a, err := types.NewEmail("Ana@Example.com")
+	require.NoError(t, err)
+	b, err := types.NewEmail("ana@example.COM")
+	require.NoError(t, err)
+	c, err := types.NewEmail("bia@example.com")
+	require.NoError(t, err)
+
+	assert.True(t, a.Equals(b), "addresses differing only in case should be equal")
+	assert.False(t, a.Equals(c))
+}
+
+func TestEmail_JSON(t *testing.T) {
+	t.Run("should round-trip through the canonical text", func(t *testing.T) {
+		email, err := types.NewEmail("Ana@Example.com")
+		require.NoError(t, err)
+
+		data, err := json.Marshal(email)
+		require.NoError(t, err)
+		var got types.Email
+		require.NoError(t, json.Unmarshal(data, &got))
+
+		assert.JSONEq(t, `"ana@example.com"`, string(data))
+		assert.True(t, email.Equals(got))
+	})
+
+	t.Run("should reject an invalid address and leave the value unchanged", func(t *testing.T) {
+		var got types.Email
+
+		err := json.Unmarshal([]byte(`"not-an-email"`), &got)
+
+		assert.ErrorIs(t, err, types.ErrInvalidEmail)
+		assert.True(t, got.IsZero())
+	})
+}
@@ -34,11 +34,30 @@ func (s Sex) MarshalText() ([]byte, error) {
 	return []byte(s.String()), nil
 }
 
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidSex] and leave s unchanged instead of decoding to SexNotInformed.
+func (s *Sex) UnmarshalText(text []byte) error {
+	for v, str := range sexToString {
+		if str == string(text) {
+			*s = v
+			return nil
+		}
+	}
+	return ErrInvalidSex
+}
+
 // Equals checks if two Sex values are equal.
 func (s Sex) Equals(other Sex) bool {
 	return s.value == other.value
 }
 
+// IsValid reports whether the Sex is one of the defined values, including the
+// zero value SexNotInformed.
+func (s Sex) IsValid() bool {
+	_, ok := sexToString[s]
+	return ok
+}
+
 // ParseSex converts an int to the corresponding Sex value.
 // If the input does not match any known value, it returns an error and an empty Sex value.
 func ParseSex(value int) (Sex, error) {
@@ -38,11 +38,30 @@ func (m MaritalStatus) MarshalText() ([]byte, error) {
 	return []byte(m.String()), nil
 }
 
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidMaritalStatus] and leave m unchanged instead of decoding to MaritalStatusNotInformed.
+func (m *MaritalStatus) UnmarshalText(text []byte) error {
+	for v, str := range maritalStatusToString {
+		if str == string(text) {
+			*m = v
+			return nil
+		}
+	}
+	return ErrInvalidMaritalStatus
+}
+
 // Equals checks if two MaritalStatus values are equal.
 func (m MaritalStatus) Equals(other MaritalStatus) bool {
 	return m.value == other.value
 }
 
+// IsValid reports whether the MaritalStatus is one of the defined values, including the
+// zero value MaritalStatusNotInformed.
+func (m MaritalStatus) IsValid() bool {
+	_, ok := maritalStatusToString[m]
+	return ok
+}
+
 // ParseMaritalStatus converts an int to the corresponding MaritalStatus value.
 // If the input does not match any known value, it returns an error and an empty MaritalStatus value.
 func ParseMaritalStatus(value int) (MaritalStatus, error) {
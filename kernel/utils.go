@@ -1,6 +1,9 @@
 package kernel
 
 import (
+	"strconv"
+	"sync/atomic"
+
 	"github.com/oklog/ulid/v2"
 )
 
@@ -20,3 +23,29 @@ func Must[T any](x T, err error) T {
 func NewID() ulid.ULID {
 	return ulid.Make()
 }
+
+var idGenerator = func() string { return NewID().String() }
+
+// GenerateID returns a new identifier from the installed ID generator, which defaults
+// to a ULID string (see [NewID]). Domain code must call it to assign entity and event IDs.
+func GenerateID() string {
+	return idGenerator()
+}
+
+// SetIDGenerator installs gen as the package-level ID generator and returns a function
+// that restores the previous one. It is meant for tests; pair it with t.Cleanup.
+// Not safe for concurrent use.
+func SetIDGenerator(gen func() string) (restore func()) {
+	previous := idGenerator
+	idGenerator = gen
+	return func() { idGenerator = previous }
+}
+
+// SequentialIDGenerator returns a deterministic generator producing prefix-1, prefix-2,
+// and so on. It is safe for concurrent use.
+func SequentialIDGenerator(prefix string) func() string {
+	var n atomic.Int64
+	return func() string {
+		return prefix + "-" + strconv.FormatInt(n.Add(1), 10)
+	}
+}
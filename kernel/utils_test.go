@@ -26,3 +26,24 @@ func TestNewID(t *testing.T) {
 	id := kernel.NewID()
 	assert.NotZero(t, id)
 }
+
+func TestGenerateID(t *testing.T) {
+	t.Run("should return a ULID string by default", func(t *testing.T) {
+		got := kernel.GenerateID()
+
+		assert.Len(t, got, 26)
+		assert.NotEqual(t, got, kernel.GenerateID(), "IDs should be unique")
+	})
+
+	t.Run("should use the installed generator until restored", func(t *testing.T) {
+		restore := kernel.SetIDGenerator(kernel.SequentialIDGenerator("id"))
+
+		assert.Equal(t, "id-1", kernel.GenerateID())
+		assert.Equal(t, "id-2", kernel.GenerateID())
+		assert.Equal(t, "id-3", kernel.NewAggregateRoot().ID)
+
+		restore()
+
+		assert.Len(t, kernel.GenerateID(), 26, "restore should reinstall the ULID generator")
+	})
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+// OrderCreator is the use case driven by [CreateOrderHandler], implemented by
+// [app.CreateOrderHandler].
+type OrderCreator interface {
+	Handle(ctx context.Context, cmd app.CreateOrderCommand) (*order.Order, error)
+}
+
+// CreateOrderHandler serves POST requests creating an order from a [CreateOrderRequest].
+// It answers 201 Created with the [OrderResponse] of the new order, whose "id" names it,
+// or the status and body derived from the domain error. Other methods are answered with
+// 405 Method Not Allowed and bodies over [MaxRequestBodyBytes] with 413 Request Entity
+// Too Large.
+type CreateOrderHandler struct {
+	creator OrderCreator
+}
+
+// NewCreateOrderHandler creates a [CreateOrderHandler].
+func NewCreateOrderHandler(creator OrderCreator) *CreateOrderHandler {
+	return &CreateOrderHandler{creator: creator}
+}
+
+// ServeHTTP implements [nethttp.Handler].
+func (h *CreateOrderHandler) ServeHTTP(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if r.Method != nethttp.MethodPost {
+		w.Header().Set("Allow", nethttp.MethodPost)
+		writeErrorStatus(w, nethttp.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return
+	}
+
+	var req CreateOrderRequest
+	if err := json.NewDecoder(nethttp.MaxBytesReader(w, r.Body, MaxRequestBodyBytes)).Decode(&req); err != nil {
+		var tooLarge *nethttp.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeErrorStatus(w, nethttp.StatusRequestEntityTooLarge, ErrRequestTooLarge)
+			return
+		}
+		writeError(w, ErrMalformedRequest.Wrap(err))
+		return
+	}
+
+	cmd, err := fromCreateRequest(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	o, err := h.creator.Handle(r.Context(), cmd)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, nethttp.StatusCreated, toOrderResponse(o))
+}
@@ -0,0 +1,131 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	orderhttp "github.com/marcosvieirajr/sales-ddd-hexagonal/order/adapters/http"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validCreateOrderBody = `{
+	"customer_id": "cust-123",
+	"delivery_address": {
+		"cep": "12345-678", "street": "Rua das Flores", "number": "100", "complement": "",
+		"district": "Centro", "city": "São Paulo", "state": "SP", "country": "Brasil"
+	},
+	"items": [{"product_id": "prod-1", "product_name": "Widget", "unit_price": 50, "quantity": 2}]
+}`
+
+// creatorFunc adapts a function to [orderhttp.OrderCreator].
+type creatorFunc func(ctx context.Context, cmd app.CreateOrderCommand) (*order.Order, error)
+
+func (f creatorFunc) Handle(ctx context.Context, cmd app.CreateOrderCommand) (*order.Order, error) {
+	return f(ctx, cmd)
+}
+
+func serveCreateOrder(t *testing.T, creator orderhttp.OrderCreator, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(nethttp.MethodPost, "/orders", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	orderhttp.NewCreateOrderHandler(creator).ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeErrors(t *testing.T, rec *httptest.ResponseRecorder) errs.Response {
+	t.Helper()
+	var got errs.Response
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	return got
+}
+
+func TestCreateOrderHandler(t *testing.T) {
+	t.Run("should answer 201 with the created order", func(t *testing.T) {
+		repo := memory.NewOrderRepository()
+		creator := app.NewCreateOrderHandler(repo, memory.NewSequentialNumbers("PED"), memory.NewOutbox())
+
+		rec := serveCreateOrder(t, creator, validCreateOrderBody)
+
+		require.Equal(t, nethttp.StatusCreated, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var got orderhttp.OrderResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+		saved, err := repo.FindByID(context.Background(), got.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "cust-123", saved.CustomerID)
+		assert.Equal(t, 100.0, saved.TotalAmount)
+		assert.Equal(t, "pending", got.Status)
+		assert.Equal(t, "Rua das Flores", got.DeliveryAddress.Street)
+	})
+
+	t.Run("should answer 400 listing every validation failure", func(t *testing.T) {
+		creator := app.NewCreateOrderHandler(memory.NewOrderRepository(), memory.NewSequentialNumbers("PED"), memory.NewOutbox())
+		body := strings.Replace(validCreateOrderBody, `"cust-123"`, `" "`, 1)
+		body = strings.Replace(body, `"quantity": 2`, `"quantity": 0`, 1)
+
+		rec := serveCreateOrder(t, creator, body)
+
+		assert.Equal(t, nethttp.StatusBadRequest, rec.Code)
+		got := decodeErrors(t, rec)
+		assert.ElementsMatch(t, []map[string]string{
+			{"code": "ORDER.INVALID_CUSTOMER_ID", "message": order.ErrInvalidCustomerID.Message},
+			{"code": "ORDER_ITEM.INVALID_QUANTITY", "message": "quantity must be greater than zero"},
+		}, got.Errors)
+	})
+
+	t.Run("should answer 400 for a malformed body", func(t *testing.T) {
+		rec := serveCreateOrder(t, creatorFunc(func(context.Context, app.CreateOrderCommand) (*order.Order, error) {
+			t.Fatal("the use case should not run")
+			return nil, nil
+		}), `{"customer_id":`)
+
+		assert.Equal(t, nethttp.StatusBadRequest, rec.Code)
+		assert.Equal(t, "HTTP.MALFORMED_REQUEST", decodeErrors(t, rec).Errors[0]["code"])
+	})
+
+	t.Run("should answer 405 for a method other than POST", func(t *testing.T) {
+		req := httptest.NewRequest(nethttp.MethodGet, "/orders", nil)
+		rec := httptest.NewRecorder()
+
+		orderhttp.NewCreateOrderHandler(creatorFunc(func(context.Context, app.CreateOrderCommand) (*order.Order, error) {
+			t.Fatal("the use case should not run")
+			return nil, nil
+		})).ServeHTTP(rec, req)
+
+		assert.Equal(t, nethttp.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, nethttp.MethodPost, rec.Header().Get("Allow"))
+		assert.Equal(t, "HTTP.METHOD_NOT_ALLOWED", decodeErrors(t, rec).Errors[0]["code"])
+	})
+
+	t.Run("should answer 413 for a body over the limit", func(t *testing.T) {
+		body := `{"customer_id": "` + strings.Repeat("a", orderhttp.MaxRequestBodyBytes) + `"}`
+
+		rec := serveCreateOrder(t, creatorFunc(func(context.Context, app.CreateOrderCommand) (*order.Order, error) {
+			t.Fatal("the use case should not run")
+			return nil, nil
+		}), body)
+
+		assert.Equal(t, nethttp.StatusRequestEntityTooLarge, rec.Code)
+		assert.Equal(t, "HTTP.REQUEST_TOO_LARGE", decodeErrors(t, rec).Errors[0]["code"])
+	})
+
+	t.Run("should answer 409 when the use case reports a conflict", func(t *testing.T) {
+		creator := creatorFunc(func(context.Context, app.CreateOrderCommand) (*order.Order, error) {
+			return nil, order.ErrOrderNumberAlreadyAssigned
+		})
+
+		rec := serveCreateOrder(t, creator, validCreateOrderBody)
+
+		assert.Equal(t, nethttp.StatusConflict, rec.Code)
+		assert.Equal(t, "ORDER.NUMBER_ALREADY_ASSIGNED", decodeErrors(t, rec).Errors[0]["code"])
+	})
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+// Address is the delivery address as exchanged over the API, both in requests and in
+// responses.
+type Address struct {
+	CEP        string `json:"cep"`
+	Street     string `json:"street"`
+	Number     string `json:"number"`
+	Complement string `json:"complement"`
+	District   string `json:"district"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	Country    string `json:"country"`
+}
+
+// CreateOrderItemRequest is a line of a [CreateOrderRequest].
+type CreateOrderItemRequest struct {
+	ProductID   string  `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	UnitPrice   float64 `json:"unit_price"`
+	Quantity    int     `json:"quantity"`
+}
+
+// CreateOrderRequest is the body accepted by [CreateOrderHandler].
+type CreateOrderRequest struct {
+	CustomerID      string                   `json:"customer_id"`
+	DeliveryAddress Address                  `json:"delivery_address"`
+	Items           []CreateOrderItemRequest `json:"items"`
+}
+
+// OrderItemResponse is a line item of an [OrderResponse].
+type OrderItemResponse struct {
+	ID              string  `json:"id"`
+	ProductID       string  `json:"product_id"`
+	ProductName     string  `json:"product_name"`
+	UnitPrice       float64 `json:"unit_price"`
+	Quantity        int     `json:"quantity"`
+	DiscountApplied float64 `json:"discount_applied"`
+	DiscountSource  string  `json:"discount_source,omitempty"` // e.g. "coupon"; empty when no discount is applied
+	TotalPrice      float64 `json:"total_price"`
+	Note            string  `json:"note"`
+}
+
+// PaymentResponse is a payment of an [OrderResponse]. The transaction code is left out.
+type PaymentResponse struct {
+	ID     string  `json:"id"`
+	Method string  `json:"method"`
+	Status string  `json:"status"`
+	Amount float64 `json:"amount"`
+}
+
+// OrderResponse is the API representation of an order. Enums are rendered by name.
+type OrderResponse struct {
+	ID              string              `json:"id"`
+	Number          string              `json:"number"`
+	CustomerID      string              `json:"customer_id"`
+	Status          string              `json:"status"`
+	ShippingMethod  string              `json:"shipping_method"`
+	DeliveryAddress Address             `json:"delivery_address"`
+	Items           []OrderItemResponse `json:"items"`
+	Payments        []PaymentResponse   `json:"payments"`
+	DiscountAmount  float64             `json:"discount_amount"`
+	TaxAmount       float64             `json:"tax_amount"`
+	TotalAmount     float64             `json:"total_amount"`
+	IsGift          bool                `json:"is_gift"`
+	OnHold          bool                `json:"on_hold"`
+	Instructions    string              `json:"instructions"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       *time.Time          `json:"updated_at"`
+}
+
+// fromCreateRequest maps req to the command of the CreateOrder use case, returning the
+// violations of [order.NewDeliveryAddress] when the address is invalid.
+func fromCreateRequest(req CreateOrderRequest) (app.CreateOrderCommand, error) {
+	a := req.DeliveryAddress
+	address, err := order.NewDeliveryAddress(a.CEP, a.Street, a.Number, a.Complement, a.District, a.City, a.State, a.Country)
+	if err != nil {
+		return app.CreateOrderCommand{}, err
+	}
+
+	items := make([]app.CreateOrderItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, app.CreateOrderItem{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			UnitPrice:   item.UnitPrice,
+			Quantity:    item.Quantity,
+		})
+	}
+
+	return app.CreateOrderCommand{CustomerID: req.CustomerID, DeliveryAddress: address, Items: items}, nil
+}
+
+// toOrderResponse maps o to its API representation, reading the unexported parts of the
+// aggregate through its getters.
+func toOrderResponse(o *order.Order) OrderResponse {
+	address := o.DeliveryAddress
+	res := OrderResponse{
+		ID:             o.ID,
+		Number:         o.Number,
+		CustomerID:     o.CustomerID,
+		Status:         o.Status.String(),
+		ShippingMethod: o.ShippingMethod.String(),
+		DeliveryAddress: Address{
+			CEP:        address.CEP(),
+			Street:     address.Street(),
+			Number:     address.Number(),
+			Complement: address.Complement(),
+			District:   address.District(),
+			City:       address.City(),
+			State:      address.State(),
+			Country:    address.Country(),
+		},
+		Items:          make([]OrderItemResponse, 0, len(o.Items())),
+		Payments:       make([]PaymentResponse, 0, len(o.Payments())),
+		DiscountAmount: o.DiscountAmount,
+		TaxAmount:      o.TaxAmount(),
+		TotalAmount:    o.TotalAmount,
+		IsGift:         o.IsGift,
+		OnHold:         o.OnHold,
+		Instructions:   o.Instructions,
+		CreatedAt:      o.CreatedAt,
+		UpdatedAt:      o.UpdatedAt,
+	}
+
+	for _, item := range o.Items() {
+		var source string
+		if s, ok := item.DiscountSourceValue(); ok {
+			source = s.String()
+		}
+		res.Items = append(res.Items, OrderItemResponse{
+			ID:              item.ID,
+			ProductID:       item.ProductID,
+			ProductName:     item.ProductName,
+			UnitPrice:       item.UnitPrice,
+			Quantity:        item.Quantity,
+			DiscountApplied: item.DiscountApplied,
+			DiscountSource:  source,
+			TotalPrice:      item.TotalPrice,
+			Note:            item.Note,
+		})
+	}
+	for _, p := range o.Payments() {
+		res.Payments = append(res.Payments, PaymentResponse{
+			ID:     p.ID,
+			Method: p.Method.String(),
+			Status: p.Status.String(),
+			Amount: p.Amount,
+		})
+	}
+	return res
+}
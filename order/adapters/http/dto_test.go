@@ -0,0 +1,87 @@
+package http
+
+import (
+	"testing"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var validAddress = Address{
+	CEP:        "12345-678",
+	Street:     "Rua das Flores",
+	Number:     "100",
+	Complement: "Apto 12",
+	District:   "Centro",
+	City:       "São Paulo",
+	State:      "SP",
+	Country:    "Brasil",
+}
+
+func TestFromCreateRequest(t *testing.T) {
+	t.Run("should map the request to the CreateOrder command", func(t *testing.T) {
+		req := CreateOrderRequest{
+			CustomerID:      "cust-123",
+			DeliveryAddress: validAddress,
+			Items:           []CreateOrderItemRequest{{ProductID: "prod-1", ProductName: "Widget", UnitPrice: 50, Quantity: 2}},
+		}
+
+		got, err := fromCreateRequest(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "cust-123", got.CustomerID)
+		want, err := order.NewDeliveryAddress("12345-678", "Rua das Flores", "100", "Apto 12", "Centro", "São Paulo", "SP", "Brasil")
+		require.NoError(t, err)
+		assert.Equal(t, want, got.DeliveryAddress)
+		require.Len(t, got.Items, 1)
+		assert.Equal(t, "prod-1", got.Items[0].ProductID)
+		assert.Equal(t, "Widget", got.Items[0].ProductName)
+		assert.Equal(t, 50.0, got.Items[0].UnitPrice)
+		assert.Equal(t, 2, got.Items[0].Quantity)
+	})
+
+	t.Run("should return the violations of an invalid address", func(t *testing.T) {
+		address := validAddress
+		address.CEP = "12345678"
+		address.State = "XX"
+
+		_, err := fromCreateRequest(CreateOrderRequest{CustomerID: "cust-123", DeliveryAddress: address})
+
+		assert.ErrorIs(t, err, order.ErrInvalidCEP)
+		assert.ErrorIs(t, err, order.ErrInvalidState)
+	})
+}
+
+func TestToOrderResponse(t *testing.T) {
+	t.Run("should map the order with its address, items, payments and enum names", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t, testfixtures.WithAddress(testfixtures.ValidAddress(t, testfixtures.WithComplement("Apto 12"))))
+		_, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+
+		got := toOrderResponse(o)
+
+		assert.Equal(t, o.ID, got.ID)
+		assert.Equal(t, o.Number, got.Number)
+		assert.Equal(t, o.CustomerID, got.CustomerID)
+		assert.Equal(t, "pending", got.Status)
+		assert.Equal(t, "standard", got.ShippingMethod)
+		assert.Equal(t, validAddress, got.DeliveryAddress)
+		require.Len(t, got.Items, len(o.Items()))
+		assert.Equal(t, o.Items()[0].ProductID, got.Items[0].ProductID)
+		require.Len(t, got.Payments, 1)
+		assert.Equal(t, "credit_card", got.Payments[0].Method)
+		assert.Equal(t, "pending", got.Payments[0].Status)
+		assert.Equal(t, o.TotalAmount, got.TotalAmount)
+	})
+
+	t.Run("should render empty lists for an order without items or payments", func(t *testing.T) {
+		got := toOrderResponse(testfixtures.ValidOrder(t, testfixtures.WithoutItems()))
+
+		assert.NotNil(t, got.Items)
+		assert.Empty(t, got.Items)
+		assert.NotNil(t, got.Payments)
+	})
+}
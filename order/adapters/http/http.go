@@ -0,0 +1,37 @@
+// Package http provides the net/http driving adapters of the order bounded context.
+// Handlers decode requests into use case commands and render domain errors through
+// [errs.HTTPStatus] and [errs.ToResponse].
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var (
+	ErrMalformedRequest = errs.New("HTTP.MALFORMED_REQUEST", "request body is not valid JSON")
+	ErrMethodNotAllowed = errs.New("HTTP.METHOD_NOT_ALLOWED", "request method is not allowed")
+	ErrRequestTooLarge  = errs.New("HTTP.REQUEST_TOO_LARGE", "request body is too large")
+)
+
+// MaxRequestBodyBytes is the largest request body a handler reads; larger ones are
+// answered with 413 Request Entity Too Large.
+const MaxRequestBodyBytes = 1 << 20
+
+func writeJSON(w nethttp.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w nethttp.ResponseWriter, err error) {
+	writeErrorStatus(w, errs.HTTPStatus(err), err)
+}
+
+// writeErrorStatus renders err like writeError but with a status [errs.HTTPStatus]
+// cannot derive from an error code, such as 405 or 413.
+func writeErrorStatus(w nethttp.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errs.ToResponse(err))
+}
@@ -0,0 +1,35 @@
+package http_test
+
+import (
+	nethttp "net/http"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPStatus_OrderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "should answer 400 to an invalid customer", err: order.ErrInvalidCustomerID, want: nethttp.StatusBadRequest},
+		{name: "should answer 400 to missing stock", err: order.ErrInsufficientStock, want: nethttp.StatusBadRequest},
+		{name: "should answer 404 to a missing order", err: order.ErrOrderNotFound, want: nethttp.StatusNotFound},
+		{name: "should answer 404 to a missing payment", err: order.ErrPaymentNotFound, want: nethttp.StatusNotFound},
+		{name: "should answer 409 to an order that is no longer pending", err: order.ErrOrderNotPending, want: nethttp.StatusConflict},
+		{name: "should answer 409 to an order that cannot be cancelled", err: order.ErrOrderCannotCancel, want: nethttp.StatusConflict},
+		{name: "should answer 409 to an order on hold", err: order.ErrOrderOnHold, want: nethttp.StatusConflict},
+		{name: "should answer 409 to a second active payment", err: payment.ErrActivePaymentExists, want: nethttp.StatusConflict},
+		{name: "should answer 409 to a payment that is no longer pending", err: payment.ErrPaymentNotPending, want: nethttp.StatusConflict},
+		{name: "should answer 409 to a wrapped conflict", err: order.ErrOrderNotPending.Wrap(payment.ErrPaymentNotPending), want: nethttp.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errs.HTTPStatus(tt.err))
+		})
+	}
+}
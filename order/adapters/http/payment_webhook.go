@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	nethttp "net/http"
+	"strings"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+)
+
+var ErrInvalidWebhookPayload = errs.New("HTTP.INVALID_WEBHOOK_PAYLOAD", "webhook payload must name the order, the payment and an approved or refused status")
+
+// PaymentSettler is the use case driven by [PaymentWebhookHandler], implemented by
+// [app.SettlePaymentHandler].
+type PaymentSettler interface {
+	Handle(ctx context.Context, cmd app.SettlePaymentCommand) error
+}
+
+type paymentWebhookPayload struct {
+	OrderID         string `json:"order_id"`
+	PaymentID       string `json:"payment_id"`
+	TransactionCode string `json:"transaction_code"`
+	Status          string `json:"status"` // "approved" or "refused"
+}
+
+// parsePaymentWebhook decodes the gateway payload into the command settling the payment,
+// returning [ErrMalformedRequest] for invalid JSON and [ErrInvalidWebhookPayload] when a
+// field is missing or the status is unknown.
+func parsePaymentWebhook(body []byte) (app.SettlePaymentCommand, error) {
+	var payload paymentWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return app.SettlePaymentCommand{}, ErrMalformedRequest.Wrap(err)
+	}
+
+	if strings.TrimSpace(payload.OrderID) == "" || strings.TrimSpace(payload.PaymentID) == "" ||
+		(payload.Status != "approved" && payload.Status != "refused") {
+		return app.SettlePaymentCommand{}, ErrInvalidWebhookPayload
+	}
+
+	return app.SettlePaymentCommand{
+		OrderID:         payload.OrderID,
+		PaymentID:       payload.PaymentID,
+		TransactionCode: payload.TransactionCode,
+		Approved:        payload.Status == "approved",
+	}, nil
+}
+
+// PaymentWebhookHandler serves the notifications the payment gateway posts once it has
+// approved or refused a payment. It answers 200 OK whenever the outcome is applied,
+// including repeated deliveries of an outcome already applied and approvals refunded
+// because the order was repriced ([payment.ErrAmountMismatch]), so the gateway stops
+// redelivering them; 400 Bad Request for a payload it cannot parse, and the status
+// derived from any other domain error. Other methods are answered with 405 Method Not
+// Allowed and bodies over [MaxRequestBodyBytes] with 413 Request Entity Too Large.
+type PaymentWebhookHandler struct {
+	settler PaymentSettler
+}
+
+// NewPaymentWebhookHandler creates a [PaymentWebhookHandler].
+func NewPaymentWebhookHandler(settler PaymentSettler) *PaymentWebhookHandler {
+	return &PaymentWebhookHandler{settler: settler}
+}
+
+// ServeHTTP implements [nethttp.Handler].
+func (h *PaymentWebhookHandler) ServeHTTP(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if r.Method != nethttp.MethodPost {
+		w.Header().Set("Allow", nethttp.MethodPost)
+		writeErrorStatus(w, nethttp.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(nethttp.MaxBytesReader(w, r.Body, MaxRequestBodyBytes))
+	if err != nil {
+		var tooLarge *nethttp.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeErrorStatus(w, nethttp.StatusRequestEntityTooLarge, ErrRequestTooLarge)
+			return
+		}
+		writeError(w, ErrMalformedRequest.Wrap(err))
+		return
+	}
+
+	cmd, err := parsePaymentWebhook(body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := h.settler.Handle(r.Context(), cmd); err != nil && !errors.Is(err, payment.ErrAmountMismatch) {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(nethttp.StatusOK)
+}
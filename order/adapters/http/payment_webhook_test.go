@@ -0,0 +1,204 @@
+package http_test
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	orderhttp "github.com/marcosvieirajr/sales-ddd-hexagonal/order/adapters/http"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookFixture struct {
+	handler   *orderhttp.PaymentWebhookHandler
+	inventory *memory.Inventory
+	repo      *memory.OrderRepository
+	outbox    *memory.Outbox
+	order     *order.Order
+	payment   *payment.Payment
+}
+
+func newWebhookFixture(t *testing.T) webhookFixture {
+	t.Helper()
+	return newWebhookFixtureWithStock(t, 10)
+}
+
+// newWebhookFixtureWithStock stocks units of "prod-1", the product of the default
+// testfixtures order line, of which the order holds 2.
+func newWebhookFixtureWithStock(t *testing.T, units int) webhookFixture {
+	t.Helper()
+	o := testfixtures.ValidOrder(t)
+	p, err := o.StartPayment(payment.MethodCreditCard)
+	require.NoError(t, err)
+	o.PullDomainEvents()
+	p.PullDomainEvents()
+
+	repo := memory.NewOrderRepository()
+	require.NoError(t, repo.Save(context.Background(), o))
+	outbox := memory.NewOutbox()
+	inventory := memory.NewInventory(map[string]int{"prod-1": units})
+	return webhookFixture{
+		handler:   orderhttp.NewPaymentWebhookHandler(app.NewSettlePaymentHandler(memory.NewUnitOfWork(repo, outbox), repo, inventory, outbox)),
+		inventory: inventory,
+		repo:      repo,
+		outbox:    outbox,
+		order:     o,
+		payment:   p,
+	}
+}
+
+func (f webhookFixture) deliver(status string) *httptest.ResponseRecorder {
+	body := fmt.Sprintf(`{"order_id":%q,"payment_id":%q,"transaction_code":"TXN-1","status":%q}`, f.order.ID, f.payment.ID, status)
+	return f.post(body)
+}
+
+func (f webhookFixture) post(body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(nethttp.MethodPost, "/webhooks/payments", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	f.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// stored returns the saved order and its first payment.
+func (f webhookFixture) stored(t *testing.T) (*order.Order, payment.Payment) {
+	t.Helper()
+	o := kernel.Must(f.repo.FindByID(context.Background(), f.order.ID))
+	require.NotEmpty(t, o.Payments())
+	return o, o.Payments()[0]
+}
+
+func TestPaymentWebhookHandler(t *testing.T) {
+	t.Run("should confirm the payment and mark the order as paid on approval", func(t *testing.T) {
+		f := newWebhookFixture(t)
+
+		rec := f.deliver("approved")
+
+		assert.Equal(t, nethttp.StatusOK, rec.Code)
+		o, p := f.stored(t)
+		assert.Equal(t, payment.StatusAuthorized, p.Status)
+		assert.Equal(t, order.StatusPaid, o.Status)
+		code, ok := p.TransactionCodeValue()
+		assert.True(t, ok)
+		assert.Equal(t, "TXN-1", code)
+		assert.NotEmpty(t, f.outbox.Events())
+		assert.Equal(t, 8, f.inventory.Available("prod-1"), "the order items should be reserved")
+	})
+
+	t.Run("should leave the payment pending on approval when the items are out of stock", func(t *testing.T) {
+		f := newWebhookFixtureWithStock(t, 1)
+
+		rec := f.deliver("approved")
+
+		assert.Equal(t, nethttp.StatusBadRequest, rec.Code)
+		assert.Equal(t, "INVENTORY.INSUFFICIENT", decodeErrors(t, rec).Errors[0]["code"])
+		assert.Equal(t, 1, f.inventory.Available("prod-1"))
+		assert.Empty(t, f.outbox.Events())
+	})
+
+	t.Run("should refuse the payment and cancel the order on refusal", func(t *testing.T) {
+		f := newWebhookFixture(t)
+
+		rec := f.deliver("refused")
+
+		assert.Equal(t, nethttp.StatusOK, rec.Code)
+		o, p := f.stored(t)
+		assert.Equal(t, payment.StatusRefused, p.Status)
+		assert.Equal(t, order.StatusCancelled, o.Status)
+	})
+
+	t.Run("should answer 200 to a duplicate delivery without applying it twice", func(t *testing.T) {
+		f := newWebhookFixture(t)
+		require.Equal(t, nethttp.StatusOK, f.deliver("approved").Code)
+		published := len(f.outbox.Events())
+		_, p := f.stored(t)
+		paidAt := *p.PaidAt
+
+		rec := f.deliver("approved")
+
+		assert.Equal(t, nethttp.StatusOK, rec.Code)
+		assert.Len(t, f.outbox.Events(), published, "no event should be published again")
+		o, p := f.stored(t)
+		assert.Equal(t, paidAt, *p.PaidAt)
+		assert.Equal(t, order.StatusPaid, o.Status)
+	})
+
+	t.Run("should answer 200 to an approval refunded because the order was repriced", func(t *testing.T) {
+		f := newWebhookFixtureWithStock(t, 10)
+		f.order.TotalAmount = 90.0 // repriced between authorization and the webhook
+		require.NoError(t, f.repo.Save(context.Background(), f.order))
+
+		rec := f.deliver("approved")
+
+		assert.Equal(t, nethttp.StatusOK, rec.Code)
+		saved, p := f.stored(t)
+		assert.Equal(t, order.StatusPending, saved.Status)
+		assert.Equal(t, payment.StatusRefunded, p.Status)
+		assert.Equal(t, 10, f.inventory.Available("prod-1"))
+	})
+
+	t.Run("should answer 400 to a malformed body", func(t *testing.T) {
+		tests := []struct {
+			name string
+			body string
+		}{
+			{name: "invalid JSON", body: `{"order_id":`},
+			{name: "missing payment ID", body: `{"order_id":"order-1","status":"approved"}`},
+			{name: "unknown status", body: `{"order_id":"order-1","payment_id":"payment-1","status":"chargeback"}`},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				f := newWebhookFixture(t)
+
+				rec := f.post(tt.body)
+
+				assert.Equal(t, nethttp.StatusBadRequest, rec.Code)
+				_, p := f.stored(t)
+				assert.Equal(t, payment.StatusPending, p.Status)
+				assert.Empty(t, f.outbox.Events())
+			})
+		}
+	})
+
+	t.Run("should answer 405 for a method other than POST", func(t *testing.T) {
+		f := newWebhookFixture(t)
+		req := httptest.NewRequest(nethttp.MethodGet, "/webhooks/payments", nil)
+		rec := httptest.NewRecorder()
+
+		f.handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, nethttp.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, nethttp.MethodPost, rec.Header().Get("Allow"))
+		assert.Equal(t, "HTTP.METHOD_NOT_ALLOWED", decodeErrors(t, rec).Errors[0]["code"])
+	})
+
+	t.Run("should answer 413 for a body over the limit", func(t *testing.T) {
+		f := newWebhookFixture(t)
+		body := `{"order_id": "` + strings.Repeat("a", orderhttp.MaxRequestBodyBytes) + `"}`
+
+		rec := f.post(body)
+
+		assert.Equal(t, nethttp.StatusRequestEntityTooLarge, rec.Code)
+		assert.Equal(t, "HTTP.REQUEST_TOO_LARGE", decodeErrors(t, rec).Errors[0]["code"])
+		_, p := f.stored(t)
+		assert.Equal(t, payment.StatusPending, p.Status)
+	})
+
+	t.Run("should answer 404 for a payment the order does not have", func(t *testing.T) {
+		f := newWebhookFixture(t)
+		f.payment = kernel.Must(payment.NewPayment(f.order.ID, 10, payment.MethodPix))
+
+		rec := f.deliver("approved")
+
+		assert.Equal(t, nethttp.StatusNotFound, rec.Code)
+	})
+}
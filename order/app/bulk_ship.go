@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+// TrackingCodeResolver is the port that supplies the carrier tracking code of an order
+// about to be shipped, typically by booking the shipment with the carrier.
+type TrackingCodeResolver interface {
+	// Resolve returns the tracking code for the order identified by orderID.
+	Resolve(ctx context.Context, orderID string) (string, error)
+}
+
+// BulkShipResult summarizes a [BulkShipHandler] run.
+type BulkShipResult struct {
+	Shipped  []string         // IDs of the shipped orders, in request order
+	Failures map[string]error // why each order that could not be shipped failed, by order ID
+}
+
+// BulkShipHandler moves a batch of orders from Separating to Shipped, recording the
+// tracking code of each one (see [order.Order.Ship]).
+type BulkShipHandler struct {
+	orders   order.Repository
+	tracking TrackingCodeResolver
+	outbox   Outbox
+}
+
+// NewBulkShipHandler creates a [BulkShipHandler].
+func NewBulkShipHandler(orders order.Repository, tracking TrackingCodeResolver, outbox Outbox) *BulkShipHandler {
+	return &BulkShipHandler{orders: orders, tracking: tracking, outbox: outbox}
+}
+
+// Handle loads each order identified by orderIDs, checks it can be shipped with
+// [order.Order.CheckShippable] before resolving its tracking code, so no shipment is
+// booked for an order in the wrong state, then ships and saves it and adds its events
+// to the outbox. A failure on one order does not stop the
+// others; it is recorded in the Failures of the returned result instead.
+func (h *BulkShipHandler) Handle(ctx context.Context, orderIDs []string) BulkShipResult {
+	result := BulkShipResult{Failures: make(map[string]error)}
+	for _, id := range orderIDs {
+		if err := h.ship(ctx, id); err != nil {
+			result.Failures[id] = err
+			continue
+		}
+		result.Shipped = append(result.Shipped, id)
+	}
+	return result
+}
+
+func (h *BulkShipHandler) ship(ctx context.Context, orderID string) error {
+	o, err := h.orders.FindByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if err := o.CheckShippable(); err != nil {
+		return err
+	}
+
+	code, err := h.tracking.Resolve(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if err := o.Ship(code); err != nil {
+		return err
+	}
+	if err := h.orders.Save(ctx, o); err != nil {
+		return err
+	}
+	return h.outbox.Add(ctx, o.PullDomainEvents()...)
+}
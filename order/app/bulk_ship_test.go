@@ -0,0 +1,108 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trackingCodes resolves the tracking code of an order from a fixed map.
+type trackingCodes map[string]string
+
+func (c trackingCodes) Resolve(_ context.Context, orderID string) (string, error) {
+	return c[orderID], nil
+}
+
+// bookingSpy records the orders whose tracking code was resolved, i.e. whose shipment
+// was booked with the carrier.
+type bookingSpy struct {
+	trackingCodes
+	booked []string
+}
+
+func (s *bookingSpy) Resolve(ctx context.Context, orderID string) (string, error) {
+	s.booked = append(s.booked, orderID)
+	return s.trackingCodes.Resolve(ctx, orderID)
+}
+
+func separatingOrder(t *testing.T) *order.Order {
+	t.Helper()
+	o := testfixtures.ValidOrder(t)
+	require.NoError(t, o.MarkAsGift())
+	require.NoError(t, o.MarkAsComped())
+	require.NoError(t, o.MarkAsSeparating())
+	return o
+}
+
+func shippedEvents(t *testing.T, outbox *memory.Outbox) []*order.ShippedEvent {
+	t.Helper()
+	var events []*order.ShippedEvent
+	for _, e := range outbox.Events() {
+		if shipped, ok := e.(*order.ShippedEvent); ok {
+			events = append(events, shipped)
+		}
+	}
+	return events
+}
+
+func TestBulkShipHandler_Handle(t *testing.T) {
+	t.Run("should ship the separating orders and report the one in the wrong state", func(t *testing.T) {
+		first := separatingOrder(t)
+		pending := testfixtures.ValidOrder(t)
+		second := separatingOrder(t)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, first, pending, second)
+		outbox := memory.NewOutbox()
+		tracking := &bookingSpy{trackingCodes: trackingCodes{first.ID: "BR0001", pending.ID: "BR0002", second.ID: "BR0003"}}
+		handler := app.NewBulkShipHandler(repo, tracking, outbox)
+
+		got := handler.Handle(context.Background(), []string{first.ID, pending.ID, second.ID})
+
+		assert.Equal(t, []string{first.ID, second.ID}, got.Shipped)
+		require.Len(t, got.Failures, 1)
+		assert.ErrorIs(t, got.Failures[pending.ID], order.ErrOrderNotSeparating)
+		assert.Equal(t, []string{first.ID, second.ID}, tracking.booked, "no shipment should be booked for the pending order")
+		savedFirst := kernel.Must(repo.FindByID(context.Background(), first.ID))
+		assert.Equal(t, order.StatusShipped, savedFirst.Status)
+		assert.Equal(t, "BR0001", savedFirst.TrackingCode)
+		savedSecond := kernel.Must(repo.FindByID(context.Background(), second.ID))
+		assert.Equal(t, order.StatusShipped, savedSecond.Status)
+		assert.Equal(t, "BR0003", savedSecond.TrackingCode)
+		savedPending := kernel.Must(repo.FindByID(context.Background(), pending.ID))
+		assert.Equal(t, order.StatusPending, savedPending.Status, "the failing order should be left untouched")
+		assert.Empty(t, savedPending.TrackingCode)
+		assert.Len(t, shippedEvents(t, outbox), 2, "each shipped order should publish its ShippedEvent")
+	})
+
+	t.Run("should report missing orders and keep shipping the others", func(t *testing.T) {
+		o := separatingOrder(t)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		handler := app.NewBulkShipHandler(repo, trackingCodes{o.ID: "BR0001"}, memory.NewOutbox())
+
+		got := handler.Handle(context.Background(), []string{"missing", o.ID})
+
+		assert.Equal(t, []string{o.ID}, got.Shipped)
+		assert.ErrorIs(t, got.Failures["missing"], order.ErrOrderNotFound)
+	})
+
+	t.Run("should report an order whose tracking code cannot be resolved", func(t *testing.T) {
+		o := separatingOrder(t)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		handler := app.NewBulkShipHandler(repo, trackingCodes{}, memory.NewOutbox())
+
+		got := handler.Handle(context.Background(), []string{o.ID})
+
+		assert.Empty(t, got.Shipped)
+		assert.ErrorIs(t, got.Failures[o.ID], order.ErrInvalidTrackingCode)
+		assert.Equal(t, order.StatusSeparating, kernel.Must(repo.FindByID(context.Background(), o.ID)).Status)
+	})
+}
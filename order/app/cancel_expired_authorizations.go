@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+)
+
+// CancelExpiredAuthorizationsHandler cancels every pending payment whose gateway
+// authorization is stale (see [payment.Payment.IsAuthorizationExpired]), so its order can
+// be edited or paid again.
+type CancelExpiredAuthorizationsHandler struct {
+	uow    UnitOfWork
+	orders order.Repository
+	outbox Outbox
+	window time.Duration
+}
+
+// NewCancelExpiredAuthorizationsHandler creates a [CancelExpiredAuthorizationsHandler]
+// that waits window after a transaction code is defined before cancelling its payment.
+func NewCancelExpiredAuthorizationsHandler(uow UnitOfWork, orders order.Repository, outbox Outbox, window time.Duration) *CancelExpiredAuthorizationsHandler {
+	return &CancelExpiredAuthorizationsHandler{uow: uow, orders: orders, outbox: outbox, window: window}
+}
+
+// Handle cancels the expired pending payment of every order created before now minus
+// the window (no payment of a newer order can have expired yet), saves the order and
+// adds the payment events to the outbox. Each order is handled in its own unit of work,
+// so a failed save or outbox write leaves its payment pending. It returns how many
+// payments were cancelled. A failure on one order does not stop the others; all failures are joined into the
+// returned error, each prefixed with the order ID.
+func (h *CancelExpiredAuthorizationsHandler) Handle(ctx context.Context) (int, error) {
+	now := kernel.Now()
+
+	candidates, _, err := h.orders.FindByDateRange(ctx, time.Time{}, now.Add(-h.window), order.Page{})
+	if err != nil {
+		return 0, err
+	}
+
+	cancelled := 0
+	var errs []error
+	for _, o := range candidates {
+		p, active := o.ActivePayment()
+		if !active || !p.IsAuthorizationExpired(now, h.window) {
+			continue
+		}
+		if err := h.cancel(ctx, o.ID, now); err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", o.ID, err))
+			continue
+		}
+		cancelled++
+	}
+
+	return cancelled, errors.Join(errs...)
+}
+
+// cancel reloads the order identified by orderID within a unit of work, so that a
+// rollback restores it, and cancels its active payment, whose authorization expired.
+func (h *CancelExpiredAuthorizationsHandler) cancel(ctx context.Context, orderID string, now time.Time) error {
+	return h.uow.Do(ctx, func(ctx context.Context) error {
+		o, err := h.orders.FindByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		p, active := o.ActivePayment()
+		if !active || !p.IsAuthorizationExpired(now, h.window) {
+			return payment.ErrPaymentNotPending
+		}
+		if err := p.CancelPayment(); err != nil {
+			return err
+		}
+
+		if err := h.orders.Save(ctx, o); err != nil {
+			return err
+		}
+
+		return h.outbox.Add(ctx, p.PullDomainEvents()...)
+	})
+}
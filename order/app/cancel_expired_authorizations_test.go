@@ -0,0 +1,88 @@
+package app_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storedPayment returns the payment paymentID of the stored order orderID.
+func storedPayment(t *testing.T, repo order.Repository, orderID, paymentID string) payment.Payment {
+	t.Helper()
+	o, err := repo.FindByID(context.Background(), orderID)
+	require.NoError(t, err)
+	i := slices.IndexFunc(o.Payments(), func(p payment.Payment) bool { return p.ID == paymentID })
+	require.GreaterOrEqual(t, i, 0, "payment %s not found", paymentID)
+	return o.Payments()[i]
+}
+
+func TestCancelExpiredAuthorizationsHandler_Handle(t *testing.T) {
+	const window = 30 * time.Minute
+
+	startPayment := func(t *testing.T, o *order.Order, code string) *payment.Payment {
+		t.Helper()
+		p, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+		if code != "" {
+			require.NoError(t, p.DefineTransactionCode(code))
+		}
+		p.PullDomainEvents()
+		return p
+	}
+
+	t.Run("should cancel only the payments whose authorization expired", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		staleOrder, freshOrder, uncodedOrder := testfixtures.ValidOrder(t), testfixtures.ValidOrder(t), testfixtures.ValidOrder(t)
+		stale := startPayment(t, staleOrder, "TXN-1")
+		uncoded := startPayment(t, uncodedOrder, "")
+		clock.Advance(window)
+		fresh := startPayment(t, freshOrder, "TXN-2")
+		clock.Advance(time.Minute)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, staleOrder, freshOrder, uncodedOrder)
+		outbox := memory.NewOutbox()
+		handler := app.NewCancelExpiredAuthorizationsHandler(memory.NewUnitOfWork(repo, outbox), repo, outbox, window)
+
+		got, err := handler.Handle(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, got)
+		assert.Equal(t, payment.StatusCancelled, storedPayment(t, repo, staleOrder.ID, stale.ID).Status)
+		assert.Equal(t, payment.StatusPending, storedPayment(t, repo, freshOrder.ID, fresh.ID).Status, "a code defined within the window should be kept")
+		assert.Equal(t, payment.StatusPending, storedPayment(t, repo, uncodedOrder.ID, uncoded.ID).Status, "a payment without code should be kept")
+		require.Len(t, outbox.Events(), 1)
+		assert.IsType(t, payment.CancelledEvent{}, outbox.Events()[0])
+	})
+
+	t.Run("should keep cancelling the remaining payments when one fails", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		failing, other := testfixtures.ValidOrder(t), testfixtures.ValidOrder(t)
+		failingPayment := startPayment(t, failing, "TXN-1")
+		p := startPayment(t, other, "TXN-2")
+		clock.Advance(window + time.Minute)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, failing, other)
+		outbox := memory.NewOutbox()
+		handler := app.NewCancelExpiredAuthorizationsHandler(memory.NewUnitOfWork(repo, outbox),
+			&failingSaveRepository{Repository: repo, failID: failing.ID}, outbox, window)
+
+		got, err := handler.Handle(context.Background())
+
+		assert.Equal(t, 1, got)
+		assert.ErrorIs(t, err, errSaveFailed)
+		assert.ErrorContains(t, err, failing.ID)
+		assert.Equal(t, payment.StatusCancelled, storedPayment(t, repo, other.ID, p.ID).Status)
+		assert.Equal(t, payment.StatusPending, storedPayment(t, repo, failing.ID, failingPayment.ID).Status,
+			"the payment of the order that failed to save should be stored unchanged")
+		assert.Len(t, outbox.Events(), 1, "only the saved order should publish events")
+	})
+}
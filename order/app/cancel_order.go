@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+// CancelOrderHandler cancels an order (see [order.Order.Cancel]) and releases the
+// inventory reservation it still holds.
+type CancelOrderHandler struct {
+	uow       UnitOfWork
+	orders    order.Repository
+	inventory order.InventoryReserver
+	outbox    Outbox
+}
+
+// NewCancelOrderHandler creates a [CancelOrderHandler].
+func NewCancelOrderHandler(uow UnitOfWork, orders order.Repository, inventory order.InventoryReserver, outbox Outbox) *CancelOrderHandler {
+	return &CancelOrderHandler{uow: uow, orders: orders, inventory: inventory, outbox: outbox}
+}
+
+// Handle loads the order identified by orderID, cancels it for reason, saves it and adds
+// its events to the outbox within the unit of work, so a failed save leaves the order as
+// it was and it can be cancelled again.
+//
+// A paid or separating order still holds the reservation of its items, which is released
+// last so the stock returns to the pool; when the release fails the unit is rolled back
+// and the order keeps its reservation. Releasing is idempotent, so the cancellation can
+// be retried. Shipping consumed the reservation, so cancelling a shipped or delivered
+// order leaves the inventory untouched.
+func (h *CancelOrderHandler) Handle(ctx context.Context, orderID string, reason order.CancellationReason) error {
+	return h.uow.Do(ctx, func(ctx context.Context) error {
+		o, err := h.orders.FindByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		reservationID := o.ReservationID
+		if err := o.Cancel(reason); err != nil {
+			return err
+		}
+
+		if err := h.orders.Save(ctx, o); err != nil {
+			return err
+		}
+
+		if err := h.outbox.Add(ctx, o.PullDomainEvents()...); err != nil {
+			return err
+		}
+
+		if reservationID == "" {
+			return nil
+		}
+		return h.inventory.Release(ctx, reservationID)
+	})
+}
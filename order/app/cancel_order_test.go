@@ -0,0 +1,158 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inventorySpy wraps an [order.InventoryReserver], recording the reservations released
+// through it and failing Release with releaseErr when set.
+type inventorySpy struct {
+	order.InventoryReserver
+	released   []string
+	releaseErr error
+}
+
+func (s *inventorySpy) Release(ctx context.Context, reservationID string) error {
+	s.released = append(s.released, reservationID)
+	if s.releaseErr != nil {
+		return s.releaseErr
+	}
+	return s.InventoryReserver.Release(ctx, reservationID)
+}
+
+// paidOrder returns a paid order of 2 units of "prod-1" whose units are reserved in
+// inventory.
+func paidOrder(t *testing.T, inventory order.InventoryReserver) *order.Order {
+	t.Helper()
+	o := testfixtures.ValidOrder(t)
+	require.NoError(t, o.MarkAsGift())
+	require.NoError(t, o.MarkAsComped())
+	reservationID, err := inventory.Reserve(context.Background(), o.ReservationLines())
+	require.NoError(t, err)
+	require.NoError(t, o.RecordReservation(reservationID))
+	return o
+}
+
+// shippedOrder returns a shipped order of 2 units of "prod-1" whose units were reserved
+// in inventory while it was paid.
+func shippedOrder(t *testing.T, inventory order.InventoryReserver) *order.Order {
+	t.Helper()
+	o := paidOrder(t, inventory)
+	require.NoError(t, o.MarkAsSeparating())
+	require.NoError(t, o.MarkAsShipped())
+	return o
+}
+
+func TestCancelOrderHandler_Handle(t *testing.T) {
+	setup := func(t *testing.T, o *order.Order, inventory order.InventoryReserver) (*app.CancelOrderHandler, *memory.OrderRepository, *memory.Outbox) {
+		t.Helper()
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		outbox := memory.NewOutbox()
+		return app.NewCancelOrderHandler(memory.NewUnitOfWork(repo, outbox), repo, inventory, outbox), repo, outbox
+	}
+
+	t.Run("should release the reservation of a paid order", func(t *testing.T) {
+		inventory := memory.NewInventory(map[string]int{"prod-1": 10})
+		o := paidOrder(t, inventory)
+		reservationID := o.ReservationID
+		spy := &inventorySpy{InventoryReserver: inventory}
+		handler, repo, outbox := setup(t, o, spy)
+
+		err := handler.Handle(context.Background(), o.ID, order.CancellationReasonCustomerCancelled)
+
+		require.NoError(t, err)
+		saved := kernel.Must(repo.FindByID(context.Background(), o.ID))
+		assert.Equal(t, order.StatusCancelled, saved.Status)
+		assert.Empty(t, saved.ReservationID)
+		assert.Equal(t, []string{reservationID}, spy.released)
+		assert.Equal(t, 10, inventory.Available("prod-1"), "the reserved units should return to stock")
+		assert.Equal(t, 1, cancelledEvents(outbox.Events()))
+	})
+
+	t.Run("should not touch the inventory when the order holds no reservation", func(t *testing.T) {
+		inventory := memory.NewInventory(map[string]int{"prod-1": 10})
+		o := testfixtures.ValidOrder(t)
+		require.NoError(t, o.MarkAsGift())
+		require.NoError(t, o.MarkAsComped())
+		spy := &inventorySpy{InventoryReserver: inventory}
+		handler, repo, _ := setup(t, o, spy)
+
+		err := handler.Handle(context.Background(), o.ID, order.CancellationReasonCustomerCancelled)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusCancelled, kernel.Must(repo.FindByID(context.Background(), o.ID)).Status)
+		assert.Empty(t, spy.released)
+		assert.Equal(t, 10, inventory.Available("prod-1"))
+	})
+
+	t.Run("should cancel a shipped order without returning its shipped units to stock", func(t *testing.T) {
+		inventory := memory.NewInventory(map[string]int{"prod-1": 10})
+		o := shippedOrder(t, inventory)
+		spy := &inventorySpy{InventoryReserver: inventory}
+		handler, repo, outbox := setup(t, o, spy)
+
+		err := handler.Handle(context.Background(), o.ID, order.CancellationReasonCustomerCancelled)
+
+		require.NoError(t, err)
+		saved := kernel.Must(repo.FindByID(context.Background(), o.ID))
+		assert.Equal(t, order.StatusCancelled, saved.Status)
+		assert.Empty(t, spy.released)
+		assert.Equal(t, 8, inventory.Available("prod-1"), "the 2 shipped units should stay out of stock")
+		assert.Equal(t, 1, cancelledEvents(outbox.Events()))
+	})
+
+	t.Run("should keep the order and its reservation when the release fails", func(t *testing.T) {
+		errReleaseFailed := errors.New("release failed")
+		inventory := memory.NewInventory(map[string]int{"prod-1": 10})
+		o := paidOrder(t, inventory)
+		reservationID := o.ReservationID
+		handler, repo, outbox := setup(t, o, &inventorySpy{InventoryReserver: inventory, releaseErr: errReleaseFailed})
+
+		err := handler.Handle(context.Background(), o.ID, order.CancellationReasonCustomerCancelled)
+
+		assert.ErrorIs(t, err, errReleaseFailed)
+		saved := kernel.Must(repo.FindByID(context.Background(), o.ID))
+		assert.Equal(t, order.StatusPaid, saved.Status)
+		assert.Equal(t, reservationID, saved.ReservationID)
+		assert.Empty(t, outbox.Events())
+	})
+
+	t.Run("should leave the order as it was when it cannot be saved", func(t *testing.T) {
+		inventory := memory.NewInventory(map[string]int{"prod-1": 10})
+		o := paidOrder(t, inventory)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		outbox := memory.NewOutbox()
+		failing := &failingSaveRepository{Repository: repo, failID: o.ID}
+		handler := app.NewCancelOrderHandler(memory.NewUnitOfWork(repo, outbox), failing, inventory, outbox)
+
+		err := handler.Handle(context.Background(), o.ID, order.CancellationReasonCustomerCancelled)
+
+		assert.ErrorIs(t, err, errSaveFailed)
+		assert.Equal(t, order.StatusPaid, kernel.Must(repo.FindByID(context.Background(), o.ID)).Status)
+		assert.Equal(t, 8, inventory.Available("prod-1"), "the reservation should still hold the units")
+		assert.Empty(t, outbox.Events())
+	})
+
+	t.Run("should return an error when the order cannot be cancelled", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t)
+		handler, repo, outbox := setup(t, o, memory.NewInventory(nil))
+
+		err := handler.Handle(context.Background(), o.ID, order.CancellationReasonCustomerCancelled)
+
+		assert.ErrorIs(t, err, order.ErrOrderCannotCancel)
+		assert.Equal(t, order.StatusPending, kernel.Must(repo.FindByID(context.Background(), o.ID)).Status)
+		assert.Empty(t, outbox.Events())
+	})
+}
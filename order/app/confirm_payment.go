@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"slices"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+)
+
+// ConfirmPaymentCommand identifies the payment to confirm and its order.
+type ConfirmPaymentCommand struct {
+	OrderID   string
+	PaymentID string
+}
+
+// ConfirmPaymentHandler confirms a payment, marks its order as paid and reserves its
+// items in a single [UnitOfWork], so the payment is never authorized while its order
+// stays unpaid or its items unreserved.
+type ConfirmPaymentHandler struct {
+	uow       UnitOfWork
+	orders    order.Repository
+	inventory order.InventoryReserver
+	outbox    Outbox
+}
+
+// NewConfirmPaymentHandler creates a [ConfirmPaymentHandler].
+func NewConfirmPaymentHandler(uow UnitOfWork, orders order.Repository, inventory order.InventoryReserver, outbox Outbox) *ConfirmPaymentHandler {
+	return &ConfirmPaymentHandler{uow: uow, orders: orders, inventory: inventory, outbox: outbox}
+}
+
+// Handle loads the order identified by cmd.OrderID, confirms its payment cmd.PaymentID,
+// marks the order as paid with [order.Order.HandleApprovedPaymentEvent], reserves its
+// items and records the reservation on the order, saves the order and adds the events of
+// the order and the payment to the outbox, all within the unit of work. When any step
+// fails the whole unit is rolled back: the payment stays pending and no event is
+// published. The reservation lives outside the unit, so it is made last and released
+// again when saving the order or adding its events fails afterwards.
+//
+// The payment amount is first reconciled with the current order total, which may have
+// been repriced since the payment was authorized. On a mismatch the authorized amount is
+// refunded instead: the payment is confirmed and refunded, the order stays pending and
+// both are saved and published, and [payment.ErrAmountMismatch] is returned.
+//
+// Returns [order.ErrPaymentNotFound] when the order has no such payment,
+// [payment.ErrPaymentNotPending] when the payment is not the pending one, and
+// [order.ErrInsufficientStock] when the items cannot be reserved.
+func (h *ConfirmPaymentHandler) Handle(ctx context.Context, cmd ConfirmPaymentCommand) error {
+	mismatch := false
+	err := h.uow.Do(ctx, func(ctx context.Context) error {
+		o, err := h.orders.FindByID(ctx, cmd.OrderID)
+		if err != nil {
+			return err
+		}
+
+		if !slices.ContainsFunc(o.Payments(), func(p payment.Payment) bool { return p.ID == cmd.PaymentID }) {
+			return order.ErrPaymentNotFound
+		}
+		p, active := o.ActivePayment()
+		if !active || p.ID != cmd.PaymentID {
+			return payment.ErrPaymentNotPending
+		}
+
+		if !amountMatches(o, p) {
+			mismatch = true
+			return h.refund(ctx, o, p)
+		}
+
+		if err := p.ConfirmPayment(); err != nil {
+			return err
+		}
+		if err := o.HandleApprovedPaymentEvent(p.ID); err != nil {
+			return err
+		}
+
+		return reserveAndSave(ctx, h.inventory, o, func() error { return h.save(ctx, o, p) })
+	})
+	if err != nil {
+		return err
+	}
+	if mismatch {
+		return payment.ErrAmountMismatch
+	}
+	return nil
+}
+
+// save saves o and adds the events of o and p to the outbox.
+func (h *ConfirmPaymentHandler) save(ctx context.Context, o *order.Order, p *payment.Payment) error {
+	if err := h.orders.Save(ctx, o); err != nil {
+		return err
+	}
+
+	events := append(o.PullDomainEvents(), p.PullDomainEvents()...)
+	return h.outbox.Add(ctx, events...)
+}
+
+// refund confirms the authorization of p and refunds it at once, leaving o pending, and
+// saves and publishes the outcome.
+func (h *ConfirmPaymentHandler) refund(ctx context.Context, o *order.Order, p *payment.Payment) error {
+	if err := refundMismatched(p); err != nil {
+		return err
+	}
+
+	if err := h.orders.Save(ctx, o); err != nil {
+		return err
+	}
+	return h.outbox.Add(ctx, p.PullDomainEvents()...)
+}
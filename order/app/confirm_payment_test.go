@@ -0,0 +1,175 @@
+package app_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmPaymentHandler_Handle(t *testing.T) {
+	// stockOf stocks units of "prod-1", the product of the default testfixtures order line.
+	stockOf := func(units int) *memory.Inventory {
+		return memory.NewInventory(map[string]int{"prod-1": units})
+	}
+	setup := func(t *testing.T, amountOff float64, inventory *memory.Inventory) (*app.ConfirmPaymentHandler, *memory.OrderRepository, *memory.Outbox, *order.Order, *payment.Payment) {
+		t.Helper()
+		o := testfixtures.ValidOrder(t)
+		p := kernel.Must(payment.NewPayment(o.ID, o.TotalAmount-amountOff, payment.MethodPix))
+		require.NoError(t, o.AddPayment(p))
+		_, err := o.DefineTransactionCode(p.ID, "TXN-1")
+		require.NoError(t, err)
+		o.PullDomainEvents()
+		p.PullDomainEvents()
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		outbox := memory.NewOutbox()
+		return app.NewConfirmPaymentHandler(memory.NewUnitOfWork(repo, outbox), repo, inventory, outbox), repo, outbox, o, p
+	}
+
+	// ==================== Success cases ==================== //
+	t.Run("should confirm the payment, mark the order paid and publish the events of both", func(t *testing.T) {
+		handler, repo, outbox, o, p := setup(t, 0, stockOf(10))
+
+		err := handler.Handle(context.Background(), app.ConfirmPaymentCommand{OrderID: o.ID, PaymentID: p.ID})
+
+		require.NoError(t, err)
+		saved := kernel.Must(repo.FindByID(context.Background(), o.ID))
+		assert.Equal(t, order.StatusPaid, saved.Status)
+		assert.Equal(t, payment.StatusAuthorized, saved.Payments()[0].Status)
+		events := outbox.Events()
+		assert.True(t, slices.ContainsFunc(events, func(e kernel.DomainEvent) bool { _, ok := e.(*order.PaidEvent); return ok }))
+		assert.IsType(t, payment.ApprovedEvent{}, events[len(events)-1])
+	})
+
+	t.Run("should reserve the order items and record the reservation", func(t *testing.T) {
+		inventory := stockOf(10)
+		handler, repo, _, o, p := setup(t, 0, inventory)
+
+		err := handler.Handle(context.Background(), app.ConfirmPaymentCommand{OrderID: o.ID, PaymentID: p.ID})
+
+		require.NoError(t, err)
+		assert.Equal(t, 8, inventory.Available("prod-1"), "the 2 units ordered should be reserved")
+		assert.NotEmpty(t, kernel.Must(repo.FindByID(context.Background(), o.ID)).ReservationID)
+	})
+
+	// ==================== Failure cases ==================== //
+	t.Run("should roll back the payment confirmation when the order cannot be marked paid", func(t *testing.T) {
+		handler, repo, outbox, o, p := setup(t, 0, stockOf(10))
+		require.NoError(t, o.Hold("fraud review"))
+		o.PullDomainEvents()
+		require.NoError(t, repo.Save(context.Background(), o))
+
+		err := handler.Handle(context.Background(), app.ConfirmPaymentCommand{OrderID: o.ID, PaymentID: p.ID})
+
+		assert.ErrorIs(t, err, order.ErrOrderOnHold)
+		saved := kernel.Must(repo.FindByID(context.Background(), o.ID))
+		assert.Equal(t, order.StatusPending, saved.Status)
+		assert.Equal(t, payment.StatusPending, saved.Payments()[0].Status, "payment confirmation should be rolled back")
+		assert.Nil(t, saved.Payments()[0].PaidAt)
+		assert.Empty(t, saved.PullDomainEvents())
+		assert.Empty(t, outbox.Events(), "no event should be published")
+	})
+
+	t.Run("should refund the payment when it does not cover the order total", func(t *testing.T) {
+		inventory := stockOf(10)
+		handler, repo, outbox, o, p := setup(t, 0.01, inventory)
+
+		err := handler.Handle(context.Background(), app.ConfirmPaymentCommand{OrderID: o.ID, PaymentID: p.ID})
+
+		assert.ErrorIs(t, err, payment.ErrAmountMismatch)
+		saved := kernel.Must(repo.FindByID(context.Background(), o.ID))
+		assert.Equal(t, order.StatusPending, saved.Status)
+		assert.Equal(t, payment.StatusRefunded, saved.Payments()[0].Status)
+		assert.Equal(t, 10, inventory.Available("prod-1"), "nothing should be reserved")
+		assert.IsType(t, payment.RefundedEvent{}, outbox.Events()[len(outbox.Events())-1])
+	})
+
+	t.Run("should refund the payment when the order was repriced after authorization", func(t *testing.T) {
+		handler, repo, _, o, p := setup(t, 0, stockOf(10))
+		o.TotalAmount = 90.0 // repriced by another process between authorization and confirmation
+		require.NoError(t, repo.Save(context.Background(), o))
+
+		err := handler.Handle(context.Background(), app.ConfirmPaymentCommand{OrderID: o.ID, PaymentID: p.ID})
+
+		assert.ErrorIs(t, err, payment.ErrAmountMismatch)
+		saved := kernel.Must(repo.FindByID(context.Background(), o.ID))
+		assert.Equal(t, order.StatusPending, saved.Status)
+		assert.Equal(t, payment.StatusRefunded, saved.Payments()[0].Status)
+	})
+
+	t.Run("should fail the payment when the items cannot be reserved", func(t *testing.T) {
+		inventory := stockOf(1)
+		handler, repo, outbox, o, p := setup(t, 0, inventory)
+
+		err := handler.Handle(context.Background(), app.ConfirmPaymentCommand{OrderID: o.ID, PaymentID: p.ID})
+
+		assert.ErrorIs(t, err, order.ErrInsufficientStock)
+		saved := kernel.Must(repo.FindByID(context.Background(), o.ID))
+		assert.Equal(t, order.StatusPending, saved.Status)
+		assert.Equal(t, payment.StatusPending, saved.Payments()[0].Status, "payment confirmation should be rolled back")
+		assert.Empty(t, saved.ReservationID)
+		assert.Equal(t, 1, inventory.Available("prod-1"), "stock should be untouched")
+		assert.Empty(t, outbox.Events(), "no event should be published")
+	})
+
+	t.Run("should release the reservation when the order cannot be saved", func(t *testing.T) {
+		inventory := stockOf(10)
+		_, repo, outbox, o, p := setup(t, 0, inventory)
+		failing := &failingSaveRepository{Repository: repo, failID: o.ID}
+		handler := app.NewConfirmPaymentHandler(memory.NewUnitOfWork(repo, outbox), failing, inventory, outbox)
+
+		err := handler.Handle(context.Background(), app.ConfirmPaymentCommand{OrderID: o.ID, PaymentID: p.ID})
+
+		assert.ErrorIs(t, err, errSaveFailed)
+		saved := kernel.Must(repo.FindByID(context.Background(), o.ID))
+		assert.Equal(t, order.StatusPending, saved.Status)
+		assert.Empty(t, saved.ReservationID)
+		assert.Equal(t, 10, inventory.Available("prod-1"), "the reserved units should be released")
+		assert.Empty(t, outbox.Events(), "no event should be published")
+	})
+
+	t.Run("should keep the writes of earlier units when a later one is rolled back", func(t *testing.T) {
+		handler, repo, outbox, o, p := setup(t, 0, stockOf(10))
+		require.NoError(t, handler.Handle(context.Background(), app.ConfirmPaymentCommand{OrderID: o.ID, PaymentID: p.ID}))
+		published := len(outbox.Events())
+		other := testfixtures.ValidOrder(t)
+		otherPayment := kernel.Must(payment.NewPayment(other.ID, other.TotalAmount, payment.MethodPix))
+		require.NoError(t, other.AddPayment(otherPayment))
+		_, err := other.DefineTransactionCode(otherPayment.ID, "TXN-2")
+		require.NoError(t, err)
+		require.NoError(t, other.Hold("fraud review"))
+		seedOrders(t, repo, other)
+
+		err = handler.Handle(context.Background(), app.ConfirmPaymentCommand{OrderID: other.ID, PaymentID: otherPayment.ID})
+
+		assert.ErrorIs(t, err, order.ErrOrderOnHold)
+		assert.Equal(t, order.StatusPaid, kernel.Must(repo.FindByID(context.Background(), o.ID)).Status)
+		assert.Len(t, outbox.Events(), published)
+	})
+
+	t.Run("should return an error when the order has no such payment", func(t *testing.T) {
+		handler, _, outbox, o, _ := setup(t, 0, stockOf(10))
+
+		err := handler.Handle(context.Background(), app.ConfirmPaymentCommand{OrderID: o.ID, PaymentID: "missing"})
+
+		assert.ErrorIs(t, err, order.ErrPaymentNotFound)
+		assert.Empty(t, outbox.Events())
+	})
+
+	t.Run("should return an error when the order does not exist", func(t *testing.T) {
+		handler, _, _, _, p := setup(t, 0, stockOf(10))
+
+		err := handler.Handle(context.Background(), app.ConfirmPaymentCommand{OrderID: "missing", PaymentID: p.ID})
+
+		assert.ErrorIs(t, err, order.ErrOrderNotFound)
+	})
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+)
+
+// CreateOrderItem is a line of a [CreateOrderCommand].
+type CreateOrderItem struct {
+	ProductID   string
+	ProductName string
+	UnitPrice   float64
+	Quantity    int
+}
+
+// CreateOrderCommand carries the input of [CreateOrderHandler].
+type CreateOrderCommand struct {
+	CustomerID      string
+	DeliveryAddress *order.DeliveryAddress
+	Items           []CreateOrderItem
+}
+
+// CreateOrderHandler places a new pending order (see [order.NewOrderWithItems]).
+type CreateOrderHandler struct {
+	orders  order.Repository
+	numbers order.NumberGenerator
+	outbox  Outbox
+}
+
+// NewCreateOrderHandler creates a [CreateOrderHandler].
+func NewCreateOrderHandler(orders order.Repository, numbers order.NumberGenerator, outbox Outbox) *CreateOrderHandler {
+	return &CreateOrderHandler{orders: orders, numbers: numbers, outbox: outbox}
+}
+
+// Handle builds the order described by cmd, numbers it with the next number of the
+// [order.NumberGenerator], saves it and adds its events to the outbox.
+// The violations of every invalid item are joined with those of the order itself, so
+// callers can report all of them at once, up to [errs.MaxViolations]; nothing is saved
+// in that case.
+func (h *CreateOrderHandler) Handle(ctx context.Context, cmd CreateOrderCommand) (*order.Order, error) {
+	items := make([]*orderitem.OrderItem, 0, len(cmd.Items))
+	var itemErrs []error
+	for _, line := range cmd.Items {
+		item, err := orderitem.NewOrderItem(line.ProductID, line.ProductName, line.UnitPrice, line.Quantity)
+		if err != nil {
+			itemErrs = append(itemErrs, err)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	o, err := order.NewOrderWithItems(cmd.CustomerID, cmd.DeliveryAddress, items)
+	if err := errs.Join(append([]error{err}, itemErrs...)...); err != nil {
+		return nil, err
+	}
+
+	number, err := h.numbers.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.AssignNumber(number); err != nil {
+		return nil, err
+	}
+
+	if err := h.orders.Save(ctx, o); err != nil {
+		return nil, err
+	}
+
+	if err := h.outbox.Add(ctx, o.PullDomainEvents()...); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
@@ -0,0 +1,53 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOrderHandler_Handle(t *testing.T) {
+	t.Run("should save the new order and publish its events", func(t *testing.T) {
+		repo := memory.NewOrderRepository()
+		outbox := memory.NewOutbox()
+		handler := app.NewCreateOrderHandler(repo, memory.NewSequentialNumbers("PED"), outbox)
+
+		o, err := handler.Handle(context.Background(), app.CreateOrderCommand{
+			CustomerID:      "cust-123",
+			DeliveryAddress: testfixtures.ValidAddress(t),
+			Items:           []app.CreateOrderItem{{ProductID: "prod-1", ProductName: "Widget", UnitPrice: 50, Quantity: 2}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 100.0, o.TotalAmount)
+		assert.Equal(t, "PED-000001", o.Number)
+		saved, err := repo.FindByID(context.Background(), o.ID)
+		require.NoError(t, err)
+		assert.Equal(t, o.Snapshot(), saved.Snapshot())
+		assert.NotEmpty(t, outbox.Events(), "the order events should be published")
+	})
+
+	t.Run("should report the violations of the order and its items together", func(t *testing.T) {
+		repo := memory.NewOrderRepository()
+		outbox := memory.NewOutbox()
+		handler := app.NewCreateOrderHandler(repo, memory.NewSequentialNumbers("PED"), outbox)
+
+		o, err := handler.Handle(context.Background(), app.CreateOrderCommand{
+			CustomerID:      " ",
+			DeliveryAddress: testfixtures.ValidAddress(t),
+			Items:           []app.CreateOrderItem{{ProductID: "prod-1", ProductName: "Widget", UnitPrice: 50, Quantity: 0}},
+		})
+
+		assert.Nil(t, o)
+		assert.ErrorIs(t, err, order.ErrInvalidCustomerID)
+		assert.ErrorIs(t, err, orderitem.ErrInvalidQuantity)
+		assert.Empty(t, outbox.Events())
+	})
+}
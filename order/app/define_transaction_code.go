@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+// DefineTransactionCodeHandler records the gateway transaction code of a payment,
+// rejecting codes already held by another payment of the same order (see
+// [order.Order.DefineTransactionCode]).
+type DefineTransactionCodeHandler struct {
+	orders order.Repository
+	outbox Outbox
+}
+
+// NewDefineTransactionCodeHandler creates a [DefineTransactionCodeHandler].
+func NewDefineTransactionCodeHandler(orders order.Repository, outbox Outbox) *DefineTransactionCodeHandler {
+	return &DefineTransactionCodeHandler{orders: orders, outbox: outbox}
+}
+
+// Handle loads the order identified by orderID, assigns code to its payment paymentID,
+// saves the order and adds the payment events to the outbox. Returns
+// payment.ErrDuplicateTransactionCode if another payment of the order already holds
+// code, leaving the order untouched.
+func (h *DefineTransactionCodeHandler) Handle(ctx context.Context, orderID, paymentID, code string) error {
+	o, err := h.orders.FindByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	p, err := o.DefineTransactionCode(paymentID, code)
+	if err != nil {
+		return err
+	}
+
+	if err := h.orders.Save(ctx, o); err != nil {
+		return err
+	}
+
+	events := append(o.PullDomainEvents(), p.PullDomainEvents()...)
+	return h.outbox.Add(ctx, events...)
+}
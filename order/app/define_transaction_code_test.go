@@ -0,0 +1,54 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefineTransactionCodeHandler_Handle(t *testing.T) {
+	t.Run("should define the code and publish the payment event", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t)
+		p, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		p.PullDomainEvents()
+		outbox := memory.NewOutbox()
+		handler := app.NewDefineTransactionCodeHandler(repo, outbox)
+
+		err = handler.Handle(context.Background(), o.ID, p.ID, "TXN-1")
+
+		require.NoError(t, err)
+		events := outbox.Events()
+		require.Len(t, events, 1)
+		defined, ok := events[0].(payment.TransactionCodeDefinedEvent)
+		require.True(t, ok, "event should be a TransactionCodeDefinedEvent")
+		assert.Equal(t, p.ID, defined.PaymentID)
+	})
+
+	t.Run("should reject a second payment reusing the first payment's code", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t)
+		first, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+		require.NoError(t, first.DefineTransactionCode("TXN-1"))
+		require.NoError(t, first.RefusePayment())
+		second, err := o.RetryPayment(payment.MethodPix)
+		require.NoError(t, err)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		outbox := memory.NewOutbox()
+		handler := app.NewDefineTransactionCodeHandler(repo, outbox)
+
+		err = handler.Handle(context.Background(), o.ID, second.ID, "TXN-1")
+
+		assert.ErrorIs(t, err, payment.ErrDuplicateTransactionCode)
+		assert.Empty(t, outbox.Events())
+	})
+}
@@ -0,0 +1,78 @@
+// Package app holds the application layer of the order bounded context: use cases that
+// load aggregates through the domain ports, invoke their behavior, persist them and
+// hand their domain events to the [Outbox].
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+// ExpireStaleOrdersHandler cancels every pending order whose unpaid time-to-live
+// has elapsed (see [order.Order.Expire]).
+type ExpireStaleOrdersHandler struct {
+	uow    UnitOfWork
+	orders order.Repository
+	outbox Outbox
+}
+
+// NewExpireStaleOrdersHandler creates an [ExpireStaleOrdersHandler].
+func NewExpireStaleOrdersHandler(uow UnitOfWork, orders order.Repository, outbox Outbox) *ExpireStaleOrdersHandler {
+	return &ExpireStaleOrdersHandler{uow: uow, orders: orders, outbox: outbox}
+}
+
+// Handle loads the pending orders created before now minus [order.UnpaidOrderTTL],
+// expires and saves each one and adds its events to the outbox. Each order is expired in
+// its own unit of work, so a failed save leaves that order pending. It returns how many
+// orders were expired. A failure on one order does not stop the others; all failures
+// are joined into the returned error, each prefixed with the order ID.
+func (h *ExpireStaleOrdersHandler) Handle(ctx context.Context) (int, error) {
+	now := kernel.Now()
+	cutoff := now.Add(-order.UnpaidOrderTTL())
+
+	candidates, _, err := h.orders.FindByDateRange(ctx, time.Time{}, cutoff, order.Page{})
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	var errs []error
+	for _, o := range candidates {
+		if !o.IsExpired(now) {
+			continue
+		}
+		if err := h.expire(ctx, o.ID, now); err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", o.ID, err))
+			continue
+		}
+		expired++
+	}
+
+	return expired, errors.Join(errs...)
+}
+
+// expire reloads the order identified by orderID within a unit of work, so that a
+// rollback restores it, and expires it.
+func (h *ExpireStaleOrdersHandler) expire(ctx context.Context, orderID string, now time.Time) error {
+	return h.uow.Do(ctx, func(ctx context.Context) error {
+		o, err := h.orders.FindByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if err := o.Expire(now); err != nil {
+			return err
+		}
+
+		if err := h.orders.Save(ctx, o); err != nil {
+			return err
+		}
+
+		return h.outbox.Add(ctx, o.PullDomainEvents()...)
+	})
+}
@@ -0,0 +1,117 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== Helpers ==================== //
+
+var errSaveFailed = errors.New("save failed")
+
+// failingSaveRepository wraps a repository and fails Save for a single order ID.
+type failingSaveRepository struct {
+	order.Repository
+	failID string
+}
+
+func (r *failingSaveRepository) Save(ctx context.Context, o *order.Order) error {
+	if o.ID == r.failID {
+		return errSaveFailed
+	}
+	return r.Repository.Save(ctx, o)
+}
+
+// seedOrders saves orders as already persisted, draining the events they raised while
+// being built so the outbox only sees what the handler under test publishes.
+func seedOrders(t *testing.T, repo order.Repository, orders ...*order.Order) {
+	t.Helper()
+	for _, o := range orders {
+		o.PullDomainEvents()
+		require.NoError(t, repo.Save(context.Background(), o))
+	}
+}
+
+func cancelledEvents(events []kernel.DomainEvent) int {
+	n := 0
+	for _, e := range events {
+		if _, ok := e.(*order.CancelledEvent); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// ==================== Tests ==================== //
+
+func TestExpireStaleOrdersHandler_Handle(t *testing.T) {
+	t.Run("should expire only the stale pending orders", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		stale1 := testfixtures.ValidOrder(t)
+		stale2 := testfixtures.ValidOrder(t)
+		paid := testfixtures.ValidOrder(t)
+		require.NoError(t, paid.MarkAsGift())
+		require.NoError(t, paid.MarkAsComped())
+		clock.Advance(order.UnpaidOrderTTL() + time.Minute)
+		fresh := testfixtures.ValidOrder(t)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, stale1, stale2, paid, fresh)
+		outbox := memory.NewOutbox()
+		handler := app.NewExpireStaleOrdersHandler(memory.NewUnitOfWork(repo, outbox), repo, outbox)
+
+		got, err := handler.Handle(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, got)
+		assert.Equal(t, order.StatusCancelled, kernel.Must(repo.FindByID(context.Background(), stale1.ID)).Status)
+		assert.Equal(t, order.StatusCancelled, kernel.Must(repo.FindByID(context.Background(), stale2.ID)).Status)
+		assert.Equal(t, order.StatusPaid, kernel.Must(repo.FindByID(context.Background(), paid.ID)).Status, "paid orders should be left untouched")
+		assert.Equal(t, order.StatusPending, kernel.Must(repo.FindByID(context.Background(), fresh.ID)).Status, "fresh orders should be left untouched")
+		assert.Equal(t, 2, cancelledEvents(outbox.Events()), "each expired order should publish its CancelledEvent")
+	})
+
+	t.Run("should keep expiring the remaining orders when one fails", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		failing := testfixtures.ValidOrder(t)
+		stale := testfixtures.ValidOrder(t)
+		clock.Advance(order.UnpaidOrderTTL() + time.Minute)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, failing, stale)
+		outbox := memory.NewOutbox()
+		handler := app.NewExpireStaleOrdersHandler(memory.NewUnitOfWork(repo, outbox),
+			&failingSaveRepository{Repository: repo, failID: failing.ID}, outbox)
+
+		got, err := handler.Handle(context.Background())
+
+		assert.Equal(t, 1, got)
+		assert.ErrorIs(t, err, errSaveFailed)
+		assert.ErrorContains(t, err, failing.ID)
+		assert.Equal(t, order.StatusCancelled, kernel.Must(repo.FindByID(context.Background(), stale.ID)).Status)
+		assert.Equal(t, order.StatusPending, kernel.Must(repo.FindByID(context.Background(), failing.ID)).Status,
+			"the order that failed to save should be stored unchanged")
+		assert.Equal(t, 1, cancelledEvents(outbox.Events()), "only the saved order should publish events")
+	})
+
+	t.Run("should expire nothing when every order is fresh", func(t *testing.T) {
+		testfixtures.Clock(t)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, testfixtures.ValidOrder(t))
+		outbox := memory.NewOutbox()
+		handler := app.NewExpireStaleOrdersHandler(memory.NewUnitOfWork(repo, outbox), repo, outbox)
+
+		got, err := handler.Handle(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, got)
+	})
+}
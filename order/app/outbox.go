@@ -0,0 +1,14 @@
+package app
+
+import (
+	"context"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+)
+
+// Outbox is the port through which use cases hand the domain events pulled from
+// aggregates over for publishing. Implementations should store them in the same
+// transaction as the aggregate so no event is lost.
+type Outbox interface {
+	Add(ctx context.Context, events ...kernel.DomainEvent) error
+}
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+)
+
+// amountMatches reports whether the amount of p still matches the current total of o,
+// which may have been repriced since p was authorized.
+func amountMatches(o *order.Order, p *payment.Payment) bool {
+	return kernel.RoundCents(p.Amount) == kernel.RoundCents(o.Total())
+}
+
+// refundMismatched settles p, whose amount no longer matches the total of its order, by
+// confirming its authorization unless already confirmed and refunding it at once. The
+// order is left pending, so it can be paid again for its current total.
+func refundMismatched(p *payment.Payment) error {
+	if p.Status.Equals(payment.StatusPending) {
+		if err := p.ConfirmPayment(); err != nil {
+			return err
+		}
+	}
+	return p.RefundPayment()
+}
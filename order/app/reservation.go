@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+// reserveAndSave reserves the items of o, which was just marked as paid, records the
+// reservation on o and runs save, which persists o and publishes its events. It is shared
+// by every use case paying an order, so each paid order holds a reservation. The
+// reservation lives outside the unit of work, so it is made last and released again when
+// recording it or saving fails.
+func reserveAndSave(ctx context.Context, inventory order.InventoryReserver, o *order.Order, save func() error) error {
+	reservationID, err := inventory.Reserve(ctx, o.ReservationLines())
+	if err != nil {
+		return err
+	}
+
+	err = o.RecordReservation(reservationID)
+	if err == nil {
+		err = save()
+	}
+	if err != nil {
+		return errors.Join(err, inventory.Release(ctx, reservationID))
+	}
+	return nil
+}
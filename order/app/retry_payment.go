@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+)
+
+// RetryPaymentHandler starts a new payment for an order whose previous payment was
+// refused or cancelled (see [order.Order.RetryPayment]).
+type RetryPaymentHandler struct {
+	orders order.Repository
+	outbox Outbox
+}
+
+// NewRetryPaymentHandler creates a [RetryPaymentHandler].
+func NewRetryPaymentHandler(orders order.Repository, outbox Outbox) *RetryPaymentHandler {
+	return &RetryPaymentHandler{orders: orders, outbox: outbox}
+}
+
+// Handle loads the order identified by orderID, starts a fresh payment with method,
+// saves the order and adds the events of the order and the new payment to the outbox.
+// Returns [payment.ErrActivePaymentExists] if a pending or authorized payment already
+// exists, leaving the order untouched.
+func (h *RetryPaymentHandler) Handle(ctx context.Context, orderID string, method payment.Method) (*payment.Payment, error) {
+	o, err := h.orders.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := o.RetryPayment(method)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.orders.Save(ctx, o); err != nil {
+		return nil, err
+	}
+
+	events := append(o.PullDomainEvents(), p.PullDomainEvents()...)
+	if err := h.outbox.Add(ctx, events...); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,73 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPaymentHandler_Handle(t *testing.T) {
+	startPayment := func(t *testing.T, o *order.Order) *payment.Payment {
+		t.Helper()
+		p, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+		require.NoError(t, p.DefineTransactionCode("TXN-"+p.ID))
+		p.PullDomainEvents()
+		return p
+	}
+
+	t.Run("should start and publish a new payment after a refusal", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t)
+		require.NoError(t, startPayment(t, o).RefusePayment())
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		outbox := memory.NewOutbox()
+		handler := app.NewRetryPaymentHandler(repo, outbox)
+
+		got, err := handler.Handle(context.Background(), o.ID, payment.MethodPix)
+
+		require.NoError(t, err)
+		assert.Equal(t, payment.StatusPending, got.Status)
+		assert.Len(t, kernel.Must(repo.FindByID(context.Background(), o.ID)).Payments(), 2)
+		events := outbox.Events()
+		require.Len(t, events, 2)
+		changed, ok := events[0].(*order.ChangedEvent)
+		require.True(t, ok, "first event should be the order ChangedEvent")
+		assert.Equal(t, o.ID, changed.Summary.OrderID)
+		created, ok := events[1].(payment.CreatedEvent)
+		require.True(t, ok, "second event should be a payment CreatedEvent")
+		assert.Equal(t, got.ID, created.PaymentID)
+	})
+
+	t.Run("should block the retry while a payment is active", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t)
+		startPayment(t, o)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		outbox := memory.NewOutbox()
+		handler := app.NewRetryPaymentHandler(repo, outbox)
+
+		got, err := handler.Handle(context.Background(), o.ID, payment.MethodPix)
+
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, payment.ErrActivePaymentExists)
+		assert.Len(t, o.Payments(), 1)
+		assert.Empty(t, outbox.Events())
+	})
+
+	t.Run("should return an error when the order does not exist", func(t *testing.T) {
+		handler := app.NewRetryPaymentHandler(memory.NewOrderRepository(), memory.NewOutbox())
+
+		_, err := handler.Handle(context.Background(), "unknown-id", payment.MethodPix)
+
+		assert.ErrorIs(t, err, order.ErrOrderNotFound)
+	})
+}
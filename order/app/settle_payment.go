@@ -0,0 +1,181 @@
+package app
+
+import (
+	"context"
+	"slices"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+)
+
+// SettlePaymentCommand carries the outcome the gateway reported for a payment.
+type SettlePaymentCommand struct {
+	OrderID         string
+	PaymentID       string
+	TransactionCode string
+	Approved        bool // false when the gateway refused the payment
+}
+
+// SettlePaymentHandler applies the gateway outcome of a payment to it and to its order:
+// an approval confirms the payment, marks the order as paid and reserves its items, a
+// refusal refuses the payment and cancels the order.
+type SettlePaymentHandler struct {
+	uow       UnitOfWork
+	orders    order.Repository
+	inventory order.InventoryReserver
+	outbox    Outbox
+}
+
+// NewSettlePaymentHandler creates a [SettlePaymentHandler].
+func NewSettlePaymentHandler(uow UnitOfWork, orders order.Repository, inventory order.InventoryReserver, outbox Outbox) *SettlePaymentHandler {
+	return &SettlePaymentHandler{uow: uow, orders: orders, inventory: inventory, outbox: outbox}
+}
+
+// Handle loads the order identified by cmd.OrderID, records the transaction code of the
+// payment unless already set, confirms or refuses it, applies the outcome to the order,
+// reserves the items of an order it marked as paid, saves the order and adds the events
+// of the order and the payment to the outbox, all within the unit of work: when the
+// order rejects the outcome, e.g. because it is on hold, or its items cannot be
+// reserved, the payment is left pending so a redelivery can settle it later. As in
+// [ConfirmPaymentHandler], the reservation is made last and released again when saving
+// fails.
+//
+// An approval is first reconciled with the current order total, which may have been
+// repriced since the payment was authorized. On a mismatch the authorized amount is
+// refunded instead, as in [ConfirmPaymentHandler]: the payment is confirmed and
+// refunded, the order stays pending and both are saved and published, and
+// [payment.ErrAmountMismatch] is returned.
+//
+// Gateways deliver outcomes at least once, so Handle is idempotent: when both the
+// payment and the order already reflect the reported outcome it returns nil without
+// changing or saving anything, and so it does for an approval that was refunded by the
+// reconciliation. A payment that holds the outcome while its order does not only gets
+// the order side applied.
+//
+// Returns [order.ErrPaymentNotFound] when the order has no such payment,
+// [payment.ErrTransactionCodeMismatch] when cmd.TransactionCode differs from the code
+// already recorded on the payment, [payment.ErrPaymentNotPending] when the payment was
+// settled the other way, and [order.ErrInsufficientStock] when the items cannot be
+// reserved.
+func (h *SettlePaymentHandler) Handle(ctx context.Context, cmd SettlePaymentCommand) error {
+	mismatch := false
+	err := h.uow.Do(ctx, func(ctx context.Context) error {
+		o, err := h.orders.FindByID(ctx, cmd.OrderID)
+		if err != nil {
+			return err
+		}
+
+		payments := o.Payments()
+		i := slices.IndexFunc(payments, func(p payment.Payment) bool { return p.ID == cmd.PaymentID })
+		if i < 0 {
+			return order.ErrPaymentNotFound
+		}
+		if code, defined := payments[i].TransactionCodeValue(); defined && code != cmd.TransactionCode {
+			return payment.ErrTransactionCodeMismatch
+		}
+
+		outcome := payment.StatusRefused
+		if cmd.Approved {
+			outcome = payment.StatusAuthorized
+		}
+		settled := payments[i].Status.Equals(outcome)
+		if settled && orderReflects(o, cmd.Approved) {
+			return nil
+		}
+		if cmd.Approved && payments[i].Status.Equals(payment.StatusRefunded) && o.Status.Equals(order.StatusPending) {
+			return nil // an earlier delivery refunded the approval of a repriced order
+		}
+
+		if active, ok := o.ActivePayment(); cmd.Approved && ok && active.ID == cmd.PaymentID && !amountMatches(o, active) {
+			mismatch = true
+			return h.refund(ctx, o, active, cmd.TransactionCode)
+		}
+
+		var p *payment.Payment
+		if !settled {
+			active, ok := o.ActivePayment()
+			if !ok || active.ID != cmd.PaymentID {
+				return payment.ErrPaymentNotPending
+			}
+			if err := settlePayment(o, active, cmd); err != nil {
+				return err
+			}
+			p = active
+		}
+
+		if !cmd.Approved {
+			if err := o.HandleRejectedPaymentEvent(cmd.PaymentID); err != nil {
+				return err
+			}
+			return h.save(ctx, o, p)
+		}
+
+		if err := o.HandleApprovedPaymentEvent(cmd.PaymentID); err != nil {
+			return err
+		}
+		return reserveAndSave(ctx, h.inventory, o, func() error { return h.save(ctx, o, p) })
+	})
+	if err != nil {
+		return err
+	}
+	if mismatch {
+		return payment.ErrAmountMismatch
+	}
+	return nil
+}
+
+// refund records code on p unless already set, confirms the authorization of p unless
+// already confirmed and refunds it at once, leaving o pending, and saves and publishes
+// the outcome.
+func (h *SettlePaymentHandler) refund(ctx context.Context, o *order.Order, p *payment.Payment, code string) error {
+	if err := defineTransactionCode(o, p, code); err != nil {
+		return err
+	}
+	if err := refundMismatched(p); err != nil {
+		return err
+	}
+	return h.save(ctx, o, p)
+}
+
+// save saves o and adds to the outbox the events of o and, unless nil, of p.
+func (h *SettlePaymentHandler) save(ctx context.Context, o *order.Order, p *payment.Payment) error {
+	if err := h.orders.Save(ctx, o); err != nil {
+		return err
+	}
+
+	events := o.PullDomainEvents()
+	if p != nil {
+		events = append(events, p.PullDomainEvents()...)
+	}
+	return h.outbox.Add(ctx, events...)
+}
+
+// settlePayment records the transaction code of the pending payment p unless already
+// set, and confirms or refuses it.
+func settlePayment(o *order.Order, p *payment.Payment, cmd SettlePaymentCommand) error {
+	if err := defineTransactionCode(o, p, cmd.TransactionCode); err != nil {
+		return err
+	}
+	if cmd.Approved {
+		return p.ConfirmPayment()
+	}
+	return p.RefusePayment()
+}
+
+// defineTransactionCode records code on p, a payment of o, unless a code is already set.
+func defineTransactionCode(o *order.Order, p *payment.Payment, code string) error {
+	if _, defined := p.TransactionCodeValue(); defined {
+		return nil
+	}
+	_, err := o.DefineTransactionCode(p.ID, code)
+	return err
+}
+
+// orderReflects reports whether o is already past the outcome of its payment: no longer
+// pending after an approval, cancelled after a refusal.
+func orderReflects(o *order.Order, approved bool) bool {
+	if approved {
+		return !o.Status.Equals(order.StatusPending) && !o.Status.Equals(order.StatusCancelled)
+	}
+	return o.Status.Equals(order.StatusCancelled)
+}
@@ -0,0 +1,178 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettlePaymentHandler_Handle(t *testing.T) {
+	// setupWithStock stocks units of "prod-1", the product of the default testfixtures
+	// order line, of which the order holds 2.
+	setupWithStock := func(t *testing.T, units int) (*app.SettlePaymentHandler, *memory.OrderRepository, *memory.Outbox, *order.Order, *payment.Payment) {
+		t.Helper()
+		o := testfixtures.ValidOrder(t)
+		p, err := o.StartPayment(payment.MethodPix)
+		require.NoError(t, err)
+		p.PullDomainEvents()
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		outbox := memory.NewOutbox()
+		inventory := memory.NewInventory(map[string]int{"prod-1": units})
+		return app.NewSettlePaymentHandler(memory.NewUnitOfWork(repo, outbox), repo, inventory, outbox), repo, outbox, o, p
+	}
+	setup := func(t *testing.T) (*app.SettlePaymentHandler, *memory.OrderRepository, *memory.Outbox, *order.Order, *payment.Payment) {
+		t.Helper()
+		return setupWithStock(t, 10)
+	}
+	stored := func(t *testing.T, repo *memory.OrderRepository, id string) (*order.Order, payment.Payment) {
+		t.Helper()
+		o := kernel.Must(repo.FindByID(context.Background(), id))
+		return o, o.Payments()[0]
+	}
+
+	t.Run("should confirm the payment and publish the events of both", func(t *testing.T) {
+		handler, repo, outbox, o, p := setup(t)
+
+		err := handler.Handle(context.Background(), app.SettlePaymentCommand{OrderID: o.ID, PaymentID: p.ID, TransactionCode: "TXN-1", Approved: true})
+
+		require.NoError(t, err)
+		saved, savedPayment := stored(t, repo, o.ID)
+		assert.Equal(t, payment.StatusAuthorized, savedPayment.Status)
+		assert.Equal(t, order.StatusPaid, saved.Status)
+		assert.IsType(t, payment.ApprovedEvent{}, outbox.Events()[len(outbox.Events())-1])
+	})
+
+	t.Run("should reserve the items of the order it marks as paid", func(t *testing.T) {
+		handler, repo, _, o, p := setup(t)
+
+		err := handler.Handle(context.Background(), app.SettlePaymentCommand{OrderID: o.ID, PaymentID: p.ID, TransactionCode: "TXN-1", Approved: true})
+
+		require.NoError(t, err)
+		saved, _ := stored(t, repo, o.ID)
+		assert.NotEmpty(t, saved.ReservationID)
+	})
+
+	t.Run("should leave the payment pending when the items cannot be reserved", func(t *testing.T) {
+		handler, repo, outbox, o, p := setupWithStock(t, 1)
+
+		err := handler.Handle(context.Background(), app.SettlePaymentCommand{OrderID: o.ID, PaymentID: p.ID, TransactionCode: "TXN-1", Approved: true})
+
+		assert.ErrorIs(t, err, order.ErrInsufficientStock)
+		saved, savedPayment := stored(t, repo, o.ID)
+		assert.Equal(t, order.StatusPending, saved.Status)
+		assert.Equal(t, payment.StatusPending, savedPayment.Status, "payment confirmation should be rolled back")
+		assert.Empty(t, saved.ReservationID)
+		assert.Empty(t, outbox.Events())
+	})
+
+	t.Run("should leave the payment pending when the order rejects the outcome, so a redelivery settles it", func(t *testing.T) {
+		handler, repo, outbox, o, p := setup(t)
+		require.NoError(t, o.Hold("fraud review"))
+		o.PullDomainEvents()
+		require.NoError(t, repo.Save(context.Background(), o))
+		cmd := app.SettlePaymentCommand{OrderID: o.ID, PaymentID: p.ID, TransactionCode: "TXN-1", Approved: true}
+
+		err := handler.Handle(context.Background(), cmd)
+
+		assert.ErrorIs(t, err, order.ErrOrderOnHold)
+		held, heldPayment := stored(t, repo, o.ID)
+		assert.Equal(t, payment.StatusPending, heldPayment.Status, "payment confirmation should be rolled back")
+		assert.Empty(t, outbox.Events())
+
+		require.NoError(t, held.Release())
+		require.NoError(t, repo.Save(context.Background(), held))
+		require.NoError(t, handler.Handle(context.Background(), cmd))
+
+		saved, savedPayment := stored(t, repo, o.ID)
+		assert.Equal(t, order.StatusPaid, saved.Status)
+		assert.Equal(t, payment.StatusAuthorized, savedPayment.Status)
+	})
+
+	t.Run("should apply the order side of a payment that already holds the outcome", func(t *testing.T) {
+		handler, repo, _, o, p := setup(t)
+		_, err := o.DefineTransactionCode(p.ID, "TXN-1")
+		require.NoError(t, err)
+		require.NoError(t, p.ConfirmPayment())
+		require.NoError(t, repo.Save(context.Background(), o))
+
+		err = handler.Handle(context.Background(), app.SettlePaymentCommand{OrderID: o.ID, PaymentID: p.ID, TransactionCode: "TXN-1", Approved: true})
+
+		require.NoError(t, err)
+		saved, _ := stored(t, repo, o.ID)
+		assert.Equal(t, order.StatusPaid, saved.Status)
+	})
+
+	t.Run("should refund an approval when the order was repriced after authorization", func(t *testing.T) {
+		handler, repo, outbox, o, p := setup(t)
+		o.TotalAmount = 90.0 // repriced by another process between authorization and the webhook
+		require.NoError(t, repo.Save(context.Background(), o))
+		cmd := app.SettlePaymentCommand{OrderID: o.ID, PaymentID: p.ID, TransactionCode: "TXN-1", Approved: true}
+
+		err := handler.Handle(context.Background(), cmd)
+
+		assert.ErrorIs(t, err, payment.ErrAmountMismatch)
+		saved, savedPayment := stored(t, repo, o.ID)
+		assert.Equal(t, order.StatusPending, saved.Status)
+		assert.Empty(t, saved.ReservationID, "nothing should be reserved")
+		assert.Equal(t, payment.StatusRefunded, savedPayment.Status)
+		assert.IsType(t, payment.RefundedEvent{}, outbox.Events()[len(outbox.Events())-1])
+
+		published := len(outbox.Events())
+		require.NoError(t, handler.Handle(context.Background(), cmd), "a redelivery should be ignored")
+		assert.Len(t, outbox.Events(), published)
+	})
+
+	t.Run("should reject an outcome whose transaction code differs from the recorded one", func(t *testing.T) {
+		handler, repo, outbox, o, p := setup(t)
+		_, err := o.DefineTransactionCode(p.ID, "TXN-1")
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(context.Background(), o))
+
+		err = handler.Handle(context.Background(), app.SettlePaymentCommand{OrderID: o.ID, PaymentID: p.ID, TransactionCode: "TXN-2", Approved: true})
+
+		assert.ErrorIs(t, err, payment.ErrTransactionCodeMismatch)
+		_, savedPayment := stored(t, repo, o.ID)
+		assert.Equal(t, payment.StatusPending, savedPayment.Status)
+		assert.Empty(t, outbox.Events())
+	})
+
+	t.Run("should ignore an outcome that was already applied", func(t *testing.T) {
+		handler, _, outbox, o, p := setup(t)
+		cmd := app.SettlePaymentCommand{OrderID: o.ID, PaymentID: p.ID, TransactionCode: "TXN-1", Approved: false}
+		require.NoError(t, handler.Handle(context.Background(), cmd))
+		published := len(outbox.Events())
+
+		err := handler.Handle(context.Background(), cmd)
+
+		require.NoError(t, err)
+		assert.Len(t, outbox.Events(), published)
+	})
+
+	t.Run("should reject the opposite outcome of a settled payment", func(t *testing.T) {
+		handler, repo, _, o, p := setup(t)
+		require.NoError(t, handler.Handle(context.Background(), app.SettlePaymentCommand{OrderID: o.ID, PaymentID: p.ID, TransactionCode: "TXN-1", Approved: true}))
+
+		err := handler.Handle(context.Background(), app.SettlePaymentCommand{OrderID: o.ID, PaymentID: p.ID, TransactionCode: "TXN-1", Approved: false})
+
+		assert.ErrorIs(t, err, payment.ErrPaymentNotPending)
+		_, savedPayment := stored(t, repo, o.ID)
+		assert.Equal(t, payment.StatusAuthorized, savedPayment.Status)
+	})
+
+	t.Run("should return an error when the order has no such payment", func(t *testing.T) {
+		handler, _, _, o, _ := setup(t)
+
+		err := handler.Handle(context.Background(), app.SettlePaymentCommand{OrderID: o.ID, PaymentID: "missing", Approved: true})
+
+		assert.ErrorIs(t, err, order.ErrPaymentNotFound)
+	})
+}
@@ -0,0 +1,12 @@
+package app
+
+import "context"
+
+// UnitOfWork is the port through which use cases make several writes as one: either
+// all of them are kept or none is. Implementations typically wrap a database transaction
+// shared by the repository and the outbox.
+type UnitOfWork interface {
+	// Do runs fn, keeping its writes when it returns nil and rolling them back otherwise.
+	// The error of fn is returned as is.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+// UpdateDeliveryAddressHandler changes the delivery address of an order after checking
+// that its CEP is serviced (see [order.Order.UpdateDeliveryAddress]).
+type UpdateDeliveryAddressHandler struct {
+	orders         order.Repository
+	serviceability order.CEPServiceability
+	outbox         Outbox
+}
+
+// NewUpdateDeliveryAddressHandler creates an [UpdateDeliveryAddressHandler].
+func NewUpdateDeliveryAddressHandler(orders order.Repository, serviceability order.CEPServiceability, outbox Outbox) *UpdateDeliveryAddressHandler {
+	return &UpdateDeliveryAddressHandler{orders: orders, serviceability: serviceability, outbox: outbox}
+}
+
+// Handle loads the order identified by orderID, replaces its delivery address with
+// address, saves the order and adds its events to the outbox. Returns
+// [order.ErrCEPNotServiceable] when the CEP of address is not serviced, leaving the
+// order untouched.
+func (h *UpdateDeliveryAddressHandler) Handle(ctx context.Context, orderID string, address order.DeliveryAddress) error {
+	o, err := h.orders.FindByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	serviceable, err := h.serviceability.IsServiceable(ctx, address.CEP())
+	if err != nil {
+		return err
+	}
+	if !serviceable {
+		return order.ErrCEPNotServiceable
+	}
+
+	if err := o.UpdateDeliveryAddress(address); err != nil {
+		return err
+	}
+
+	if err := h.orders.Save(ctx, o); err != nil {
+		return err
+	}
+
+	return h.outbox.Add(ctx, o.PullDomainEvents()...)
+}
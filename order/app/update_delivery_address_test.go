@@ -0,0 +1,55 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateDeliveryAddressHandler_Handle(t *testing.T) {
+	t.Run("should update the address when its CEP is serviced", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t)
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		outbox := memory.NewOutbox()
+		handler := app.NewUpdateDeliveryAddressHandler(repo, memory.NewCEPServiceability("01310-100"), outbox)
+		address := testfixtures.ValidAddress(t, testfixtures.WithCEP("01310-100"))
+
+		err := handler.Handle(context.Background(), o.ID, *address)
+
+		require.NoError(t, err)
+		assert.Equal(t, "01310-100", kernel.Must(repo.FindByID(context.Background(), o.ID)).DeliveryAddress.CEP())
+		assert.NotEmpty(t, outbox.Events(), "the order events should be published")
+	})
+
+	t.Run("should reject an address whose CEP is not serviced", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t)
+		original := o.DeliveryAddress
+		repo := memory.NewOrderRepository()
+		seedOrders(t, repo, o)
+		outbox := memory.NewOutbox()
+		handler := app.NewUpdateDeliveryAddressHandler(repo, memory.NewCEPServiceability("01310-100"), outbox)
+		address := testfixtures.ValidAddress(t, testfixtures.WithCEP("69900-000"))
+
+		err := handler.Handle(context.Background(), o.ID, *address)
+
+		assert.ErrorIs(t, err, order.ErrCEPNotServiceable)
+		assert.Equal(t, original, o.DeliveryAddress, "address should be unchanged")
+		assert.Empty(t, outbox.Events())
+	})
+
+	t.Run("should return an error when the order does not exist", func(t *testing.T) {
+		handler := app.NewUpdateDeliveryAddressHandler(memory.NewOrderRepository(), memory.NewCEPServiceability(), memory.NewOutbox())
+
+		err := handler.Handle(context.Background(), "missing", *testfixtures.ValidAddress(t))
+
+		assert.ErrorIs(t, err, order.ErrOrderNotFound)
+	})
+}
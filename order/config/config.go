@@ -0,0 +1,50 @@
+// Package config loads the deployment settings of the order bounded context from JSON
+// and applies them through the configuration seams of the domain packages.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+)
+
+// Config is the JSON document read by [Load], e.g.
+//
+//	{"valid_states": ["SP", "RJ"], "enabled_methods": ["credit_card", "pix"]}
+//
+// An omitted or empty list keeps the default of accepting every state or method.
+type Config struct {
+	ValidStates    []string `json:"valid_states"`    // UFs delivered to, see [order.SetValidStates]
+	EnabledMethods []string `json:"enabled_methods"` // payment method names, see [payment.SetEnabledMethods]
+}
+
+// Load parses the JSON config read from r and applies it with [order.SetValidStates] and
+// [payment.SetEnabledMethods]. Unknown fields, UFs and method names are rejected with an
+// error naming the offending setting, and nothing is applied.
+func Load(r io.Reader) (Config, error) {
+	var cfg Config
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("config: %w", err)
+	}
+
+	methods := make([]payment.Method, 0, len(cfg.EnabledMethods))
+	for _, name := range cfg.EnabledMethods {
+		var m payment.Method
+		if err := m.UnmarshalText([]byte(name)); err != nil {
+			return Config{}, fmt.Errorf("config: enabled method %q: %w", name, err)
+		}
+		methods = append(methods, m)
+	}
+
+	if err := order.SetValidStates(cfg.ValidStates); err != nil {
+		return Config{}, fmt.Errorf("config: valid states %q: %w", cfg.ValidStates, err)
+	}
+	payment.SetEnabledMethods(methods)
+
+	return cfg, nil
+}
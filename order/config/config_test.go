@@ -0,0 +1,90 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/config"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resetSettings(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		_ = order.SetValidStates(nil)
+		payment.SetEnabledMethods(nil)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should parse and apply a valid config", func(t *testing.T) {
+		resetSettings(t)
+
+		got, err := config.Load(strings.NewReader(`{"valid_states": ["SP", "rj"], "enabled_methods": ["credit_card", "pix"]}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SP", "rj"}, got.ValidStates)
+		assert.Equal(t, []payment.Method{payment.MethodCreditCard, payment.MethodPix}, payment.EnabledMethods())
+		_, err = order.NewDeliveryAddress("20040-020", "Av. Rio Branco", "1", "", "Centro", "Rio de Janeiro", "RJ", "Brasil")
+		assert.NoError(t, err, "an enabled state should be accepted")
+		_, err = order.NewDeliveryAddress("30130-010", "Av. Afonso Pena", "1", "", "Centro", "Belo Horizonte", "MG", "Brasil")
+		assert.ErrorIs(t, err, order.ErrInvalidState, "a state left out should be rejected")
+	})
+
+	t.Run("should keep every default when the lists are omitted", func(t *testing.T) {
+		resetSettings(t)
+
+		_, err := config.Load(strings.NewReader(`{}`))
+
+		require.NoError(t, err)
+		assert.Nil(t, payment.EnabledMethods())
+	})
+
+	t.Run("should reject invalid configs without applying them", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			json    string
+			wantErr error
+			wantMsg string
+		}{
+			{
+				name:    "unknown UF",
+				json:    `{"valid_states": ["SP", "XX"], "enabled_methods": ["pix"]}`,
+				wantErr: order.ErrInvalidState,
+				wantMsg: `valid states ["SP" "XX"]`,
+			},
+			{
+				name:    "unknown method",
+				json:    `{"valid_states": ["SP"], "enabled_methods": ["pix", "paypal"]}`,
+				wantErr: payment.ErrInvalidPaymentMethod,
+				wantMsg: `enabled method "paypal"`,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resetSettings(t)
+
+				_, err := config.Load(strings.NewReader(tt.json))
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorContains(t, err, tt.wantMsg)
+				assert.Nil(t, payment.EnabledMethods(), "methods should not be applied")
+				_, err = order.NewDeliveryAddress("30130-010", "Av. Afonso Pena", "1", "", "Centro", "Belo Horizonte", "MG", "Brasil")
+				assert.NoError(t, err, "states should not be applied")
+			})
+		}
+	})
+
+	t.Run("should reject malformed JSON and unknown fields", func(t *testing.T) {
+		for _, input := range []string{`{"valid_states": `, `{"states": ["SP"]}`} {
+			resetSettings(t)
+
+			_, err := config.Load(strings.NewReader(input))
+
+			assert.ErrorContains(t, err, "config:", input)
+		}
+	})
+}
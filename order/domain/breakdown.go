@@ -0,0 +1,14 @@
+package order
+
+// Breakdown itemizes how an [Order] total is composed:
+// Total = max(ItemsTotal − CouponDiscount − Credits, 0) + Tax, or equivalently
+// Total = Gross − DiscountTotal + Tax.
+type Breakdown struct {
+	Gross          float64 `json:"gross"`           // sum of the line subtotals, before any discount
+	ItemsTotal     float64 `json:"items_total"`     // sum of the line item totals
+	CouponDiscount float64 `json:"coupon_discount"` // discount granted by the applied coupon
+	Credits        float64 `json:"credits"`         // sum of the order-level credits
+	DiscountTotal  float64 `json:"discount_total"`  // item discounts, coupon discount and credits combined
+	Tax            float64 `json:"tax"`             // tax charged on the taxable base
+	Total          float64 `json:"total"`           // amount due
+}
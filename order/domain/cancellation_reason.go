@@ -15,6 +15,7 @@ var (
 	CancellationReasonOutOfStock        = CancellationReason{3}
 	CancellationReasonInvalidAddress    = CancellationReason{4}
 	CancellationReasonOther             = CancellationReason{5}
+	CancellationReasonPaymentTimeout    = CancellationReason{6}
 )
 
 var cancellationToString = map[CancellationReason]string{
@@ -23,6 +24,7 @@ var cancellationToString = map[CancellationReason]string{
 	CancellationReasonOutOfStock:        "out_of_stock",
 	CancellationReasonInvalidAddress:    "invalid_address",
 	CancellationReasonOther:             "other",
+	CancellationReasonPaymentTimeout:    "payment_timeout",
 }
 
 // String returns the string representation of the CancellationReason.
@@ -38,11 +40,29 @@ func (s CancellationReason) MarshalText() ([]byte, error) {
 	return []byte(s.String()), nil
 }
 
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidCancellationReason] and leave s unchanged instead of decoding to an uninitialized CancellationReason.
+func (s *CancellationReason) UnmarshalText(text []byte) error {
+	for v, str := range cancellationToString {
+		if str == string(text) {
+			*s = v
+			return nil
+		}
+	}
+	return ErrInvalidCancellationReason
+}
+
 // Equals checks if two CancellationReason values are equal.
 func (s CancellationReason) Equals(other CancellationReason) bool {
 	return s.value == other.value
 }
 
+// IsValid reports whether the CancellationReason is one of the defined values; the zero value is not.
+func (s CancellationReason) IsValid() bool {
+	_, ok := cancellationToString[s]
+	return ok
+}
+
 // ParseCancellationReason converts an int to the corresponding CancellationReason value.
 // If the input does not match any known cancellation reason, it returns an error and an empty CancellationReason value.
 func ParseCancellationReason(value int) (CancellationReason, error) {
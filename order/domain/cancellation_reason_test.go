@@ -20,6 +20,7 @@ func TestCancellationReason_String(t *testing.T) {
 		{name: "should return 'out_of_stock' for CancellationReasonOutOfStock", reason: order.CancellationReasonOutOfStock, want: "out_of_stock"},
 		{name: "should return 'invalid_address' for CancellationReasonInvalidAddress", reason: order.CancellationReasonInvalidAddress, want: "invalid_address"},
 		{name: "should return 'other' for CancellationReasonOther", reason: order.CancellationReasonOther, want: "other"},
+		{name: "should return 'payment_timeout' for CancellationReasonPaymentTimeout", reason: order.CancellationReasonPaymentTimeout, want: "payment_timeout"},
 		// ==================== Failure cases ==================== //
 		{name: "should return 'unknown' for an unrecognized reason value", reason: order.CancellationReason{}, want: "unknown"},
 	}
@@ -54,6 +55,48 @@ func TestCancellationReason_MarshalText(t *testing.T) {
 	}
 }
 
+func TestCancellationReason_UnmarshalText(t *testing.T) {
+	// ==================== Success cases ==================== //
+	successTests := []struct {
+		name string
+		text string
+		want order.CancellationReason
+	}{
+		{name: "should unmarshal 'out_of_stock' to CancellationReasonOutOfStock", text: "out_of_stock", want: order.CancellationReasonOutOfStock},
+		{name: "should unmarshal 'payment_error' to CancellationReasonPaymentError", text: "payment_error", want: order.CancellationReasonPaymentError},
+	}
+	for _, tt := range successTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got order.CancellationReason
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	failureTests := []struct {
+		name string
+		text string
+	}{
+		{name: "should return an error for a misspelled cancellation reason", text: "out_of_stock_typo"},
+		{name: "should return an error for the 'unknown' placeholder", text: "unknown"},
+		{name: "should return an error for an empty string", text: ""},
+	}
+	for _, tt := range failureTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := order.CancellationReasonPaymentError
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			assert.ErrorIs(t, err, order.ErrInvalidCancellationReason)
+			assert.Equal(t, order.CancellationReasonPaymentError, got, "value should be unchanged")
+		})
+	}
+}
+
 func TestCancellationReason_Equals(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -89,6 +132,7 @@ func TestParseCancellationReason(t *testing.T) {
 		{name: "should parse 3 to CancellationReasonOutOfStock", value: 3, wantReason: order.CancellationReasonOutOfStock},
 		{name: "should parse 4 to CancellationReasonInvalidAddress", value: 4, wantReason: order.CancellationReasonInvalidAddress},
 		{name: "should parse 5 to CancellationReasonOther", value: 5, wantReason: order.CancellationReasonOther},
+		{name: "should parse 6 to CancellationReasonPaymentTimeout", value: 6, wantReason: order.CancellationReasonPaymentTimeout},
 	}
 	for _, tt := range successTests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,16 @@
+package order
+
+import (
+	"context"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrCEPNotServiceable = errs.New("ORDER.CEP_NOT_SERVICEABLE", "delivery is not available for the CEP")
+
+// CEPServiceability is the port that tells whether deliveries reach a CEP. A CEP may be
+// well formed (see [NewDeliveryAddress]) and still not be serviced by any carrier.
+type CEPServiceability interface {
+	// IsServiceable reports whether orders can be delivered to cep.
+	IsServiceable(ctx context.Context, cep string) (bool, error)
+}
@@ -0,0 +1,72 @@
+package order
+
+import (
+	"encoding/json"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
+)
+
+var (
+	ErrInvalidCreditAmount = errs.New("CREDIT.INVALID_AMOUNT", "credit amount must be greater than zero")
+	ErrInvalidCreditReason = errs.New("CREDIT.INVALID_REASON", "credit reason cannot be null or whitespace")
+)
+
+// Credit is an immutable value object representing an order-level negative adjustment,
+// such as a goodwill credit, that reduces the order total.
+type Credit struct {
+	amount float64
+	reason string
+}
+
+// NewCredit constructs and validates a [Credit]. amount must be strictly positive and
+// reason must be non-blank.
+//
+// If multiple fields are invalid, all violations are collected and returned as a
+// single joined error, allowing callers to inspect every failure via [errors.Is].
+func NewCredit(amount float64, reason string) (*Credit, error) {
+	if err := errs.Join(
+		guard.CheckNotZeroOrNegative(amount, ErrInvalidCreditAmount),
+		guard.CheckNotNullOrWhiteSpace(reason, ErrInvalidCreditReason),
+	); err != nil {
+		return nil, err
+	}
+	return &Credit{amount: amount, reason: reason}, nil
+}
+
+// Amount returns the positive amount deducted from the order total.
+func (c Credit) Amount() float64 { return c.amount }
+
+// Reason returns why the credit was granted.
+func (c Credit) Reason() string { return c.reason }
+
+// creditJSON is the wire shape of a [Credit].
+type creditJSON struct {
+	Amount float64 `json:"amount"`
+	Reason string  `json:"reason"`
+}
+
+// MarshalJSON serializes the credit as its amount and reason.
+func (c Credit) MarshalJSON() ([]byte, error) {
+	return json.Marshal(creditJSON{c.amount, c.reason})
+}
+
+// UnmarshalJSON parses the shape written by MarshalJSON, validating it as [NewCredit]
+// does. Invalid credits return its error and leave c unchanged.
+func (c *Credit) UnmarshalJSON(data []byte) error {
+	var v creditJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	parsed, err := NewCredit(v.Amount, v.Reason)
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// Equals reports whether c and other have the same amount and reason.
+func (c Credit) Equals(other Credit) bool {
+	return c == other
+}
@@ -0,0 +1,40 @@
+package order_test
+
+import (
+	"testing"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCredit(t *testing.T) {
+	t.Run("should successfully create a credit with valid input", func(t *testing.T) {
+		got, err := order.NewCredit(10.0, "goodwill")
+
+		require.NoError(t, err)
+		assert.Equal(t, 10.0, got.Amount())
+		assert.Equal(t, "goodwill", got.Reason())
+	})
+
+	t.Run("should return an error when input is invalid", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			amount  float64
+			reason  string
+			wantErr error
+		}{
+			{name: "should return an error when amount is zero", amount: 0, reason: "goodwill", wantErr: order.ErrInvalidCreditAmount},
+			{name: "should return an error when amount is negative", amount: -5, reason: "goodwill", wantErr: order.ErrInvalidCreditAmount},
+			{name: "should return an error when reason is blank", amount: 5, reason: "  ", wantErr: order.ErrInvalidCreditReason},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := order.NewCredit(tt.amount, tt.reason)
+
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, tt.wantErr)
+			})
+		}
+	})
+}
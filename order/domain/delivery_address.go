@@ -1,24 +1,35 @@
 package order
 
 import (
-	"errors"
+	"encoding/json"
 	"regexp"
 	"strings"
 
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
 )
 
 var (
-	ErrInvalidCEP      = errs.New("DELIVERY_ADDRESS.INVALID_CEP_FORMAT", "invalid CEP: must be in the format 12345-678")
-	ErrInvalidStreet   = errs.New("DELIVERY_ADDRESS.INVALID_STREET", "street cannot be null or whitespace")
-	ErrInvalidNumber   = errs.New("DELIVERY_ADDRESS.INVALID_NUMBER", "number cannot be null or whitespace")
-	ErrInvalidDistrict = errs.New("DELIVERY_ADDRESS.INVALID_DISTRICT", "district cannot be null or whitespace")
-	ErrInvalidCity     = errs.New("DELIVERY_ADDRESS.INVALID_CITY", "city cannot be null or whitespace")
-	ErrInvalidState    = errs.New("DELIVERY_ADDRESS.INVALID_STATE", "invalid state: must be a valid Brazilian state (UF)")
-	ErrInvalidCountry  = errs.New("DELIVERY_ADDRESS.INVALID_COUNTRY", "country cannot be null or whitespace")
+	ErrInvalidCEP        = errs.New("DELIVERY_ADDRESS.INVALID_CEP_FORMAT", "invalid CEP: must be in the format 12345-678")
+	ErrInvalidStreet     = errs.New("DELIVERY_ADDRESS.INVALID_STREET", "street cannot be null or whitespace")
+	ErrInvalidNumber     = errs.New("DELIVERY_ADDRESS.INVALID_NUMBER", "invalid number: must be digits optionally followed by a letter, or s/n")
+	ErrInvalidDistrict   = errs.New("DELIVERY_ADDRESS.INVALID_DISTRICT", "district cannot be null or whitespace")
+	ErrInvalidCity       = errs.New("DELIVERY_ADDRESS.INVALID_CITY", "city cannot be null or whitespace")
+	ErrInvalidState      = errs.New("DELIVERY_ADDRESS.INVALID_STATE", "invalid state: must be a valid Brazilian state (UF)")
+	ErrInvalidCountry    = errs.New("DELIVERY_ADDRESS.INVALID_COUNTRY", "country cannot be null or whitespace")
+	ErrInvalidComplement = errs.New("DELIVERY_ADDRESS.INVALID_COMPLEMENT", "complement cannot be longer than 100 characters")
 )
 
+// MaxComplementLength is the maximum number of characters of a [DeliveryAddress] complement.
+const MaxComplementLength = 100
+
+// DefaultDeliveryCountry is the country [NewDeliveryAddress] fills in when none is
+// given, unless overridden with [SetDeliveryCountry].
+const DefaultDeliveryCountry = "Brasil"
+
+var deliveryCountry = kernel.NewSetting(DefaultDeliveryCountry)
+
 // DeliveryAddress is an immutable value object representing a Brazilian postal address.
 // All fields are unexported to enforce construction through [NewDeliveryAddress] and
 // to prevent external mutation. Two DeliveryAddress values are equal when every field
@@ -37,14 +48,25 @@ type DeliveryAddress struct {
 // NewDeliveryAddress constructs and validates a [DeliveryAddress] value object.
 // All fields except complement are required (non-empty, non-whitespace).
 // cep must follow the Brazilian postal format "12345-678" and state must be a valid
-// two-letter UF code (e.g. "SP", "RJ"). complement may be an empty string.
+// two-letter UF code (e.g. "SP", "RJ"). number must be digits optionally followed by a
+// letter, as in "123" or "123A", or "s/n" (sem número) for unnumbered buildings; the
+// check ignores case, and number is stored without surrounding whitespace. complement
+// may be an empty string and holds at most [MaxComplementLength] characters
+// ([ErrInvalidComplement]). An empty country defaults to [DeliveryCountry]; a
+// whitespace-only one is still rejected.
 //
 // If multiple fields are invalid, all violations are collected and returned as a
 // single joined error, allowing callers to inspect every failure via [errors.Is].
 func NewDeliveryAddress(cep, street, number, complement, district, city, state, country string) (*DeliveryAddress, error) {
-	if err := errors.Join(
+	number = strings.TrimSpace(number)
+	if country == "" {
+		country = deliveryCountry.Load()
+	}
+
+	if err := errs.Join(
 		guard.CheckNotNullOrWhiteSpace(street, ErrInvalidStreet),
-		guard.CheckNotNullOrWhiteSpace(number, ErrInvalidNumber),
+		guard.CheckMatchRegex(number, numberRegex, ErrInvalidNumber),
+		guard.CheckLength(complement, 0, MaxComplementLength, ErrInvalidComplement),
 		guard.CheckNotNullOrWhiteSpace(district, ErrInvalidDistrict),
 		guard.CheckNotNullOrWhiteSpace(city, ErrInvalidCity),
 		guard.CheckNotNullOrWhiteSpace(country, ErrInvalidCountry),
@@ -66,6 +88,38 @@ func NewDeliveryAddress(cep, street, number, complement, district, city, state,
 	}, nil
 }
 
+// deliveryAddressJSON is the wire shape of a [DeliveryAddress].
+type deliveryAddressJSON struct {
+	CEP        string `json:"cep"`
+	Street     string `json:"street"`
+	Number     string `json:"number"`
+	Complement string `json:"complement"`
+	District   string `json:"district"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	Country    string `json:"country"`
+}
+
+// MarshalJSON serializes the address with snake_case field names.
+func (da DeliveryAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(deliveryAddressJSON{da.cep, da.street, da.number, da.complement, da.district, da.city, da.state, da.country})
+}
+
+// UnmarshalJSON parses the shape written by MarshalJSON, validating it as
+// [NewDeliveryAddress] does. Invalid addresses return its error and leave da unchanged.
+func (da *DeliveryAddress) UnmarshalJSON(data []byte) error {
+	var v deliveryAddressJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	parsed, err := NewDeliveryAddress(v.CEP, v.Street, v.Number, v.Complement, v.District, v.City, v.State, v.Country)
+	if err != nil {
+		return err
+	}
+	*da = *parsed
+	return nil
+}
+
 // Equals reports whether da and other represent the same postal address by
 // comparing every field for equality. It returns false if other is nil.
 func (da *DeliveryAddress) Equals(other *DeliveryAddress) bool {
@@ -75,14 +129,113 @@ func (da *DeliveryAddress) Equals(other *DeliveryAddress) bool {
 	return *da == *other
 }
 
+// CEP returns the postal code in the "12345-678" format.
+func (da *DeliveryAddress) CEP() string {
+	return da.cep
+}
+
+// Street returns the street name.
+func (da *DeliveryAddress) Street() string {
+	return da.street
+}
+
+// Number returns the building number on the street.
+func (da *DeliveryAddress) Number() string {
+	return da.number
+}
+
+// Complement returns the optional complement (e.g. apartment), or "" when absent.
+func (da *DeliveryAddress) Complement() string {
+	return da.complement
+}
+
+// WithComplement returns a copy of da with its complement replaced, leaving da
+// unchanged. Like [NewDeliveryAddress] it accepts an empty complement and returns
+// [ErrInvalidComplement] when it is longer than [MaxComplementLength] characters.
+func (da *DeliveryAddress) WithComplement(complement string) (*DeliveryAddress, error) {
+	if err := guard.CheckLength(complement, 0, MaxComplementLength, ErrInvalidComplement); err != nil {
+		return nil, err
+	}
+	next := *da
+	next.complement = complement
+	return &next, nil
+}
+
+// District returns the district (bairro).
+func (da *DeliveryAddress) District() string {
+	return da.district
+}
+
+// City returns the destination city.
+func (da *DeliveryAddress) City() string {
+	return da.city
+}
+
+// State returns the two-letter UF code of the destination state.
+func (da *DeliveryAddress) State() string {
+	return da.state
+}
+
+// Country returns the destination country as given, e.g. "Brasil".
+func (da *DeliveryAddress) Country() string {
+	return da.country
+}
+
+// Region returns the Brazilian macro-region of the destination state (e.g. "Sudeste"),
+// or [RegionInternational] when the country is not Brazil.
+func (da *DeliveryAddress) Region() string {
+	if _, ok := brazilCountryNames[strings.ToUpper(strings.TrimSpace(da.country))]; !ok {
+		return RegionInternational
+	}
+	return stateRegions[strings.ToUpper(da.state)]
+}
+
 // IsZero reports whether the DeliveryAddress is uninitialized (nil pointer or zero-value struct).
 func (da *DeliveryAddress) IsZero() bool {
 	return da == nil || *da == DeliveryAddress{}
 }
 
+// SetValidStates restricts the states accepted by [NewDeliveryAddress] to states, so a
+// deployment can serve only a subset of the country (e.g. loaded from its JSON config).
+// Every entry must be a real Brazilian UF, compared case-insensitively, or
+// [ErrInvalidState] is returned and the current set is kept. An empty list restores the
+// full default.
+func SetValidStates(states []string) error {
+	if len(states) == 0 {
+		servicedStates.Store(validStates)
+		return nil
+	}
+
+	restricted := make(map[string]struct{}, len(states))
+	for _, state := range states {
+		state = strings.ToUpper(state)
+		if _, ok := validStates[state]; !ok {
+			return ErrInvalidState
+		}
+		restricted[state] = struct{}{}
+	}
+	servicedStates.Store(restricted)
+	return nil
+}
+
+// DeliveryCountry returns the country [NewDeliveryAddress] uses when none is given.
+func DeliveryCountry() string {
+	return deliveryCountry.Load()
+}
+
+// SetDeliveryCountry configures the country [NewDeliveryAddress] uses when none is given.
+// country must not be blank ([ErrInvalidCountry]).
+func SetDeliveryCountry(country string) error {
+	if err := guard.CheckNotNullOrWhiteSpace(country, ErrInvalidCountry); err != nil {
+		return err
+	}
+	deliveryCountry.Store(country)
+	return nil
+}
+
 func checkValidState(state string) error {
 	state = strings.ToUpper(state)
-	if _, ok := validStates[state]; !ok {
+	if _, ok := servicedStates.Load()[state]; !ok {
 		return ErrInvalidState
 	}
 	return nil
@@ -92,6 +245,11 @@ func checkValidState(state string) error {
 // Note: The regex is a package-level precompiled variable to avoid recompiling it on every validation of a DeliveryAddress.
 var cepRegex = regexp.MustCompile(`^\d{5}-\d{3}$`)
 
+// Regular expression for a plausible building number: digits optionally followed by a letter,
+// separated by nothing, a space or a hyphen (123, 123A, 123-B), or s/n for "sem número".
+// It is lenient on purpose and only rejects values that cannot be a number, like "!!!".
+var numberRegex = regexp.MustCompile(`(?i)^(\d+([ -]?[a-z])?|s/?n)$`)
+
 // List of valid Brazilian states (UF) for validation. Using a map for O(1) lookups.
 // Note: This is a package-level variable to avoid recreating the map on every validation of a DeliveryAddress.
 var validStates = map[string]struct{}{
@@ -100,3 +258,35 @@ var validStates = map[string]struct{}{
 	"PE": {}, "PI": {}, "RJ": {}, "RN": {}, "RS": {}, "RO": {}, "RR": {}, "SC": {},
 	"SP": {}, "SE": {}, "TO": {},
 }
+
+// Macro-regions returned by [DeliveryAddress.Region].
+const (
+	RegionNorte         = "Norte"
+	RegionNordeste      = "Nordeste"
+	RegionCentroOeste   = "Centro-Oeste"
+	RegionSudeste       = "Sudeste"
+	RegionSul           = "Sul"
+	RegionInternational = "international"
+)
+
+// stateRegions maps each Brazilian state (UF) to its IBGE macro-region.
+var stateRegions = map[string]string{
+	"AC": RegionNorte, "AM": RegionNorte, "AP": RegionNorte, "PA": RegionNorte,
+	"RO": RegionNorte, "RR": RegionNorte, "TO": RegionNorte,
+	"AL": RegionNordeste, "BA": RegionNordeste, "CE": RegionNordeste, "MA": RegionNordeste,
+	"PB": RegionNordeste, "PE": RegionNordeste, "PI": RegionNordeste, "RN": RegionNordeste,
+	"SE": RegionNordeste,
+	"DF": RegionCentroOeste, "GO": RegionCentroOeste, "MT": RegionCentroOeste, "MS": RegionCentroOeste,
+	"ES": RegionSudeste, "MG": RegionSudeste, "RJ": RegionSudeste, "SP": RegionSudeste,
+	"PR": RegionSul, "RS": RegionSul, "SC": RegionSul,
+}
+
+// brazilCountryNames lists the upper-cased country values treated as Brazil by
+// [DeliveryAddress.Region].
+var brazilCountryNames = map[string]struct{}{
+	"BRASIL": {}, "BRAZIL": {}, "BR": {},
+}
+
+// servicedStates is the subset of validStates accepted for delivery, configured with
+// [SetValidStates]. It defaults to every state.
+var servicedStates = kernel.NewSetting(validStates)
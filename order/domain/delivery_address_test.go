@@ -2,9 +2,12 @@ package order_test
 
 import (
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -52,6 +55,28 @@ func TestNewDeliveryAddress(t *testing.T) {
 				"12345-678", "Street", "123", "", "District", "City", "BA", "Country",
 			)),
 		},
+		{
+			name: "should accept a number followed by a letter",
+			args: args{
+				cep: "12345-678", street: "Street", number: "123A",
+				complement: "", district: "District", city: "City",
+				state: "BA", country: "Country",
+			},
+			want: kernel.Must(order.NewDeliveryAddress(
+				"12345-678", "Street", "123A", "", "District", "City", "BA", "Country",
+			)),
+		},
+		{
+			name: "should accept s/n for an unnumbered building",
+			args: args{
+				cep: "12345-678", street: "Street", number: "s/n",
+				complement: "", district: "District", city: "City",
+				state: "BA", country: "Country",
+			},
+			want: kernel.Must(order.NewDeliveryAddress(
+				"12345-678", "Street", "s/n", "", "District", "City", "BA", "Country",
+			)),
+		},
 	}
 	for _, tt := range successTests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -81,6 +106,16 @@ func TestNewDeliveryAddress(t *testing.T) {
 			args:    args{cep: "12345-678", street: "Street", number: "", complement: "Complement", district: "District", city: "City", state: "BA", country: "Country"},
 			wantErr: order.ErrInvalidNumber,
 		},
+		{
+			name:    "should return an error when number has no digits",
+			args:    args{cep: "12345-678", street: "Street", number: "!!!", complement: "Complement", district: "District", city: "City", state: "BA", country: "Country"},
+			wantErr: order.ErrInvalidNumber,
+		},
+		{
+			name:    "should return an error when number is not numeric",
+			args:    args{cep: "12345-678", street: "Street", number: "abc!@#", complement: "Complement", district: "District", city: "City", state: "BA", country: "Country"},
+			wantErr: order.ErrInvalidNumber,
+		},
 		{
 			name:    "should return an error when district is empty",
 			args:    args{cep: "12345-678", street: "Street", number: "123", complement: "Complement", district: "", city: "City", state: "BA", country: "Country"},
@@ -92,8 +127,8 @@ func TestNewDeliveryAddress(t *testing.T) {
 			wantErr: order.ErrInvalidCity,
 		},
 		{
-			name:    "should return an error when country is empty",
-			args:    args{cep: "12345-678", street: "Street", number: "123", complement: "Complement", district: "District", city: "City", state: "BA", country: ""},
+			name:    "should return an error when country is whitespace",
+			args:    args{cep: "12345-678", street: "Street", number: "123", complement: "Complement", district: "District", city: "City", state: "BA", country: "   "},
 			wantErr: order.ErrInvalidCountry,
 		},
 		{
@@ -151,6 +186,101 @@ func TestNewDeliveryAddress(t *testing.T) {
 	}
 }
 
+func TestNewDeliveryAddress_MaxViolations(t *testing.T) {
+	t.Cleanup(func() { _ = errs.SetMaxViolations(errs.DefaultMaxViolations) })
+	require.NoError(t, errs.SetMaxViolations(3))
+
+	_, err := order.NewDeliveryAddress("invalid", "", "!!!", strings.Repeat("a", order.MaxComplementLength+1), "", "", "XX", " ")
+
+	entries := errs.MarshalChain(err)
+	require.Len(t, entries, 4, "the cap plus the marker")
+	assert.ErrorIs(t, err, order.ErrInvalidStreet)
+	assert.ErrorIs(t, err, order.ErrInvalidNumber)
+	assert.ErrorIs(t, err, order.ErrInvalidComplement)
+	assert.NotErrorIs(t, err, order.ErrInvalidCEP, "violations past the cap should be dropped")
+	assert.Equal(t, "...and 5 more", entries[3]["message"])
+}
+
+func TestNewDeliveryAddress_Number(t *testing.T) {
+	newAddress := func(number string) (*order.DeliveryAddress, error) {
+		return order.NewDeliveryAddress("12345-678", "Street", number, "", "District", "City", "BA", "Country")
+	}
+
+	// ==================== Success cases ==================== //
+	for _, number := range []string{"123", "123A", "123b", "123 B", "123-B", "s/n", "S/N", "sn"} {
+		t.Run("should accept "+strconv.Quote(number), func(t *testing.T) {
+			got, err := newAddress(number)
+
+			require.NoError(t, err)
+			assert.Equal(t, number, got.Number())
+		})
+	}
+
+	t.Run("should trim surrounding whitespace from the number", func(t *testing.T) {
+		got, err := newAddress(" 45 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "45", got.Number())
+	})
+
+	// ==================== Failure cases ==================== //
+	for _, number := range []string{"!!!", "abc", "A123", "123AB", "12.5", "s/n/", "   "} {
+		t.Run("should reject "+strconv.Quote(number), func(t *testing.T) {
+			_, err := newAddress(number)
+
+			assert.ErrorIs(t, err, order.ErrInvalidNumber)
+		})
+	}
+}
+
+func TestNewDeliveryAddress_Complement(t *testing.T) {
+	newAddress := func(complement string) (*order.DeliveryAddress, error) {
+		return order.NewDeliveryAddress("12345-678", "Street", "1", complement, "District", "City", "BA", "Country")
+	}
+
+	// ==================== Success cases ==================== //
+	for _, complement := range []string{"", "Apto 12, Bloco B", strings.Repeat("a", order.MaxComplementLength)} {
+		t.Run("should accept a complement of "+strconv.Itoa(len(complement))+" characters", func(t *testing.T) {
+			got, err := newAddress(complement)
+
+			require.NoError(t, err)
+			assert.Equal(t, complement, got.Complement())
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	t.Run("should reject a complement longer than MaxComplementLength", func(t *testing.T) {
+		_, err := newAddress(strings.Repeat("a", order.MaxComplementLength+1))
+
+		assert.ErrorIs(t, err, order.ErrInvalidComplement)
+	})
+}
+
+func TestDeliveryAddress_WithComplement(t *testing.T) {
+	base := kernel.Must(order.NewDeliveryAddress("12345-678", "Street", "1", "Apto 1", "District", "City", "BA", "Country"))
+
+	// ==================== Success cases ==================== //
+	for _, complement := range []string{"", "Apto 12, Bloco B", strings.Repeat("a", order.MaxComplementLength)} {
+		t.Run("should replace the complement with one of "+strconv.Itoa(len(complement))+" characters", func(t *testing.T) {
+			got, err := base.WithComplement(complement)
+
+			require.NoError(t, err)
+			assert.Equal(t, complement, got.Complement())
+			assert.Equal(t, base.Street(), got.Street())
+			assert.Equal(t, "Apto 1", base.Complement(), "the original address should be unchanged")
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	t.Run("should reject a complement longer than MaxComplementLength", func(t *testing.T) {
+		got, err := base.WithComplement(strings.Repeat("a", order.MaxComplementLength+1))
+
+		assert.ErrorIs(t, err, order.ErrInvalidComplement)
+		assert.Nil(t, got)
+		assert.Equal(t, "Apto 1", base.Complement())
+	})
+}
+
 func TestDeliveryAddress_Equals(t *testing.T) {
 	baseAddr := kernel.Must(order.NewDeliveryAddress(
 		"12345-678", "Street", "123", "",
@@ -220,8 +350,136 @@ func TestDeliveryAddress_IsZero(t *testing.T) {
 	}
 }
 
+func TestDeliveryAddress_Region(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   string
+		country string
+		want    string
+	}{
+		{name: "should map AM to Norte", state: "AM", country: "Brasil", want: order.RegionNorte},
+		{name: "should map BA to Nordeste", state: "BA", country: "Brasil", want: order.RegionNordeste},
+		{name: "should map DF to Centro-Oeste", state: "DF", country: "Brasil", want: order.RegionCentroOeste},
+		{name: "should map SP to Sudeste", state: "SP", country: "Brazil", want: order.RegionSudeste},
+		{name: "should map RS to Sul", state: "RS", country: "BR", want: order.RegionSul},
+		{name: "should return international for a non-Brazilian address", state: "SP", country: "Portugal", want: order.RegionInternational},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := kernel.Must(order.NewDeliveryAddress("12345-678", "Street", "123", "", "District", "City", tt.state, tt.country))
+
+			got := addr.Region()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 // This test ensures that all fields of the DeliveryAddress struct, as value object,
 // are unexported, preventing external mutation after construction.
+func TestDeliveryAddress_Getters(t *testing.T) {
+	t.Run("should return every field given to the constructor", func(t *testing.T) {
+		da := kernel.Must(order.NewDeliveryAddress("12345-678", "Rua das Flores", "100", "Apto 12", "Centro", "São Paulo", "SP", "Brasil"))
+
+		assert.Equal(t, "12345-678", da.CEP())
+		assert.Equal(t, "Rua das Flores", da.Street())
+		assert.Equal(t, "100", da.Number())
+		assert.Equal(t, "Apto 12", da.Complement())
+		assert.Equal(t, "Centro", da.District())
+		assert.Equal(t, "São Paulo", da.City())
+		assert.Equal(t, "SP", da.State())
+		assert.Equal(t, "Brasil", da.Country())
+	})
+}
+
+func TestSetValidStates(t *testing.T) {
+	newAddressIn := func(state string) (*order.DeliveryAddress, error) {
+		return order.NewDeliveryAddress("12345-678", "Rua das Flores", "100", "", "Centro", "Cidade", state, "Brasil")
+	}
+	restoreDefault := func(t *testing.T) {
+		t.Cleanup(func() { require.NoError(t, order.SetValidStates(nil)) })
+	}
+
+	t.Run("should accept only the configured states", func(t *testing.T) {
+		restoreDefault(t)
+
+		err := order.SetValidStates([]string{"SP", "rj"})
+
+		require.NoError(t, err)
+		_, err = newAddressIn("SP")
+		assert.NoError(t, err)
+		_, err = newAddressIn("RJ")
+		assert.NoError(t, err)
+		_, err = newAddressIn("BA")
+		assert.ErrorIs(t, err, order.ErrInvalidState)
+	})
+
+	t.Run("should restore every state when given an empty list", func(t *testing.T) {
+		restoreDefault(t)
+		require.NoError(t, order.SetValidStates([]string{"SP"}))
+
+		err := order.SetValidStates(nil)
+
+		require.NoError(t, err)
+		_, err = newAddressIn("BA")
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return an error and keep the current set when a state is not a real UF", func(t *testing.T) {
+		restoreDefault(t)
+
+		err := order.SetValidStates([]string{"SP", "XX"})
+
+		assert.ErrorIs(t, err, order.ErrInvalidState)
+		_, err = newAddressIn("BA")
+		assert.NoError(t, err, "default states should still be accepted")
+	})
+}
+
+func TestSetDeliveryCountry(t *testing.T) {
+	newAddressIn := func(country string) (*order.DeliveryAddress, error) {
+		return order.NewDeliveryAddress("12345-678", "Rua das Flores", "100", "", "Centro", "Cidade", "SP", country)
+	}
+	restoreDefault := func(t *testing.T) {
+		t.Cleanup(func() { require.NoError(t, order.SetDeliveryCountry(order.DefaultDeliveryCountry)) })
+	}
+
+	t.Run("should fill in the default country when none is given", func(t *testing.T) {
+		da, err := newAddressIn("")
+
+		require.NoError(t, err)
+		assert.Equal(t, order.DefaultDeliveryCountry, da.Country())
+	})
+
+	t.Run("should keep an explicit country", func(t *testing.T) {
+		da, err := newAddressIn("Portugal")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Portugal", da.Country())
+	})
+
+	t.Run("should fill in the configured country", func(t *testing.T) {
+		restoreDefault(t)
+
+		err := order.SetDeliveryCountry("Brazil")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Brazil", order.DeliveryCountry())
+		da, err := newAddressIn("")
+		require.NoError(t, err)
+		assert.Equal(t, "Brazil", da.Country())
+	})
+
+	t.Run("should return an error and keep the current country when given a blank one", func(t *testing.T) {
+		restoreDefault(t)
+
+		err := order.SetDeliveryCountry(" ")
+
+		assert.ErrorIs(t, err, order.ErrInvalidCountry)
+		assert.Equal(t, order.DefaultDeliveryCountry, order.DeliveryCountry())
+	})
+}
+
 func TestDeliveryAddress_MustBeImmutable(t *testing.T) {
 	typ := reflect.TypeFor[order.DeliveryAddress]()
 	for f := range typ.Fields() {
@@ -0,0 +1,45 @@
+package order
+
+import (
+	"fmt"
+	"slices"
+)
+
+// FieldDiff is a field on which two orders disagree, as reported by [Diff].
+type FieldDiff struct {
+	Field string // "status", "total", "items" or "delivery_address"
+	Old   any    // value in the first order
+	New   any    // value in the second order
+}
+
+// Diff compares a with b field by field, e.g. a stored order with its in-memory copy,
+// and returns the fields that differ: the status, the total, the item set as
+// "productID×quantity" lines sorted by product, and the delivery address. Identical
+// orders yield no diffs. It is a debugging aid for tests and ops tooling; a and b must
+// not be nil.
+func Diff(a, b *Order) []FieldDiff {
+	var diffs []FieldDiff
+	if !a.Status.Equals(b.Status) {
+		diffs = append(diffs, FieldDiff{Field: "status", Old: a.Status, New: b.Status})
+	}
+	if a.Total() != b.Total() {
+		diffs = append(diffs, FieldDiff{Field: "total", Old: a.Total(), New: b.Total()})
+	}
+	if oldItems, newItems := a.itemSet(), b.itemSet(); !slices.Equal(oldItems, newItems) {
+		diffs = append(diffs, FieldDiff{Field: "items", Old: oldItems, New: newItems})
+	}
+	if !a.DeliveryAddress.Equals(&b.DeliveryAddress) {
+		diffs = append(diffs, FieldDiff{Field: "delivery_address", Old: a.DeliveryAddress, New: b.DeliveryAddress})
+	}
+	return diffs
+}
+
+// itemSet lists the lines of the order as "productID×quantity", sorted by product.
+func (o *Order) itemSet() []string {
+	lines := make([]string, 0, len(o.items))
+	for _, item := range o.Items() {
+		lines = append(lines, fmt.Sprintf("%s×%d", item.ProductID, item.Quantity))
+	}
+	slices.Sort(lines)
+	return lines
+}
@@ -0,0 +1,59 @@
+package order_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func copyOf(t *testing.T, o *order.Order) *order.Order {
+	t.Helper()
+	return kernel.Must(order.FromSnapshot(o.Snapshot()))
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("should report no diffs for identical orders", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		got := order.Diff(o, copyOf(t, o))
+
+		assert.Empty(t, got)
+	})
+
+	t.Run("should report the items and the total when a line is added", func(t *testing.T) {
+		stored := createOrderWithItems(t)
+		current := copyOf(t, stored)
+		require.NoError(t, current.AddItem("prod-2", "Gadget", 10.0, 3))
+
+		got := order.Diff(stored, current)
+
+		assert.Equal(t, []order.FieldDiff{
+			{Field: "total", Old: 100.0, New: 130.0},
+			{Field: "items", Old: []string{"prod-1×2"}, New: []string{"prod-1×2", "prod-2×3"}},
+		}, got)
+	})
+
+	t.Run("should report a changed delivery address", func(t *testing.T) {
+		stored := createOrderWithItems(t)
+		current := copyOf(t, stored)
+		newAddr := kernel.Must(order.NewDeliveryAddress("98765-432", "Av. Brasil", "500", "", "Jardins", "Rio de Janeiro", "RJ", "Brasil"))
+		require.NoError(t, current.UpdateDeliveryAddress(*newAddr))
+
+		got := order.Diff(stored, current)
+
+		assert.Equal(t, []order.FieldDiff{{Field: "delivery_address", Old: stored.DeliveryAddress, New: *newAddr}}, got)
+	})
+
+	t.Run("should report a changed status", func(t *testing.T) {
+		paid := driveOrderToPaid(t)
+		stale := copyOf(t, paid)
+		stale.Status = order.StatusPending
+
+		got := order.Diff(stale, paid)
+
+		assert.Equal(t, []order.FieldDiff{{Field: "status", Old: order.StatusPending, New: order.StatusPaid}}, got)
+	})
+}
@@ -0,0 +1,28 @@
+package order_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/promo"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSentinelErrors_UniqueCodes guards the error catalog of the order aggregate and its
+// entities: errors.Is matches domain errors by code, so no two may share one. The catalog
+// is collected by errs.New, so new sentinels are checked without being listed here.
+func TestSentinelErrors_UniqueCodes(t *testing.T) {
+	registered := errs.Sentinels()
+	sentinels := make([]error, 0, len(registered))
+	for _, sentinel := range registered {
+		sentinels = append(sentinels, sentinel)
+	}
+	for _, want := range []error{orderitem.ErrInvalidProductID, payment.ErrInvalidChargebackReason, promo.ErrInvalidCouponCode} {
+		require.Contains(t, sentinels, want, "the catalog of every domain package should be collected")
+	}
+
+	testfixtures.AssertUniqueCodes(t, sentinels...)
+}
@@ -0,0 +1,31 @@
+package order
+
+import (
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrInvalidUnpaidOrderTTL = errs.New("ORDER.INVALID_UNPAID_TTL", "unpaid order TTL must be greater than zero")
+
+// DefaultUnpaidOrderTTL is how long a pending order may stay unpaid before it can be
+// expired, unless overridden with [SetUnpaidOrderTTL].
+const DefaultUnpaidOrderTTL = 24 * time.Hour
+
+var unpaidOrderTTL = kernel.NewSetting(DefaultUnpaidOrderTTL)
+
+// UnpaidOrderTTL returns the configured time-to-live of unpaid orders.
+func UnpaidOrderTTL() time.Duration {
+	return unpaidOrderTTL.Load()
+}
+
+// SetUnpaidOrderTTL configures how long a pending order may stay unpaid before
+// [Order.Expire] accepts it. ttl must be strictly positive.
+func SetUnpaidOrderTTL(ttl time.Duration) error {
+	if ttl <= 0 {
+		return ErrInvalidUnpaidOrderTTL
+	}
+	unpaidOrderTTL.Store(ttl)
+	return nil
+}
@@ -0,0 +1,27 @@
+package order_test
+
+import (
+	"testing"
+	"time"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetUnpaidOrderTTL(t *testing.T) {
+	t.Cleanup(func() { _ = order.SetUnpaidOrderTTL(order.DefaultUnpaidOrderTTL) })
+
+	t.Run("should configure a positive TTL", func(t *testing.T) {
+		err := order.SetUnpaidOrderTTL(time.Hour)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Hour, order.UnpaidOrderTTL())
+	})
+
+	t.Run("should return an error when TTL is not positive", func(t *testing.T) {
+		err := order.SetUnpaidOrderTTL(0)
+
+		assert.ErrorIs(t, err, order.ErrInvalidUnpaidOrderTTL)
+		assert.Equal(t, time.Hour, order.UnpaidOrderTTL(), "TTL should be unchanged")
+	})
+}
@@ -0,0 +1,45 @@
+package order
+
+import (
+	"context"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var (
+	ErrInsufficientStock    = errs.New("INVENTORY.INSUFFICIENT", "not enough stock to reserve the order items")
+	ErrReservationNotFound  = errs.NewNotFound("INVENTORY.RESERVATION_NOT_FOUND", "reservation not found")
+	ErrInvalidReservationID = errs.New("ORDER.INVALID_RESERVATION_ID", "reservation ID cannot be null or whitespace")
+)
+
+// ReservationLine asks the inventory to hold Quantity units of ProductID.
+type ReservationLine struct {
+	ProductID string
+	Quantity  int
+}
+
+// InventoryReserver is the port that holds stock for paid orders, so the items sold are
+// not sold again before the order is shipped. Shipping consumes the reservation (see
+// [Order.Ship]); a reservation whose order could not be completed is released back to
+// the pool.
+type InventoryReserver interface {
+	// Reserve holds every line at once and returns the ID of the reservation, or
+	// [ErrInsufficientStock] without holding anything when a product lacks stock.
+	Reserve(ctx context.Context, items []ReservationLine) (reservationID string, err error)
+
+	// Release returns the stock held by reservationID to the pool. Releasing a
+	// reservation again is a no-op, so callers can retry; [ErrReservationNotFound] is
+	// returned when the reservation never existed.
+	Release(ctx context.Context, reservationID string) error
+}
+
+// ReservationLines returns one [ReservationLine] per line item of the order, in the
+// order of [Order.Items].
+func (o *Order) ReservationLines() []ReservationLine {
+	items := o.Items()
+	lines := make([]ReservationLine, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, ReservationLine{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+	return lines
+}
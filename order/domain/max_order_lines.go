@@ -0,0 +1,33 @@
+package order
+
+import (
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var (
+	ErrInvalidMaxOrderLines = errs.New("ORDER.INVALID_MAX_LINES", "maximum number of order lines must be greater than zero")
+	ErrTooManyLines         = errs.New("ORDER.TOO_MANY_LINES", "order cannot hold more distinct lines than the configured maximum")
+)
+
+// DefaultMaxOrderLines is the largest number of distinct product lines an order may
+// hold, unless overridden with [SetMaxOrderLines].
+const DefaultMaxOrderLines = 100
+
+var maxOrderLines = kernel.NewSetting(DefaultMaxOrderLines)
+
+// MaxOrderLines returns the configured maximum number of distinct lines per order.
+func MaxOrderLines() int {
+	return maxOrderLines.Load()
+}
+
+// SetMaxOrderLines configures the number of distinct lines [Order.AddItem] and
+// [NewOrderWithItems] allow; adding units to an existing line never counts against it.
+// n must be greater than zero.
+func SetMaxOrderLines(n int) error {
+	if n <= 0 {
+		return ErrInvalidMaxOrderLines
+	}
+	maxOrderLines.Store(n)
+	return nil
+}
@@ -0,0 +1,111 @@
+package order_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetMaxOrderLines(t *testing.T) {
+	t.Cleanup(func() { _ = order.SetMaxOrderLines(order.DefaultMaxOrderLines) })
+
+	t.Run("should default to DefaultMaxOrderLines", func(t *testing.T) {
+		assert.Equal(t, 100, order.MaxOrderLines())
+	})
+
+	t.Run("should configure a positive maximum", func(t *testing.T) {
+		err := order.SetMaxOrderLines(3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, order.MaxOrderLines())
+	})
+
+	t.Run("should return an error when the maximum is not positive", func(t *testing.T) {
+		for _, n := range []int{0, -1} {
+			err := order.SetMaxOrderLines(n)
+
+			assert.ErrorIs(t, err, order.ErrInvalidMaxOrderLines)
+			assert.Equal(t, 3, order.MaxOrderLines(), "maximum should be unchanged")
+		}
+	})
+}
+
+func TestOrder_AddItem_MaxOrderLines(t *testing.T) {
+	t.Cleanup(func() { _ = order.SetMaxOrderLines(order.DefaultMaxOrderLines) })
+	require.NoError(t, order.SetMaxOrderLines(3))
+
+	t.Run("should accept lines up to the maximum", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		require.NoError(t, o.AddItem("prod-2", "Gadget", 10.0, 1))
+		require.NoError(t, o.AddItem("prod-3", "Gizmo", 5.0, 1))
+
+		assert.Len(t, o.Items(), 3)
+	})
+
+	t.Run("should reject a new line beyond the maximum", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.AddItem("prod-2", "Gadget", 10.0, 1))
+		require.NoError(t, o.AddItem("prod-3", "Gizmo", 5.0, 1))
+		total := o.TotalAmount
+
+		err := o.AddItem("prod-4", "Doohickey", 1.0, 1)
+
+		assert.ErrorIs(t, err, order.ErrTooManyLines)
+		assert.Len(t, o.Items(), 3)
+		assert.Equal(t, total, o.TotalAmount, "total should be unchanged")
+	})
+
+	t.Run("should merge into an existing line at the maximum", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.AddItem("prod-2", "Gadget", 10.0, 1))
+		require.NoError(t, o.AddItem("prod-3", "Gizmo", 5.0, 1))
+
+		err := o.AddItem("prod-1", "Widget", 50.0, 1)
+
+		require.NoError(t, err)
+		assert.Len(t, o.Items(), 3)
+		assert.Equal(t, 165.0, o.TotalAmount, "prod-1 should hold three units")
+	})
+}
+
+func TestNewOrderWithItems_MaxOrderLines(t *testing.T) {
+	t.Cleanup(func() { _ = order.SetMaxOrderLines(order.DefaultMaxOrderLines) })
+	require.NoError(t, order.SetMaxOrderLines(3))
+
+	lines := func(n int) []*orderitem.OrderItem {
+		items := make([]*orderitem.OrderItem, 0, n)
+		for i := range n {
+			items = append(items, kernel.Must(orderitem.NewOrderItem(fmt.Sprintf("prod-%d", i+1), "Widget", 10.0, 1)))
+		}
+		return items
+	}
+
+	t.Run("should accept lines up to the maximum", func(t *testing.T) {
+		got, err := order.NewOrderWithItems("cust-123", createValidAddress(t), lines(3))
+
+		require.NoError(t, err)
+		assert.Len(t, got.Items(), 3)
+	})
+
+	t.Run("should not count merged lines against the maximum", func(t *testing.T) {
+		items := append(lines(3), kernel.Must(orderitem.NewOrderItem("prod-1", "Widget", 10.0, 2)))
+
+		got, err := order.NewOrderWithItems("cust-123", createValidAddress(t), items)
+
+		require.NoError(t, err)
+		assert.Len(t, got.Items(), 3)
+	})
+
+	t.Run("should return an error for one line over the maximum", func(t *testing.T) {
+		got, err := order.NewOrderWithItems("cust-123", createValidAddress(t), lines(4))
+
+		assert.ErrorIs(t, err, order.ErrTooManyLines)
+		assert.Nil(t, got)
+	})
+}
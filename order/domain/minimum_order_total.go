@@ -0,0 +1,29 @@
+package order
+
+import (
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrInvalidMinimumOrderTotal = errs.New("ORDER.INVALID_MINIMUM_TOTAL", "minimum order total cannot be negative")
+
+// DefaultMinimumOrderTotal is the smallest total an order may be paid with, unless
+// overridden with [SetMinimumOrderTotal]. Zero means no minimum.
+const DefaultMinimumOrderTotal = 0.0
+
+var minimumOrderTotal = kernel.NewSetting(DefaultMinimumOrderTotal)
+
+// MinimumOrderTotal returns the configured minimum order total.
+func MinimumOrderTotal() float64 {
+	return minimumOrderTotal.Load()
+}
+
+// SetMinimumOrderTotal configures the smallest total [Order.StartPayment] accepts.
+// v must not be negative.
+func SetMinimumOrderTotal(v float64) error {
+	if v < 0 {
+		return ErrInvalidMinimumOrderTotal
+	}
+	minimumOrderTotal.Store(v)
+	return nil
+}
@@ -0,0 +1,57 @@
+package order_test
+
+import (
+	"testing"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetMinimumOrderTotal(t *testing.T) {
+	t.Cleanup(func() { _ = order.SetMinimumOrderTotal(order.DefaultMinimumOrderTotal) })
+
+	t.Run("should default to no minimum", func(t *testing.T) {
+		assert.Equal(t, 0.0, order.MinimumOrderTotal())
+	})
+
+	t.Run("should configure a non-negative minimum", func(t *testing.T) {
+		err := order.SetMinimumOrderTotal(150.0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 150.0, order.MinimumOrderTotal())
+	})
+
+	t.Run("should return an error when the minimum is negative", func(t *testing.T) {
+		err := order.SetMinimumOrderTotal(-1)
+
+		assert.ErrorIs(t, err, order.ErrInvalidMinimumOrderTotal)
+		assert.Equal(t, 150.0, order.MinimumOrderTotal(), "minimum should be unchanged")
+	})
+}
+
+func TestOrder_StartPayment_MinimumOrderTotal(t *testing.T) {
+	t.Cleanup(func() { _ = order.SetMinimumOrderTotal(order.DefaultMinimumOrderTotal) })
+	require.NoError(t, order.SetMinimumOrderTotal(150.0))
+
+	t.Run("should block payment of an order below the minimum", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		p, err := o.StartPayment(payment.MethodCreditCard)
+
+		assert.ErrorIs(t, err, order.ErrOrderBelowMinimum)
+		assert.Nil(t, p)
+		assert.Empty(t, o.Payments())
+	})
+
+	t.Run("should allow payment of an order reaching the minimum", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.AddItem("prod-2", "Gadget", 50.0, 1))
+
+		p, err := o.StartPayment(payment.MethodCreditCard)
+
+		require.NoError(t, err)
+		assert.Equal(t, 150.0, p.Amount)
+	})
+}
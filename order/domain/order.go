@@ -1,7 +1,11 @@
 package order
 
 import (
-	"errors"
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
@@ -9,68 +13,163 @@ import (
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/promo"
 )
 
 var (
-	ErrInvalidCustomerID      = errs.New("ORDER.INVALID_CUSTOMER_ID", "customer ID cannot be null or whitespace")
-	ErrInvalidDeliveryAddress = errs.New("ORDER.INVALID_DELIVERY_ADDRESS", "delivery address cannot be zero")
-	ErrOrderNotPending        = errs.New("ORDER.NOT_PENDING", "order must be in pending status to perform this operation")
-	ErrItemNotFound           = errs.New("ORDER.ITEM_NOT_FOUND", "item not found in order")
-	ErrCannotRemoveLastItem   = errs.New("ORDER.CANNOT_REMOVE_LAST_ITEM", "cannot remove the last item from an order")
-	ErrNoItems                = errs.New("ORDER.NO_ITEMS", "order must have at least one item to start payment")
-	ErrPaymentAlreadyPending  = errs.New("ORDER.PAYMENT_ALREADY_PENDING", "order already has a pending payment")
-	ErrOrderNotPaid           = errs.New("ORDER.NOT_PAID", "order must be in paid status to start separating")
-	ErrOrderNotSeparating     = errs.New("ORDER.NOT_SEPARATING", "order must be in separating status to be shipped")
-	ErrOrderNotShipped        = errs.New("ORDER.NOT_SHIPPED", "order must be in shipped status to be delivered")
-	ErrOrderCannotCancel      = errs.New("ORDER.CANNOT_CANCEL", "order cannot be cancelled in its current status")
+	ErrInvalidCustomerID       = errs.New("ORDER.INVALID_CUSTOMER_ID", "customer ID cannot be null or whitespace")
+	ErrInvalidDeliveryAddress  = errs.New("ORDER.INVALID_DELIVERY_ADDRESS", "delivery address cannot be zero")
+	ErrOrderNotPending         = errs.NewConflict("ORDER.NOT_PENDING", "order must be in pending status to perform this operation")
+	ErrItemNotFound            = errs.NewNotFound("ORDER.ITEM_NOT_FOUND", "item not found in order")
+	ErrCannotRemoveLastItem    = errs.New("ORDER.CANNOT_REMOVE_LAST_ITEM", "cannot remove the last item from an order")
+	ErrNoItems                 = errs.New("ORDER.NO_ITEMS", "order must have at least one item to start payment")
+	ErrPaymentAlreadyPending   = errs.NewConflict("ORDER.PAYMENT_ALREADY_PENDING", "order already has a pending payment")
+	ErrOrderNotPaid            = errs.NewConflict("ORDER.NOT_PAID", "order must be in paid status to start separating")
+	ErrOrderNotSeparating      = errs.NewConflict("ORDER.NOT_SEPARATING", "order must be in separating status to be shipped")
+	ErrOrderNotShipped         = errs.NewConflict("ORDER.NOT_SHIPPED", "order must be in shipped status to be delivered")
+	ErrOrderCannotCancel       = errs.NewConflict("ORDER.CANNOT_CANCEL", "order cannot be cancelled in its current status")
+	ErrOrderNotGift            = errs.NewConflict("ORDER.NOT_GIFT", "order must be a gift to be paid without a payment")
+	ErrGiftOrderNotPayable     = errs.NewConflict("ORDER.GIFT_NOT_PAYABLE", "gift orders do not require a payment")
+	ErrCouponMinNotMet         = errs.New("ORDER.COUPON_MIN_NOT_MET", "order total does not reach the coupon minimum order total")
+	ErrCreditExceedsTotal      = errs.New("ORDER.CREDIT_EXCEEDS_TOTAL", "credits cannot drive the order total below zero")
+	ErrOrderNotExpired         = errs.NewConflict("ORDER.NOT_EXPIRED", "order has not exceeded its unpaid time-to-live")
+	ErrInvalidStatusTransition = errs.NewConflict("ORDER.INVALID_STATUS_TRANSITION", "order status does not allow this transition")
+	ErrReturnWindowExpired     = errs.NewConflict("ORDER.RETURN_WINDOW_EXPIRED", "return window for the order has expired")
+	ErrCannotEditPaidOrder     = errs.NewConflict("ORDER.CANNOT_EDIT_WITH_PENDING_PAYMENT", "order cannot be edited while a payment is pending")
+	ErrNoPaymentToRetry        = errs.NewConflict("ORDER.NO_PAYMENT_TO_RETRY", "order has no previous payment to retry")
+	ErrPaymentNotFound         = errs.NewNotFound("ORDER.PAYMENT_NOT_FOUND", "payment not found in order")
+	ErrOrderOnHold             = errs.NewConflict("ORDER.ON_HOLD", "order is on hold for review and cannot advance")
+	ErrOrderNotOnHold          = errs.NewConflict("ORDER.NOT_ON_HOLD", "order is not on hold")
+	ErrInvalidHoldReason       = errs.New("ORDER.INVALID_HOLD_REASON", "hold reason cannot be null or whitespace")
+	ErrOrderBelowMinimum       = errs.New("ORDER.BELOW_MINIMUM", "order total is below the minimum order total")
+	ErrNoReturnItems           = errs.New("ORDER.NO_RETURN_ITEMS", "a return request must name at least one item")
+	ErrReturnAlreadyRequested  = errs.NewConflict("ORDER.RETURN_ALREADY_REQUESTED", "a return has already been requested for the order")
+	ErrInstructionsTooLong     = errs.New("ORDER.INSTRUCTIONS_TOO_LONG", "delivery instructions cannot be longer than 500 characters")
+	ErrInvalidTrackingCode     = errs.New("ORDER.INVALID_TRACKING_CODE", "tracking code cannot be null or whitespace")
+	ErrMultipleActivePayments  = errs.New("ORDER.MULTIPLE_ACTIVE_PAYMENTS", "order cannot have more than one active payment")
+	ErrInvalidPayment          = errs.New("ORDER.INVALID_PAYMENT", "payment cannot be nil and must belong to the order")
+	ErrPaymentAmountMismatch   = errs.NewConflict("ORDER.PAYMENT_AMOUNT_MISMATCH", "payment amount does not match the order total")
+	ErrDiscountExceedsTotal    = errs.New("ORDER.DISCOUNT_EXCEEDS_TOTAL", "coupon discount and credits cannot exceed the items total")
 )
 
+// MaxInstructionsLength is the maximum number of characters of [Order.Instructions].
+const MaxInstructionsLength = 500
+
 // Order is the aggregate root of the order bounded context.
 // It owns the lifecycle of its associated payment and order items.
+//
+// An order is editable while it is pending and has no pending payment: changes to items,
+// coupon, credits, tax, delivery address or shipping method are rejected with
+// [ErrCannotEditPaidOrder] once a payment is started, so the payment amount never goes
+// stale, and are allowed again after that payment is refused.
+//
+// The coupon discount and credits together never exceed the items total: an item change
+// or coupon that would make them do so is rejected with [ErrDiscountExceedsTotal]
+// instead of clamping the total to zero and silently dropping part of the discount.
+//
+// Command methods return [kernel.ErrNilAggregate] instead of panicking when called on a
+// nil *Order.
 type Order struct {
 	kernel.AggregateRoot
-	ID              string
-	CustomerID      string
-	DeliveryAddress DeliveryAddress
-	TotalAmount     float64
-	Status          Status
-	Number          string
-	UpdatedAt       *time.Time
+	CustomerID      string          `json:"customer_id"`
+	DeliveryAddress DeliveryAddress `json:"delivery_address"`
+	TotalAmount     float64         `json:"total_amount"`    // sum of item totals minus DiscountAmount and Credits, plus TaxAmount
+	DiscountAmount  float64         `json:"discount_amount"` // discount granted by Coupon
+	Coupon          *promo.Coupon   `json:"coupon"`
+	Credits         []Credit        `json:"credits"`
+	TaxRate         float64         `json:"tax_rate"` // rate picked from the tax policy for the destination state
+	ShippingMethod  ShippingMethod  `json:"shipping_method"`
+	Status          Status          `json:"status"`
+	Number          string          `json:"number"` // human-friendly number, set by AssignNumber
+	IsGift          bool            `json:"is_gift"`
+	OnHold          bool            `json:"on_hold"`        // set by Hold while the order is under manual review
+	HoldReason      string          `json:"hold_reason"`    // why the order was held; cleared by Release
+	Instructions    string          `json:"instructions"`   // delivery instructions, e.g. "leave at the door"
+	TrackingCode    string          `json:"tracking_code"`  // carrier tracking code, set by Ship
+	ReservationID   string          `json:"reservation_id"` // inventory reservation holding the items, set by RecordReservation, consumed by Ship and cleared by Cancel
+	ReturnRequest   *ReturnRequest  `json:"return_request"`
+	CreatedAt       time.Time       `json:"created_at"`
+	DeliveredAt     *time.Time      `json:"delivered_at"`
 
 	// ===== Itens ===== //
 	items map[string]*orderitem.OrderItem
 
 	// ===== Payment ====== //
-	payments    map[string]*payment.Payment
-	lastPayment *payment.Payment
+	payments []*payment.Payment // in the order they were attached; at most one is active
+
+	// ===== Tax ===== //
+	taxPolicy TaxPolicy
 }
 
 // NewOrder is a factory that creates a new pending Order, validating customerID (non-blank)
-// and address (non-zero).
+// and address (non-zero). The order has no Number until one is given with
+// [Order.AssignNumber].
 func NewOrder(customerID string, address *DeliveryAddress) (*Order, error) {
-	if err := errors.Join(
+	if err := errs.Join(
 		guard.CheckNotNullOrWhiteSpace(customerID, ErrInvalidCustomerID),
 		guard.CheckNotZeroValue(address, ErrInvalidDeliveryAddress),
 	); err != nil {
 		return nil, err
 	}
 
+	o := newOrder(customerID, address)
+	o.AddDomainEvent(newChangedEvent(o.Summary()))
+	return o, nil
+}
+
+func newOrder(customerID string, address *DeliveryAddress) *Order {
 	return &Order{
-		ID:              kernel.NewID().String(),
+		AggregateRoot:   kernel.NewAggregateRoot(),
 		CustomerID:      customerID,
 		DeliveryAddress: *address,
 		TotalAmount:     0,
+		ShippingMethod:  ShippingMethodStandard,
 		Status:          StatusPending,
-		Number:          generateNumber(),
+		CreatedAt:       kernel.Now(),
 		items:           make(map[string]*orderitem.OrderItem),
-		payments:        make(map[string]*payment.Payment),
-	}, nil
+	}
+}
+
+// NewOrderWithItems is a factory that creates a new pending Order, as [NewOrder] does,
+// already holding items. Lines for the same ProductID are merged into the first one by
+// summing their quantities, mirroring [Order.AddItem], so the order never holds two
+// lines for one product; more distinct lines than [MaxOrderLines] yield [ErrTooManyLines].
+// The items are copied and not retained.
+func NewOrderWithItems(customerID string, address *DeliveryAddress, items []*orderitem.OrderItem) (*Order, error) {
+	if err := errs.Join(
+		guard.CheckNotNullOrWhiteSpace(customerID, ErrInvalidCustomerID),
+		guard.CheckNotZeroValue(address, ErrInvalidDeliveryAddress),
+	); err != nil {
+		return nil, err
+	}
+
+	o := newOrder(customerID, address)
+
+	for _, item := range items {
+		if existing, exists := o.items[item.ProductID]; exists {
+			if err := existing.AddUnits(item.Quantity); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if len(o.items) >= MaxOrderLines() {
+			return nil, ErrTooManyLines
+		}
+		line := *item
+		o.items[item.ProductID] = &line
+	}
+
+	o.calculateTotalAmount()
+	o.AddDomainEvent(newChangedEvent(o.Summary()))
+	return o, nil
 }
 
-// AddItem adds or increases the quantity of a product line item; the order must be pending.
+// AddItem adds or increases the quantity of a product line item; the order must be editable.
+// A new line is rejected with [ErrTooManyLines] once the order holds [MaxOrderLines] lines.
 func (o *Order) AddItem(productID, productName string, unitPrice float64, quantity int) error {
-	if !o.Status.Equals(StatusPending) {
-		return ErrOrderNotPending
+	if err := o.checkEditable(); err != nil {
+		return err
 	}
 
 	if item, exists := o.items[productID]; exists {
@@ -80,10 +179,14 @@ func (o *Order) AddItem(productID, productName string, unitPrice float64, quanti
 		}
 
 		o.calculateTotalAmount()
-		o.updateTimestamp()
+		o.touch()
 		return nil
 	}
 
+	if len(o.items) >= MaxOrderLines() {
+		return ErrTooManyLines
+	}
+
 	item, err := orderitem.NewOrderItem(productID, productName, unitPrice, quantity)
 	if err != nil {
 		return err
@@ -91,19 +194,21 @@ func (o *Order) AddItem(productID, productName string, unitPrice float64, quanti
 
 	o.items[productID] = item
 	o.calculateTotalAmount()
-	o.updateTimestamp()
+	o.touch()
 
 	return nil
 }
 
-// RemoveItem removes a line item from the order; the order must be pending and at least
-// one other item must remain.
+// RemoveItem removes a line item from the order; the order must be editable, at least
+// one other item must remain, and the remaining items must still cover the coupon discount
+// and credits ([ErrDiscountExceedsTotal]).
 func (o *Order) RemoveItem(item *orderitem.OrderItem) error {
-	if !o.Status.Equals(StatusPending) {
-		return ErrOrderNotPending
+	if err := o.checkEditable(); err != nil {
+		return err
 	}
 
-	if _, exists := o.items[item.ProductID]; !exists {
+	key, exists := o.lineKey(item)
+	if !exists {
 		return ErrItemNotFound
 	}
 
@@ -111,18 +216,260 @@ func (o *Order) RemoveItem(item *orderitem.OrderItem) error {
 		return ErrCannotRemoveLastItem
 	}
 
-	delete(o.items, item.ProductID)
+	if err := o.checkDiscountsWithin(o.itemsTotal()-o.items[key].TotalPrice, o.Coupon); err != nil {
+		return err
+	}
+
+	delete(o.items, key)
+
+	o.calculateTotalAmount()
+	o.touch()
+	return nil
+}
+
+// Deduplicate merges each line that repeats a product, which only legacy data restored
+// with [FromSnapshot] can hold, into the first line of that product with
+// [orderitem.OrderItem.Merge], and returns how many lines were merged away. A repeated
+// line that cannot be merged, e.g. for a differing unit price, is kept as a separate
+// line, since merging it would reprice the order. The total is recalculated while the
+// order is pending; a paid order keeps the total it was charged.
+func (o *Order) Deduplicate() int {
+	if o == nil {
+		return 0
+	}
+
+	repeated := make([]string, 0)
+	for key, item := range o.items {
+		if key != item.ProductID {
+			repeated = append(repeated, key)
+		}
+	}
+	slices.SortFunc(repeated, func(a, b string) int {
+		return compareLines(*o.items[a], *o.items[b])
+	})
+
+	merged := 0
+	for _, key := range repeated {
+		item := o.items[key]
+		first, ok := o.items[item.ProductID]
+		if !ok {
+			delete(o.items, key)
+			o.items[item.ProductID] = item
+			continue
+		}
+		if err := first.Merge(item); err != nil {
+			continue
+		}
+		delete(o.items, key)
+		merged++
+	}
+
+	if merged > 0 {
+		if o.Status.Equals(StatusPending) {
+			o.calculateTotalAmount()
+		}
+		o.touch()
+	}
+	return merged
+}
+
+// Items returns a copy of the order line items sorted by creation time. Changes to the
+// returned values do not affect the order; use the Order methods to edit its lines.
+func (o *Order) Items() []orderitem.OrderItem {
+	items := make([]orderitem.OrderItem, 0, len(o.items))
+	for _, item := range o.items {
+		items = append(items, *item)
+	}
+	slices.SortFunc(items, compareLines)
+	return items
+}
+
+// compareLines orders line items by creation time, then by ID.
+func compareLines(a, b orderitem.OrderItem) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+// FindItem returns a copy of the line item identified by itemID and whether it exists.
+func (o *Order) FindItem(itemID string) (orderitem.OrderItem, bool) {
+	for _, item := range o.items {
+		if item.ID == itemID {
+			return *item, true
+		}
+	}
+	return orderitem.OrderItem{}, false
+}
+
+// Payments returns a copy of every payment attached to the order in the order they were
+// attached. Changes to the returned values do not affect the order.
+func (o *Order) Payments() []payment.Payment {
+	payments := make([]payment.Payment, 0, len(o.payments))
+	for _, p := range o.payments {
+		payments = append(payments, *p)
+	}
+	return payments
+}
+
+// ActivePayment returns the order's single active payment, i.e. one that is pending or
+// authorized (see [payment.Payment.IsActive]), and whether there is one.
+func (o *Order) ActivePayment() (*payment.Payment, bool) {
+	for _, p := range o.payments {
+		if p.IsActive() {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// UpdateProductPrice sets the unit price of every line for productID to newPrice and
+// recalculates the order total; the order must be editable, newPrice strictly positive,
+// and at least one line must match, or [ErrItemNotFound] is returned. A price drop that
+// leaves the coupon discount and credits above the items total returns
+// [ErrDiscountExceedsTotal]. Lines for the same product are merged on insertion, so in
+// practice there is at most one.
+func (o *Order) UpdateProductPrice(productID string, newPrice float64) error {
+	if err := o.checkEditable(); err != nil {
+		return err
+	}
+
+	itemsTotal := o.itemsTotal()
+	updated := make(map[string]orderitem.OrderItem)
+	for key, item := range o.items {
+		if item.ProductID != productID {
+			continue
+		}
+		next := *item
+		if err := next.UpdateUnitPrice(newPrice); err != nil {
+			return err
+		}
+		itemsTotal += next.TotalPrice - item.TotalPrice
+		updated[key] = next
+	}
+
+	if len(updated) == 0 {
+		return ErrItemNotFound
+	}
+
+	if err := o.checkDiscountsWithin(itemsTotal, o.Coupon); err != nil {
+		return err
+	}
+
+	for key, next := range updated {
+		*o.items[key] = next
+	}
 
 	o.calculateTotalAmount()
-	o.updateTimestamp()
+	o.touch()
 	return nil
 }
 
-// UpdateDeliveryAddress replaces the delivery address; the order must be pending and
+// AddUnitsToItem increases the quantity of the line item identified by itemID and
+// recalculates the order total; the order must be editable and the item must exist.
+func (o *Order) AddUnitsToItem(itemID string, units int) error {
+	item, err := o.findEditableItem(itemID)
+	if err != nil {
+		return err
+	}
+
+	if err := item.AddUnits(units); err != nil {
+		return err
+	}
+
+	o.calculateTotalAmount()
+	o.touch()
+	return nil
+}
+
+// SetItemNote replaces the customer note of the line item identified by itemID (see
+// [orderitem.OrderItem.SetNote]); the order must be editable and the item must exist.
+func (o *Order) SetItemNote(itemID, note string) error {
+	item, err := o.findEditableItem(itemID)
+	if err != nil {
+		return err
+	}
+
+	if err := item.SetNote(note); err != nil {
+		return err
+	}
+
+	o.touch()
+	return nil
+}
+
+// SetItemTaxCategory changes how the line item identified by itemID is taxed (see
+// [orderitem.OrderItem.SetTaxCategory]) and recalculates the order total; the order
+// must be editable and the item must exist.
+func (o *Order) SetItemTaxCategory(itemID string, category orderitem.TaxCategory) error {
+	item, err := o.findEditableItem(itemID)
+	if err != nil {
+		return err
+	}
+
+	if err := item.SetTaxCategory(category); err != nil {
+		return err
+	}
+
+	o.calculateTotalAmount()
+	o.touch()
+	return nil
+}
+
+// RemoveUnitsFromItem decreases the quantity of the line item identified by itemID and
+// recalculates the order total; the order must be editable, the item must exist, and the
+// items must still cover the coupon discount and credits ([ErrDiscountExceedsTotal]).
+func (o *Order) RemoveUnitsFromItem(itemID string, units int) error {
+	item, err := o.findEditableItem(itemID)
+	if err != nil {
+		return err
+	}
+
+	next := *item
+	if err := next.RemoveUnits(units); err != nil {
+		return err
+	}
+
+	if err := o.checkDiscountsWithin(o.itemsTotal()-item.TotalPrice+next.TotalPrice, o.Coupon); err != nil {
+		return err
+	}
+	*item = next
+
+	o.calculateTotalAmount()
+	o.touch()
+	return nil
+}
+
+// ApplyItemDiscount sets the discount of the line item identified by itemID (see
+// [orderitem.OrderItem.ApplyDiscount]) and recalculates the order total; the order must
+// be editable, the item must exist, and the items must still cover the coupon discount
+// and credits ([ErrDiscountExceedsTotal]).
+func (o *Order) ApplyItemDiscount(itemID string, discount float64) error {
+	item, err := o.findEditableItem(itemID)
+	if err != nil {
+		return err
+	}
+
+	next := *item
+	if err := next.ApplyDiscount(discount); err != nil {
+		return err
+	}
+
+	if err := o.checkDiscountsWithin(o.itemsTotal()-item.TotalPrice+next.TotalPrice, o.Coupon); err != nil {
+		return err
+	}
+	*item = next
+
+	o.calculateTotalAmount()
+	o.touch()
+	return nil
+}
+
+// UpdateDeliveryAddress replaces the delivery address; the order must be editable and
 // the new address must be non-zero.
 func (o *Order) UpdateDeliveryAddress(newAddress DeliveryAddress) error {
-	if !o.Status.Equals(StatusPending) {
-		return ErrOrderNotPending
+	if err := o.checkEditable(); err != nil {
+		return err
 	}
 
 	if newAddress.IsZero() {
@@ -130,25 +477,253 @@ func (o *Order) UpdateDeliveryAddress(newAddress DeliveryAddress) error {
 	}
 
 	o.DeliveryAddress = newAddress
-	o.updateTimestamp()
+	o.calculateTotalAmount()
+	o.touch()
+	return nil
+}
+
+// SetInstructions replaces the delivery instructions of the order; an empty string
+// clears them. The order must be editable and s at most [MaxInstructionsLength]
+// characters long, otherwise [ErrInstructionsTooLong] is returned.
+func (o *Order) SetInstructions(s string) error {
+	if err := o.checkEditable(); err != nil {
+		return err
+	}
+
+	if err := guard.CheckLength(s, 0, MaxInstructionsLength, ErrInstructionsTooLong); err != nil {
+		return err
+	}
+
+	o.Instructions = s
+	o.touch()
+	return nil
+}
+
+// SetShippingMethod sets the delivery speed chosen by the customer, Standard by default;
+// the order must be editable and m a known [ShippingMethod].
+func (o *Order) SetShippingMethod(m ShippingMethod) error {
+	if err := o.checkEditable(); err != nil {
+		return err
+	}
+
+	if err := guard.CheckValidEnum(m, ErrInvalidShippingMethod); err != nil {
+		return err
+	}
+
+	o.ShippingMethod = m
+	o.touch()
+	return nil
+}
+
+// MarkAsGift flags the order as a gift, so it is settled through [Order.MarkAsComped]
+// instead of a payment; the order must be pending and have no payment started.
+func (o *Order) MarkAsGift() error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if !o.Status.Equals(StatusPending) {
+		return ErrOrderNotPending
+	}
+
+	if len(o.payments) > 0 {
+		return ErrPaymentAlreadyPending
+	}
+
+	o.IsGift = true
+	o.touch()
+	return nil
+}
+
+// MarkAsComped transitions a gift order to Paid without a payment and raises a
+// CompedEvent; the order must be pending, flagged as a gift and have items.
+func (o *Order) MarkAsComped() error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if !o.Status.Equals(StatusPending) {
+		return ErrOrderNotPending
+	}
+
+	if o.OnHold {
+		return ErrOrderOnHold
+	}
+
+	if !o.IsGift {
+		return ErrOrderNotGift
+	}
+
+	if len(o.items) == 0 {
+		return ErrNoItems
+	}
+
+	o.Status = StatusPaid
+	o.touch()
+
+	event := newCompedEvent(o.ID, o.CustomerID, o.TotalAmount)
+	o.AddDomainEvent(event)
+	return nil
+}
+
+// ApplyCoupon validates c and applies its discount to the order total, replacing any
+// previously applied coupon; the order must be editable and its current total must reach
+// the coupon minimum order total. Together with the credits, the discount cannot exceed
+// the items total ([ErrDiscountExceedsTotal]). A percentage discount follows later item
+// changes.
+func (o *Order) ApplyCoupon(c promo.Coupon) error {
+	if err := o.checkEditable(); err != nil {
+		return err
+	}
+
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	if !c.IsSatisfiedBy(o.itemsTotal()) {
+		return ErrCouponMinNotMet
+	}
+
+	if err := o.checkDiscountsWithin(o.itemsTotal(), &c); err != nil {
+		return err
+	}
+
+	o.Coupon = &c
+	o.calculateTotalAmount()
+	o.touch()
+	return nil
+}
+
+// AddCredit records a negative adjustment of amount, justified by reason, reducing the
+// order total; the order must be editable and the credit cannot drive the total below zero.
+func (o *Order) AddCredit(amount float64, reason string) error {
+	if err := o.checkEditable(); err != nil {
+		return err
+	}
+
+	credit, err := NewCredit(amount, reason)
+	if err != nil {
+		return err
+	}
+
+	if credit.Amount() > o.taxableBase() {
+		return ErrCreditExceedsTotal
+	}
+
+	o.Credits = append(o.Credits, *credit)
+	o.calculateTotalAmount()
+	o.touch()
+	return nil
+}
+
+// ApplyTaxPolicy taxes the order with the rate policy assigns to the delivery address
+// state, replacing any previously applied policy; the rate follows later address
+// changes. The order must be editable and every rate in policy must be in [0, 1). The
+// order keeps its own copy of policy, so later changes to the map do not affect it.
+func (o *Order) ApplyTaxPolicy(policy TaxPolicy) error {
+	if err := o.checkEditable(); err != nil {
+		return err
+	}
+
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	o.taxPolicy = maps.Clone(policy)
+	o.calculateTotalAmount()
+	o.touch()
 	return nil
 }
 
+// TaxAmount returns the tax charged on the taxable base (items total minus coupon
+// discount and credits), rounded to cents. Each item is taxed at the rate its
+// [orderitem.TaxCategory] derives from TaxRate, on its share of the taxable base: the
+// coupon discount and credits are spread over the items in proportion to their totals.
+func (o *Order) TaxAmount() float64 {
+	itemsTotal := o.itemsTotal()
+	if itemsTotal == 0 {
+		return 0
+	}
+
+	share := o.taxableBase() / itemsTotal
+	tax := 0.0
+	for _, item := range o.Items() {
+		tax += item.TotalPrice * share * item.TaxCategory.RateFor(o.TaxRate)
+	}
+	return kernel.RoundCents(tax)
+}
+
+// Total returns the amount due for the order.
+func (o *Order) Total() float64 {
+	return o.TotalAmount
+}
+
+// DiscountTotal returns everything deducted from the gross value of the items: the
+// item discounts plus the coupon discount and credits, the latter two capped at the
+// items total just like [Order.Total] does, so Gross − DiscountTotal + Tax == Total.
+func (o *Order) DiscountTotal() float64 {
+	itemsTotal := o.itemsTotal()
+	itemDiscounts := o.grossTotal() - itemsTotal
+	orderDiscounts := itemsTotal - o.taxableBase()
+	return itemDiscounts + orderDiscounts
+}
+
+// Breakdown itemizes the order total into items, coupon discount, credits and tax.
+func (o *Order) Breakdown() Breakdown {
+	return Breakdown{
+		Gross:          o.grossTotal(),
+		ItemsTotal:     o.itemsTotal(),
+		CouponDiscount: o.DiscountAmount,
+		Credits:        o.creditsTotal(),
+		DiscountTotal:  o.DiscountTotal(),
+		Tax:            o.TaxAmount(),
+		Total:          o.TotalAmount,
+	}
+}
+
+// TotalDriftTolerance is the largest difference between the stored and the recomputed
+// total that [Order.RecalculateFromScratch] still reports as consistent.
+const TotalDriftTolerance = 0.005
+
+// RecalculateFromScratch recomputes each line total with
+// [orderitem.OrderItem.ExpectedTotalPrice] and the order total from those lines, the
+// coupon, credits and tax policy, and compares it with the stored TotalAmount, reporting
+// whether both agree within [TotalDriftTolerance]. It is a diagnostic for detecting stale
+// totals and does not change the order.
+func (o *Order) RecalculateFromScratch() (stored, recomputed float64, consistent bool) {
+	fresh := o.clone()
+	for _, item := range fresh.items {
+		item.TotalPrice = item.ExpectedTotalPrice()
+	}
+	fresh.calculateTotalAmount()
+	return o.TotalAmount, fresh.TotalAmount, math.Abs(o.TotalAmount-fresh.TotalAmount) <= TotalDriftTolerance
+}
+
 // StartPayment creates a new pending Payment for the order; the order must be pending,
-// have items, and have no existing pending payment.
+// not a gift, have items, reach [MinimumOrderTotal], and have no existing pending payment.
 func (o *Order) StartPayment(method payment.Method) (*payment.Payment, error) {
+	if o == nil {
+		return nil, kernel.ErrNilAggregate
+	}
+
 	if !o.Status.Equals(StatusPending) {
 		return nil, ErrOrderNotPending
 	}
 
+	if o.IsGift {
+		return nil, ErrGiftOrderNotPayable
+	}
+
 	if len(o.items) == 0 {
 		return nil, ErrNoItems
 	}
 
-	for _, p := range o.payments {
-		if p.Status.Equals(payment.StatusPending) {
-			return nil, ErrPaymentAlreadyPending
-		}
+	if o.Total() < MinimumOrderTotal() {
+		return nil, ErrOrderBelowMinimum
+	}
+
+	if o.hasPendingPayment() {
+		return nil, ErrPaymentAlreadyPending
 	}
 
 	newPayment, err := payment.NewPayment(o.ID, o.TotalAmount, method)
@@ -156,69 +731,280 @@ func (o *Order) StartPayment(method payment.Method) (*payment.Payment, error) {
 		return nil, err
 	}
 
-	o.payments[newPayment.ID] = newPayment
-	o.lastPayment = newPayment
-	o.updateTimestamp()
+	if err := o.attachPayment(newPayment); err != nil {
+		return nil, err
+	}
+	o.touch()
 	return newPayment, nil
 }
 
-// HandleApprovedPaymentEvent transitions the order to Paid when the identified payment
-// is approved.
+// AddPayment attaches p, a payment created outside the order (e.g. replayed from the
+// gateway), to the pending order. p must belong to the order and not be attached yet,
+// otherwise [ErrInvalidPayment] is returned, and [ErrMultipleActivePayments] is returned
+// when both p and another payment of the order are active.
+func (o *Order) AddPayment(p *payment.Payment) error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if !o.Status.Equals(StatusPending) {
+		return ErrOrderNotPending
+	}
+
+	if p == nil || p.OrderID != o.ID {
+		return ErrInvalidPayment
+	}
+
+	if _, exists := o.findPayment(p.ID); exists {
+		return ErrInvalidPayment
+	}
+
+	if err := o.attachPayment(p); err != nil {
+		return err
+	}
+	o.touch()
+	return nil
+}
+
+// Validate checks the invariants spanning the order's payments, returning
+// [ErrMultipleActivePayments] when more than one of them is active.
+func (o *Order) Validate() error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	return o.checkSingleActivePayment(o.payments)
+}
+
+// attachPayment appends p to the order's payments unless that would leave the order with
+// more than one active payment.
+func (o *Order) attachPayment(p *payment.Payment) error {
+	payments := append(slices.Clip(o.payments), p)
+	if err := o.checkSingleActivePayment(payments); err != nil {
+		return err
+	}
+	o.payments = payments
+	return nil
+}
+
+func (o *Order) checkSingleActivePayment(payments []*payment.Payment) error {
+	active := 0
+	for _, p := range payments {
+		if p.IsActive() {
+			active++
+		}
+	}
+	if active > 1 {
+		return ErrMultipleActivePayments
+	}
+	return nil
+}
+
+func (o *Order) findPayment(paymentID string) (*payment.Payment, bool) {
+	for _, p := range o.payments {
+		if p.ID == paymentID {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+func (o *Order) lastPaymentID() string {
+	if len(o.payments) == 0 {
+		return ""
+	}
+	return o.payments[len(o.payments)-1].ID
+}
+
+// RetryPayment starts a fresh payment after the previous attempt failed, instead of
+// reviving the old one. The order must already have a payment and none of its payments
+// may be active (pending or authorized), otherwise [ErrNoPaymentToRetry] or
+// [payment.ErrActivePaymentExists] is returned; the rules of [Order.StartPayment] apply.
+func (o *Order) RetryPayment(method payment.Method) (*payment.Payment, error) {
+	if o == nil {
+		return nil, kernel.ErrNilAggregate
+	}
+
+	if len(o.payments) == 0 {
+		return nil, ErrNoPaymentToRetry
+	}
+
+	if _, active := o.ActivePayment(); active {
+		return nil, payment.ErrActivePaymentExists
+	}
+
+	return o.StartPayment(method)
+}
+
+// DefineTransactionCode assigns code to the order's payment identified by paymentID (see
+// [payment.Payment.DefineTransactionCode]) and returns that payment, so callers can pull
+// its events. A code reused by two payments of one order points to a bug or a replay,
+// so [payment.ErrDuplicateTransactionCode] is returned when another payment of the order
+// already holds code, and [ErrPaymentNotFound] when no payment matches paymentID.
+func (o *Order) DefineTransactionCode(paymentID, code string) (*payment.Payment, error) {
+	if o == nil {
+		return nil, kernel.ErrNilAggregate
+	}
+
+	p, exists := o.findPayment(paymentID)
+	if !exists {
+		return nil, ErrPaymentNotFound
+	}
+
+	for _, other := range o.payments {
+		if existing, ok := other.TransactionCodeValue(); ok && other.ID != paymentID && existing == code {
+			return nil, payment.ErrDuplicateTransactionCode
+		}
+	}
+
+	if err := p.DefineTransactionCode(code); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// HandleApprovedPaymentEvent transitions the order to Paid and raises a PaidEvent when
+// the identified payment is approved. The payment amount must match the order total to
+// the cent, or [ErrPaymentAmountMismatch] is returned and the order stays pending.
 func (o *Order) HandleApprovedPaymentEvent(paymentID string) error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
 	if !o.Status.Equals(StatusPending) {
 		return ErrOrderNotPending
 	}
 
-	if _, exists := o.payments[paymentID]; !exists {
+	if o.OnHold {
+		return ErrOrderOnHold
+	}
+
+	p, exists := o.findPayment(paymentID)
+	if !exists {
 		return nil
 	}
 
+	if kernel.RoundCents(p.Amount) != kernel.RoundCents(o.TotalAmount) {
+		return ErrPaymentAmountMismatch
+	}
+
 	o.Status = StatusPaid
-	o.updateTimestamp()
+	o.touch()
+
+	event := newPaidEvent(o.ID, o.CustomerID, paymentID, o.TotalAmount)
+	o.AddDomainEvent(event)
 	return nil
 }
 
 // HandleRejectedPaymentEvent transitions the order to Cancelled and raises a CancelledEvent
 // when the identified payment is rejected.
 func (o *Order) HandleRejectedPaymentEvent(paymentID string) error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
 	if !o.Status.Equals(StatusPending) {
 		return ErrOrderNotPending
 	}
 
-	if _, exists := o.payments[paymentID]; !exists {
+	if _, exists := o.findPayment(paymentID); !exists {
 		return nil
 	}
 
 	o.Status = StatusCancelled
-	o.updateTimestamp()
+	o.touch()
 
 	event := newCancelledEvent(o.ID, o.CustomerID, o.Status, CancellationReasonPaymentError, paymentID)
 	o.AddDomainEvent(event)
 	return nil
 }
 
+// RecordReservation stores reservationID, the inventory reservation holding the items
+// of the order (see [InventoryReserver]); the order must be Paid and reservationID
+// non-blank ([ErrInvalidReservationID]).
+func (o *Order) RecordReservation(reservationID string) error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if err := guard.CheckNotNullOrWhiteSpace(reservationID, ErrInvalidReservationID); err != nil {
+		return err
+	}
+
+	if !o.Status.Equals(StatusPaid) {
+		return ErrOrderNotPaid
+	}
+
+	o.ReservationID = reservationID
+	o.touch()
+	return nil
+}
+
 // MarkAsSeparating advances the order to the Separating status; the order must be Paid.
 func (o *Order) MarkAsSeparating() error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
 	if !o.Status.Equals(StatusPaid) {
 		return ErrOrderNotPaid
 	}
 
+	if o.OnHold {
+		return ErrOrderOnHold
+	}
+
 	o.Status = StatusSeparating
-	o.updateTimestamp()
+	o.touch()
 	return nil
 }
 
 // MarkAsShipped advances the order to the Shipped status and raises a ShippedEvent;
-// the order must be Separating.
+// the order must be Separating. Use [Order.Ship] to also record a tracking code.
 func (o *Order) MarkAsShipped() error {
+	return o.ship("")
+}
+
+// Ship behaves like [Order.MarkAsShipped] and also records trackingCode, the carrier
+// code the customer follows the delivery with, on the order and on the ShippedEvent.
+// trackingCode is stored without surrounding whitespace; a blank one returns
+// [ErrInvalidTrackingCode].
+func (o *Order) Ship(trackingCode string) error {
+	if err := guard.CheckNotNullOrWhiteSpace(trackingCode, ErrInvalidTrackingCode); err != nil {
+		return err
+	}
+	return o.ship(strings.TrimSpace(trackingCode))
+}
+
+// CheckShippable returns the error [Order.Ship] would return for the state of the order:
+// [ErrOrderNotSeparating] unless it is Separating and [ErrOrderOnHold] while it is held.
+// It lets callers skip work that only matters to a shipment, such as booking a carrier.
+func (o *Order) CheckShippable() error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
 	if !o.Status.Equals(StatusSeparating) {
 		return ErrOrderNotSeparating
 	}
 
+	if o.OnHold {
+		return ErrOrderOnHold
+	}
+	return nil
+}
+
+func (o *Order) ship(trackingCode string) error {
+	if err := o.CheckShippable(); err != nil {
+		return err
+	}
+
 	o.Status = StatusShipped
-	o.updateTimestamp()
+	o.TrackingCode = trackingCode
+	o.ReservationID = "" // the reserved units leave with the shipment
+	o.touch()
 
-	event := newShippedEvent(o.ID, o.CustomerID, o.DeliveryAddress)
+	event := newShippedEvent(o.ID, o.CustomerID, o.DeliveryAddress, trackingCode)
 	o.AddDomainEvent(event)
 	return nil
 }
@@ -226,51 +1012,302 @@ func (o *Order) MarkAsShipped() error {
 // MarkAsDelivered advances the order to the Delivered status and raises a DeliveredEvent;
 // the order must be Shipped.
 func (o *Order) MarkAsDelivered() error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
 	if !o.Status.Equals(StatusShipped) {
 		return ErrOrderNotShipped
 	}
 
+	if o.OnHold {
+		return ErrOrderOnHold
+	}
+
 	o.Status = StatusDelivered
-	o.updateTimestamp()
+	o.DeliveredAt = new(kernel.Now())
+	o.touch()
 
 	event := newDeliveredEvent(o.ID, o.CustomerID)
 	o.AddDomainEvent(event)
 	return nil
 }
 
-// Cancel cancels the order and raises a CancelledEvent; the order must be in a
-// cancellable status.
+// DeliveredAtValue returns when the order was delivered and whether it has been,
+// sparing callers from dereferencing DeliveredAt after a nil check.
+func (o *Order) DeliveredAtValue() (time.Time, bool) {
+	if o.DeliveredAt == nil {
+		return time.Time{}, false
+	}
+	return *o.DeliveredAt, true
+}
+
+// RequestReturn records a request to return the items identified by itemIDs for reason
+// and raises a ReturnRequestedEvent. The order must be delivered, within the
+// [ReturnWindow] counted from DeliveredAt (or CreatedAt when unknown), and have no
+// previous return request; itemIDs must be non-empty and name items of the order.
+func (o *Order) RequestReturn(itemIDs []string, reason CancellationReason) error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if !o.Status.Equals(StatusDelivered) {
+		return ErrInvalidStatusTransition
+	}
+
+	if o.ReturnRequest != nil {
+		return ErrReturnAlreadyRequested
+	}
+
+	now := kernel.Now()
+	if now.After(o.deliveredAt().Add(ReturnWindow())) {
+		return ErrReturnWindowExpired
+	}
+
+	if len(itemIDs) == 0 {
+		return ErrNoReturnItems
+	}
+
+	for _, id := range itemIDs {
+		if _, ok := o.FindItem(id); !ok {
+			return ErrItemNotFound
+		}
+	}
+
+	ids := append([]string(nil), itemIDs...)
+	o.ReturnRequest = &ReturnRequest{ItemIDs: ids, Reason: reason, RequestedAt: now}
+	o.touch()
+
+	event := newReturnRequestedEvent(o.ID, o.CustomerID, ids, reason)
+	o.AddDomainEvent(event)
+	return nil
+}
+
+// IsExpired reports whether the order is still pending and now is past CreatedAt plus
+// the configured [UnpaidOrderTTL].
+func (o *Order) IsExpired(now time.Time) bool {
+	return o.Status.Equals(StatusPending) && now.After(o.CreatedAt.Add(UnpaidOrderTTL()))
+}
+
+// Expire cancels an unpaid order whose time-to-live has elapsed at now and raises a
+// CancelledEvent with [CancellationReasonPaymentTimeout]; the order must be pending.
+// Returns [ErrOrderNotExpired] when now is not yet past CreatedAt plus [UnpaidOrderTTL].
+func (o *Order) Expire(now time.Time) error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if !o.Status.Equals(StatusPending) {
+		return ErrOrderNotPending
+	}
+
+	if !o.IsExpired(now) {
+		return ErrOrderNotExpired
+	}
+
+	o.Status = StatusCancelled
+	o.touch()
+
+	event := newCancelledEvent(o.ID, o.CustomerID, o.Status, CancellationReasonPaymentTimeout, o.lastPaymentID())
+	o.AddDomainEvent(event)
+	return nil
+}
+
+// Hold puts the order on hold for manual review, e.g. on suspicion of fraud, without
+// cancelling it: while held it cannot be paid, separated, shipped or delivered. reason
+// must not be blank, and the order must be neither delivered nor cancelled nor already held.
+func (o *Order) Hold(reason string) error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if err := guard.CheckNotNullOrWhiteSpace(reason, ErrInvalidHoldReason); err != nil {
+		return err
+	}
+
+	if o.Status.Equals(StatusDelivered) || o.Status.Equals(StatusCancelled) {
+		return ErrInvalidStatusTransition
+	}
+
+	if o.OnHold {
+		return ErrOrderOnHold
+	}
+
+	o.OnHold = true
+	o.HoldReason = reason
+	o.touch()
+	return nil
+}
+
+// Release lifts the hold placed by [Order.Hold], letting the order advance again.
+func (o *Order) Release() error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if !o.OnHold {
+		return ErrOrderNotOnHold
+	}
+
+	o.OnHold = false
+	o.HoldReason = ""
+	o.touch()
+	return nil
+}
+
+// Cancel cancels the order and raises a CancelledEvent; the order must be paid,
+// separating, shipped or delivered, otherwise [ErrOrderCannotCancel] is returned.
+// Pending orders are expired instead (see [Order.Expire]). Cancel clears ReservationID:
+// a paid or separating order still holds its inventory reservation, which the caller
+// must release (see [InventoryReserver.Release]), while shipping already consumed it.
 func (o *Order) Cancel(reason CancellationReason) error {
-	if !o.Status.Equals(StatusShipped) &&
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if !o.Status.Equals(StatusPaid) &&
+		!o.Status.Equals(StatusSeparating) &&
+		!o.Status.Equals(StatusShipped) &&
 		!o.Status.Equals(StatusDelivered) {
 		return ErrOrderCannotCancel
 	}
 
 	o.Status = StatusCancelled
-	o.updateTimestamp()
+	o.ReservationID = ""
+	o.touch()
 
-	var paymentID string
-	if o.lastPayment != nil {
-		paymentID = o.lastPayment.ID
+	event := newCancelledEvent(o.ID, o.CustomerID, o.Status, reason, o.lastPaymentID())
+	o.AddDomainEvent(event)
+	return nil
+}
+
+// checkEditable guards every change to items, discounts, credits, tax or address, all of
+// which affect the total. Besides being pending, the order must have no pending payment:
+// its amount was fixed from the total when started, so editing would leave it stale.
+// Once the payment is refused the order becomes editable again.
+func (o *Order) checkEditable() error {
+	if o == nil {
+		return kernel.ErrNilAggregate
 	}
 
-	event := newCancelledEvent(o.ID, o.CustomerID, o.Status, reason, paymentID)
-	o.AddDomainEvent(event)
+	if !o.Status.Equals(StatusPending) {
+		return ErrOrderNotPending
+	}
+
+	if o.hasPendingPayment() {
+		return ErrCannotEditPaidOrder
+	}
 	return nil
 }
 
-func (o *Order) updateTimestamp() {
-	o.UpdatedAt = new(time.Now().UTC())
+func (o *Order) hasPendingPayment() bool {
+	for _, p := range o.payments {
+		if p.Status.Equals(payment.StatusPending) {
+			return true
+		}
+	}
+	return false
+}
+
+// findEditableItem returns the stored line item identified by itemID, enforcing that the
+// order can still be edited.
+func (o *Order) findEditableItem(itemID string) (*orderitem.OrderItem, error) {
+	if err := o.checkEditable(); err != nil {
+		return nil, err
+	}
+
+	item, exists := o.FindItem(itemID)
+	if !exists {
+		return nil, ErrItemNotFound
+	}
+	key, _ := o.lineKey(&item)
+	return o.items[key], nil
+}
+
+// lineKey returns the key of the stored line item: the one with the ID of item, which
+// may be a line repeating a product restored by [FromSnapshot], or else the line of its
+// product.
+func (o *Order) lineKey(item *orderitem.OrderItem) (string, bool) {
+	for key, line := range o.items {
+		if line.ID == item.ID {
+			return key, true
+		}
+	}
+	_, exists := o.items[item.ProductID]
+	return item.ProductID, exists
+}
+
+func (o *Order) deliveredAt() time.Time {
+	if t, ok := o.DeliveredAtValue(); ok {
+		return t
+	}
+	return o.CreatedAt
 }
 
+// calculateTotalAmount recomputes the discount, tax rate and total. An order without
+// items totals exactly zero whatever coupon, credits or tax policy it carries.
 func (o *Order) calculateTotalAmount() {
-	totalAmount := 0.0
+	o.TaxRate = o.taxPolicy.RateFor(o.DeliveryAddress.State())
+	if len(o.items) == 0 {
+		o.DiscountAmount = 0
+		o.TotalAmount = 0
+		return
+	}
+
+	itemsTotal := o.itemsTotal()
+
+	o.DiscountAmount = 0
+	if o.Coupon != nil {
+		o.DiscountAmount = o.Coupon.DiscountFor(itemsTotal)
+	}
+	o.TotalAmount = o.taxableBase() + o.TaxAmount()
+}
+
+func (o *Order) taxableBase() float64 {
+	return max(o.itemsTotal()-o.DiscountAmount-o.creditsTotal(), 0)
+}
+
+func (o *Order) creditsTotal() float64 {
+	total := 0.0
+	for _, c := range o.Credits {
+		total += c.Amount()
+	}
+	return total
+}
+
+func (o *Order) grossTotal() float64 {
+	total := 0.0
+	for _, item := range o.items {
+		total += item.Subtotal()
+	}
+	return total
+}
+
+func (o *Order) itemsTotal() float64 {
+	total := 0.0
 	for _, item := range o.items {
-		totalAmount = +totalAmount + item.TotalPrice
+		total += item.TotalPrice
 	}
-	o.TotalAmount = totalAmount
+	return total
+}
+
+// checkDiscountsWithin returns [ErrDiscountExceedsTotal] when the discount of coupon on
+// itemsTotal plus the order credits would exceed itemsTotal, so a change can be rejected
+// before it is applied.
+func (o *Order) checkDiscountsWithin(itemsTotal float64, coupon *promo.Coupon) error {
+	discount := o.creditsTotal()
+	if coupon != nil {
+		discount += coupon.DiscountFor(itemsTotal)
+	}
+	if kernel.RoundCents(discount) > kernel.RoundCents(itemsTotal) {
+		return ErrDiscountExceedsTotal
+	}
+	return nil
 }
 
-func generateNumber() string {
-	return "PED-" + kernel.NewID().String()[:8] // TODO: reimplement
+// touch stamps the order as updated and raises a [ChangedEvent] carrying its new
+// [Summary]; every state-changing command ends with it.
+func (o *Order) touch() {
+	o.UpdateTimestamp()
+	o.AddDomainEvent(newChangedEvent(o.Summary()))
 }
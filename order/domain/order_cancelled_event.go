@@ -2,7 +2,6 @@ package order
 
 import (
 	"strings"
-	"time"
 
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
 )
@@ -21,8 +20,8 @@ type CancelledEvent struct {
 func newCancelledEvent(orderID string, customerID string, status Status, reason CancellationReason, paymentID string) *CancelledEvent {
 	e := CancelledEvent{
 		Event: kernel.Event{
-			ID:           kernel.NewID().String(),
-			DateOccurred: time.Now().UTC(),
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
 		},
 		OrderID:            orderID,
 		CustomerID:         customerID,
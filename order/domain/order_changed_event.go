@@ -0,0 +1,21 @@
+package order
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+
+// ChangedEvent is a domain event raised whenever an Order is created or its state
+// changes, carrying the resulting [Summary] so read models can be refreshed without
+// reloading the aggregate.
+type ChangedEvent struct {
+	kernel.Event
+	Summary Summary `json:"summary"`
+}
+
+func newChangedEvent(summary Summary) *ChangedEvent {
+	return &ChangedEvent{
+		Event: kernel.Event{
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
+		},
+		Summary: summary,
+	}
+}
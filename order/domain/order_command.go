@@ -0,0 +1,92 @@
+package order
+
+import (
+	"slices"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/promo"
+)
+
+// Command is an edit that [Order.Apply] runs as part of a batch. It can only be
+// implemented within this package, so every command goes through an Order method and
+// its invariants.
+type Command interface {
+	applyTo(o *Order) error
+}
+
+// AddItemCommand runs [Order.AddItem].
+type AddItemCommand struct {
+	ProductID   string
+	ProductName string
+	UnitPrice   float64
+	Quantity    int
+}
+
+func (c AddItemCommand) applyTo(o *Order) error {
+	return o.AddItem(c.ProductID, c.ProductName, c.UnitPrice, c.Quantity)
+}
+
+// AddUnitsCommand runs [Order.AddUnitsToItem].
+type AddUnitsCommand struct {
+	ItemID string
+	Units  int
+}
+
+func (c AddUnitsCommand) applyTo(o *Order) error {
+	return o.AddUnitsToItem(c.ItemID, c.Units)
+}
+
+// RemoveUnitsCommand runs [Order.RemoveUnitsFromItem].
+type RemoveUnitsCommand struct {
+	ItemID string
+	Units  int
+}
+
+func (c RemoveUnitsCommand) applyTo(o *Order) error {
+	return o.RemoveUnitsFromItem(c.ItemID, c.Units)
+}
+
+// ApplyCouponCommand runs [Order.ApplyCoupon].
+type ApplyCouponCommand struct {
+	Coupon promo.Coupon
+}
+
+func (c ApplyCouponCommand) applyTo(o *Order) error {
+	return o.ApplyCoupon(c.Coupon)
+}
+
+// Apply runs cmds in order as a single atomic edit, e.g. for an API accepting a batch of
+// changes in one request. The commands run against a copy of the order, which replaces
+// it only when every command succeeds; on the first error Apply stops and returns it,
+// leaving the order and its pending events unchanged.
+func (o *Order) Apply(cmds ...Command) error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	draft := o.clone()
+	for _, cmd := range cmds {
+		if err := cmd.applyTo(draft); err != nil {
+			return err
+		}
+	}
+
+	*o = *draft
+	return nil
+}
+
+// clone returns a copy of the order that can be edited without affecting it: items and
+// credits are copied, while payments, which commands do not edit, are shared. Events
+// raised on the copy are appended past the order's own, so they never show up in it.
+func (o *Order) clone() *Order {
+	c := *o
+	c.Credits = slices.Clone(o.Credits)
+	c.items = make(map[string]*orderitem.OrderItem, len(o.items))
+	for productID, item := range o.items {
+		line := *item
+		c.items[productID] = &line
+	}
+	c.payments = slices.Clone(o.payments)
+	return &c
+}
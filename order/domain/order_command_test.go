@@ -0,0 +1,59 @@
+package order_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/promo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_Apply(t *testing.T) {
+	coupon := *kernel.Must(promo.NewCoupon("TEN", promo.DiscountTypePercentage, 10, 0))
+
+	t.Run("should apply every command of a valid batch in order", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		widget := o.Items()[0]
+
+		err := o.Apply(
+			order.AddItemCommand{ProductID: "prod-2", ProductName: "Gadget", UnitPrice: 10.0, Quantity: 3},
+			order.AddUnitsCommand{ItemID: widget.ID, Units: 1},
+			order.ApplyCouponCommand{Coupon: coupon},
+		)
+
+		require.NoError(t, err)
+		assert.Len(t, o.Items(), 2)
+		got, ok := o.FindItem(widget.ID)
+		require.True(t, ok)
+		assert.Equal(t, 3, got.Quantity)
+		assert.Equal(t, 162.0, o.Total(), "Total should be (150 + 30) - 10% = 162")
+		assert.Equal(t, &coupon, o.Coupon)
+	})
+
+	t.Run("should leave the order unchanged when the third command fails", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		widget := o.Items()[0]
+		itemsBefore, eventsBefore := o.Items(), o.DomainEvents()
+
+		err := o.Apply(
+			order.AddItemCommand{ProductID: "prod-2", ProductName: "Gadget", UnitPrice: 10.0, Quantity: 3},
+			order.AddUnitsCommand{ItemID: widget.ID, Units: 1},
+			order.RemoveUnitsCommand{ItemID: "missing", Units: 1},
+		)
+
+		assert.ErrorIs(t, err, order.ErrItemNotFound)
+		assert.Equal(t, itemsBefore, o.Items(), "items should be unchanged")
+		assert.Equal(t, 100.0, o.Total(), "Total should be unchanged")
+		assert.Equal(t, eventsBefore, o.DomainEvents(), "no event should be raised")
+	})
+
+	t.Run("should return ErrNilAggregate instead of panicking on a nil order", func(t *testing.T) {
+		var o *order.Order
+
+		err := o.Apply(order.AddUnitsCommand{ItemID: "item-1", Units: 1})
+
+		assert.ErrorIs(t, err, kernel.ErrNilAggregate)
+	})
+}
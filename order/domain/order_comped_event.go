@@ -0,0 +1,24 @@
+package order
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+
+// CompedEvent is a domain event raised when a gift Order is moved to Paid without
+// a payment, carrying the total amount that was waived.
+type CompedEvent struct {
+	kernel.Event
+	OrderID     string  `json:"order_id"`
+	CustomerID  string  `json:"customer_id"`
+	TotalAmount float64 `json:"total_amount"`
+}
+
+func newCompedEvent(orderID string, customerID string, totalAmount float64) *CompedEvent {
+	return &CompedEvent{
+		Event: kernel.Event{
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
+		},
+		OrderID:     orderID,
+		CustomerID:  customerID,
+		TotalAmount: totalAmount,
+	}
+}
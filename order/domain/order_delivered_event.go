@@ -1,10 +1,6 @@
 package order
 
-import (
-	"time"
-
-	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
-)
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
 
 // DeliveredEvent is a domain event raised when an Order is successfully delivered
 // to the customer.
@@ -17,8 +13,8 @@ type DeliveredEvent struct {
 func newDeliveredEvent(orderID string, customerID string) *DeliveredEvent {
 	return &DeliveredEvent{
 		Event: kernel.Event{
-			ID:           kernel.NewID().String(),
-			DateOccurred: time.Now().UTC(),
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
 		},
 		OrderID:    orderID,
 		CustomerID: customerID,
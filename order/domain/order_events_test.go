@@ -0,0 +1,128 @@
+package order_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countEvents tallies events by their dynamic type name, e.g. "*order.PaidEvent".
+func countEvents(events []kernel.DomainEvent) map[string]int {
+	counts := make(map[string]int)
+	for _, e := range events {
+		counts[reflect.TypeOf(e).String()]++
+	}
+	return counts
+}
+
+// This test ensures every Order transition raises exactly one event of its own type,
+// alongside the ChangedEvent raised by every change, and that repeating the transition,
+// which then fails, raises nothing.
+func TestOrder_TransitionEventsEmittedOnce(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T) (*order.Order, func() error)
+		wantEvents map[string]int
+	}{
+		{
+			name: "HandleApprovedPaymentEvent",
+			setup: func(t *testing.T) (*order.Order, func() error) {
+				o := createOrderWithItems(t)
+				p, err := o.StartPayment(payment.MethodCreditCard)
+				require.NoError(t, err)
+				return o, func() error { return o.HandleApprovedPaymentEvent(p.ID) }
+			},
+			wantEvents: map[string]int{"*order.PaidEvent": 1, "*order.ChangedEvent": 1},
+		},
+		{
+			name: "HandleRejectedPaymentEvent",
+			setup: func(t *testing.T) (*order.Order, func() error) {
+				o := createOrderWithItems(t)
+				p, err := o.StartPayment(payment.MethodCreditCard)
+				require.NoError(t, err)
+				return o, func() error { return o.HandleRejectedPaymentEvent(p.ID) }
+			},
+			wantEvents: map[string]int{"*order.CancelledEvent": 1, "*order.ChangedEvent": 1},
+		},
+		{
+			name: "MarkAsComped",
+			setup: func(t *testing.T) (*order.Order, func() error) {
+				o := createOrderWithItems(t)
+				require.NoError(t, o.MarkAsGift())
+				return o, o.MarkAsComped
+			},
+			wantEvents: map[string]int{"*order.CompedEvent": 1, "*order.ChangedEvent": 1},
+		},
+		{
+			name: "MarkAsSeparating",
+			setup: func(t *testing.T) (*order.Order, func() error) {
+				o := driveOrderToPaid(t)
+				return o, o.MarkAsSeparating
+			},
+			wantEvents: map[string]int{"*order.ChangedEvent": 1},
+		},
+		{
+			name: "MarkAsShipped",
+			setup: func(t *testing.T) (*order.Order, func() error) {
+				o := driveOrderToSeparating(t)
+				return o, o.MarkAsShipped
+			},
+			wantEvents: map[string]int{"*order.ShippedEvent": 1, "*order.ChangedEvent": 1},
+		},
+		{
+			name: "MarkAsDelivered",
+			setup: func(t *testing.T) (*order.Order, func() error) {
+				o := driveOrderToShipped(t)
+				return o, o.MarkAsDelivered
+			},
+			wantEvents: map[string]int{"*order.DeliveredEvent": 1, "*order.ChangedEvent": 1},
+		},
+		{
+			name: "RequestReturn",
+			setup: func(t *testing.T) (*order.Order, func() error) {
+				testfixtures.Clock(t)
+				o := driveOrderToDelivered(t)
+				itemIDs := []string{o.Items()[0].ID}
+				return o, func() error { return o.RequestReturn(itemIDs, order.CancellationReasonOther) }
+			},
+			wantEvents: map[string]int{"*order.ReturnRequestedEvent": 1, "*order.ChangedEvent": 1},
+		},
+		{
+			name: "Expire",
+			setup: func(t *testing.T) (*order.Order, func() error) {
+				clock := testfixtures.Clock(t)
+				o := createOrderWithItems(t)
+				clock.Advance(order.UnpaidOrderTTL() + time.Second)
+				return o, func() error { return o.Expire(kernel.Now()) }
+			},
+			wantEvents: map[string]int{"*order.CancelledEvent": 1, "*order.ChangedEvent": 1},
+		},
+		{
+			name: "Cancel",
+			setup: func(t *testing.T) (*order.Order, func() error) {
+				o := driveOrderToShipped(t)
+				return o, func() error { return o.Cancel(order.CancellationReasonCustomerCancelled) }
+			},
+			wantEvents: map[string]int{"*order.CancelledEvent": 1, "*order.ChangedEvent": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run("should emit exactly one event for "+tt.name, func(t *testing.T) {
+			o, transition := tt.setup(t)
+			o.PullDomainEvents()
+
+			require.NoError(t, transition())
+			assert.Equal(t, tt.wantEvents, countEvents(o.PullDomainEvents()))
+
+			require.Error(t, transition(), "repeating the transition should fail")
+			assert.Empty(t, o.PullDomainEvents(), "a failed transition should emit nothing")
+		})
+	}
+}
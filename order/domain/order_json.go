@@ -0,0 +1,33 @@
+package order
+
+import (
+	"encoding/json"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+)
+
+// orderFields has the fields of Order but none of its methods, so marshaling it does not
+// recurse into [Order.MarshalJSON].
+type orderFields Order
+
+// MarshalJSON serializes the order with snake_case field names, including its line items
+// and payments, which are otherwise unexported. Payments are rendered through
+// [payment.Payment.MarshalJSON], so their transaction codes are masked. The figures of
+// [Order.Breakdown] are included so clients can show the item discounts apart from the
+// order-level discount (coupon and credits), the tax and the net total. Shipping is
+// always 0, as no shipping fee is charged yet.
+func (o Order) MarshalJSON() ([]byte, error) {
+	b := o.Breakdown()
+	itemDiscounts := b.Gross - b.ItemsTotal
+	return json.Marshal(struct {
+		orderFields
+		Items             []orderitem.OrderItem `json:"items"`
+		Payments          []payment.Payment     `json:"payments"`
+		ItemDiscountTotal float64               `json:"item_discount_total"`
+		OrderDiscount     float64               `json:"order_discount"`
+		Shipping          float64               `json:"shipping"`
+		Tax               float64               `json:"tax"`
+		NetTotal          float64               `json:"net_total"`
+	}{orderFields(o), o.Items(), o.Payments(), itemDiscounts, b.DiscountTotal - itemDiscounts, 0, b.Tax, b.Total})
+}
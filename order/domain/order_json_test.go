@@ -0,0 +1,75 @@
+package order_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/promo"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_MarshalJSON(t *testing.T) {
+	t.Run("should serialize every field with snake_case names", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t, testfixtures.WithItem("prod-1", "Widget", 50.0, 2), testfixtures.WithItem("prod-2", "Gadget", 10.0, 1))
+		o.Number = "PED-GOLDEN"
+		require.NoError(t, o.ApplyCoupon(*kernel.Must(promo.NewCoupon("OFF10", promo.DiscountTypeAbsolute, 10, 0))))
+		require.NoError(t, o.AddCredit(5.0, "goodwill"))
+		require.NoError(t, o.ApplyTaxPolicy(order.TaxPolicy{"SP": 0.1}))
+		p, err := o.StartPayment(payment.MethodPix)
+		require.NoError(t, err)
+		require.NoError(t, p.DefineTransactionCode("TXN-0001-ABCD"))
+
+		got, err := json.Marshal(o)
+
+		require.NoError(t, err)
+		testfixtures.Golden(t, "order", got)
+	})
+
+	t.Run("should serialize the discount breakdown", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t, testfixtures.WithItem("prod-1", "Widget", 50.0, 2), testfixtures.WithItem("prod-2", "Gadget", 10.0, 1))
+		o.Number = "PED-GOLDEN"
+		item := o.Items()[0]
+		require.NoError(t, o.ApplyItemDiscount(item.ID, 20.0))
+		require.NoError(t, o.ApplyCoupon(*kernel.Must(promo.NewCoupon("OFF10", promo.DiscountTypeAbsolute, 10, 0))))
+		require.NoError(t, o.AddCredit(5.0, "goodwill"))
+		require.NoError(t, o.ApplyTaxPolicy(order.TaxPolicy{"SP": 0.1}))
+
+		got, err := json.Marshal(o)
+
+		require.NoError(t, err)
+		testfixtures.Golden(t, "order_discounts", got)
+	})
+
+	t.Run("should expose a discount breakdown consistent with the total", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t, testfixtures.WithItem("prod-1", "Widget", 50.0, 2), testfixtures.WithItem("prod-2", "Gadget", 10.0, 1))
+		item := o.Items()[0]
+		require.NoError(t, o.ApplyItemDiscount(item.ID, 20.0))
+		require.NoError(t, o.ApplyCoupon(*kernel.Must(promo.NewCoupon("OFF10", promo.DiscountTypeAbsolute, 10, 0))))
+		require.NoError(t, o.AddCredit(5.0, "goodwill"))
+		require.NoError(t, o.ApplyTaxPolicy(order.TaxPolicy{"SP": 0.1}))
+		data, err := json.Marshal(o)
+		require.NoError(t, err)
+
+		var got struct {
+			ItemDiscountTotal float64 `json:"item_discount_total"`
+			OrderDiscount     float64 `json:"order_discount"`
+			Shipping          float64 `json:"shipping"`
+			Tax               float64 `json:"tax"`
+			NetTotal          float64 `json:"net_total"`
+			TotalAmount       float64 `json:"total_amount"`
+		}
+		require.NoError(t, json.Unmarshal(data, &got))
+
+		assert.Equal(t, 20.0, got.ItemDiscountTotal)
+		assert.Equal(t, 15.0, got.OrderDiscount, "order discount should be the coupon plus the credits")
+		assert.Zero(t, got.Shipping, "no shipping fee is charged yet")
+		assert.Equal(t, 7.5, got.Tax)
+		assert.InDelta(t, 110.0-got.ItemDiscountTotal-got.OrderDiscount+got.Shipping+got.Tax, got.NetTotal, 1e-9)
+		assert.Equal(t, got.TotalAmount, got.NetTotal, "the net total should be the amount due")
+	})
+}
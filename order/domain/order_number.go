@@ -0,0 +1,46 @@
+package order
+
+import (
+	"context"
+	"strings"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
+)
+
+var (
+	ErrInvalidOrderNumber         = errs.New("ORDER.INVALID_NUMBER", "order number cannot be null or whitespace")
+	ErrOrderNumberAlreadyAssigned = errs.NewConflict("ORDER.NUMBER_ALREADY_ASSIGNED", "order number is already assigned")
+)
+
+// NumberGenerator is the port that draws the human-friendly Number of new orders.
+// Customers and support staff identify orders by it, so implementations must never hand
+// out a number twice, across processes and restarts; back them with a durable sequence,
+// such as a database one.
+type NumberGenerator interface {
+	// Next returns a number no order has been given before.
+	Next(ctx context.Context) (string, error)
+}
+
+// AssignNumber gives the order its human-friendly number, drawn from a
+// [NumberGenerator], and raises a ChangedEvent. number is stored without surrounding
+// whitespace; a blank one returns [ErrInvalidOrderNumber], and an order that already
+// has a number returns [ErrOrderNumberAlreadyAssigned].
+func (o *Order) AssignNumber(number string) error {
+	if o == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if err := guard.CheckNotNullOrWhiteSpace(number, ErrInvalidOrderNumber); err != nil {
+		return err
+	}
+
+	if o.Number != "" {
+		return ErrOrderNumberAlreadyAssigned
+	}
+
+	o.Number = strings.TrimSpace(number)
+	o.touch()
+	return nil
+}
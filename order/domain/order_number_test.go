@@ -0,0 +1,50 @@
+package order_test
+
+import (
+	"testing"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_AssignNumber(t *testing.T) {
+	t.Run("should store the number and raise a ChangedEvent", func(t *testing.T) {
+		o := createValidOrder(t)
+		o.PullDomainEvents()
+
+		err := o.AssignNumber(" PED-000042 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "PED-000042", o.Number)
+		events := o.PullDomainEvents()
+		require.Len(t, events, 1)
+		require.IsType(t, &order.ChangedEvent{}, events[0])
+		assert.Equal(t, "PED-000042", events[0].(*order.ChangedEvent).Summary.Number)
+	})
+
+	t.Run("should leave a new order without a number", func(t *testing.T) {
+		o := createValidOrder(t)
+
+		assert.Empty(t, o.Number)
+	})
+
+	t.Run("should return ErrInvalidOrderNumber for a blank number", func(t *testing.T) {
+		o := createValidOrder(t)
+
+		err := o.AssignNumber("  ")
+
+		assert.ErrorIs(t, err, order.ErrInvalidOrderNumber)
+		assert.Empty(t, o.Number)
+	})
+
+	t.Run("should return ErrOrderNumberAlreadyAssigned for a numbered order", func(t *testing.T) {
+		o := createValidOrder(t)
+		require.NoError(t, o.AssignNumber("PED-000001"))
+
+		err := o.AssignNumber("PED-000002")
+
+		assert.ErrorIs(t, err, order.ErrOrderNumberAlreadyAssigned)
+		assert.Equal(t, "PED-000001", o.Number)
+	})
+}
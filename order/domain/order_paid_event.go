@@ -0,0 +1,26 @@
+package order
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+
+// PaidEvent is a domain event raised when an Order is paid through an approved payment,
+// carrying the payment ID and the amount charged.
+type PaidEvent struct {
+	kernel.Event
+	OrderID     string  `json:"order_id"`
+	CustomerID  string  `json:"customer_id"`
+	PaymentID   string  `json:"payment_id"`
+	TotalAmount float64 `json:"total_amount"`
+}
+
+func newPaidEvent(orderID string, customerID string, paymentID string, totalAmount float64) *PaidEvent {
+	return &PaidEvent{
+		Event: kernel.Event{
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
+		},
+		OrderID:     orderID,
+		CustomerID:  customerID,
+		PaymentID:   paymentID,
+		TotalAmount: totalAmount,
+	}
+}
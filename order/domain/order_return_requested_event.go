@@ -0,0 +1,26 @@
+package order
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+
+// ReturnRequestedEvent is a domain event raised when the customer requests to return
+// items of a delivered Order.
+type ReturnRequestedEvent struct {
+	kernel.Event
+	OrderID    string             `json:"order_id"`
+	CustomerID string             `json:"customer_id"`
+	ItemIDs    []string           `json:"item_ids"`
+	Reason     CancellationReason `json:"reason"`
+}
+
+func newReturnRequestedEvent(orderID string, customerID string, itemIDs []string, reason CancellationReason) *ReturnRequestedEvent {
+	return &ReturnRequestedEvent{
+		Event: kernel.Event{
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
+		},
+		OrderID:    orderID,
+		CustomerID: customerID,
+		ItemIDs:    itemIDs,
+		Reason:     reason,
+	}
+}
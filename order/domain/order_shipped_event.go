@@ -1,28 +1,26 @@
 package order
 
-import (
-	"time"
-
-	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
-)
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
 
 // ShippedEvent is a domain event raised when an Order is dispatched,
-// carrying the delivery address.
+// carrying the delivery address and, when shipped with [Order.Ship], the tracking code.
 type ShippedEvent struct {
 	kernel.Event
 	OrderID         string          `json:"order_id"`
 	CustomerID      string          `json:"customer_id"`
 	DeliveryAddress DeliveryAddress `json:"delivery_address"`
+	TrackingCode    string          `json:"tracking_code,omitempty"`
 }
 
-func newShippedEvent(orderID string, customerID string, deliveryAddress DeliveryAddress) *ShippedEvent {
+func newShippedEvent(orderID string, customerID string, deliveryAddress DeliveryAddress, trackingCode string) *ShippedEvent {
 	return &ShippedEvent{
 		Event: kernel.Event{
-			ID:           kernel.NewID().String(),
-			DateOccurred: time.Now().UTC(),
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
 		},
 		OrderID:         orderID,
 		CustomerID:      customerID,
 		DeliveryAddress: deliveryAddress,
+		TrackingCode:    trackingCode,
 	}
 }
@@ -38,13 +38,32 @@ func (s Status) MarshalText() ([]byte, error) {
 	return []byte(s.String()), nil
 }
 
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidOrderStatus] and leave s unchanged instead of decoding to an uninitialized Status.
+func (s *Status) UnmarshalText(text []byte) error {
+	for v, str := range statusToString {
+		if str == string(text) {
+			*s = v
+			return nil
+		}
+	}
+	return ErrInvalidOrderStatus
+}
+
 // Equals checks if two Status values are equal.
 func (s Status) Equals(other Status) bool {
 	return s.value == other.value
 }
 
+// IsValid reports whether the Status is one of the defined values; the zero value is not.
+func (s Status) IsValid() bool {
+	_, ok := statusToString[s]
+	return ok
+}
+
 // ParseStatus converts an int to the corresponding Status value.
-// If the input does not match any known status, it returns an error and an empty Status value.
+// If the input does not match any known status, it returns an error and an empty Status value;
+// 0, the uninitialized value, is always rejected.
 func ParseStatus(value int) (Status, error) {
 	s := Status{value}
 	if _, ok := statusToString[s]; !ok {
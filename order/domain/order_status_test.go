@@ -55,6 +55,48 @@ func TestStatus_MarshalText(t *testing.T) {
 	}
 }
 
+func TestStatus_UnmarshalText(t *testing.T) {
+	// ==================== Success cases ==================== //
+	successTests := []struct {
+		name string
+		text string
+		want order.Status
+	}{
+		{name: "should unmarshal 'shipped' to StatusShipped", text: "shipped", want: order.StatusShipped},
+		{name: "should unmarshal 'paid' to StatusPaid", text: "paid", want: order.StatusPaid},
+	}
+	for _, tt := range successTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got order.Status
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	failureTests := []struct {
+		name string
+		text string
+	}{
+		{name: "should return an error for a misspelled status", text: "shipped_typo"},
+		{name: "should return an error for the 'unknown' placeholder", text: "unknown"},
+		{name: "should return an error for an empty string", text: ""},
+	}
+	for _, tt := range failureTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := order.StatusPaid
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			assert.ErrorIs(t, err, order.ErrInvalidOrderStatus)
+			assert.Equal(t, order.StatusPaid, got, "value should be unchanged")
+		})
+	}
+}
+
 func TestStatus_Equals(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -107,6 +149,7 @@ func TestParseStatus(t *testing.T) {
 		value   int
 		wantErr error
 	}{
+		{name: "should return an error for zero value (uninitialized)", value: 0, wantErr: order.ErrInvalidOrderStatus},
 		{name: "should return an error for a negative value", value: -1, wantErr: order.ErrInvalidOrderStatus},
 		{name: "should return an error for an out-of-range value", value: 999, wantErr: order.ErrInvalidOrderStatus},
 	}
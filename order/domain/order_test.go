@@ -1,12 +1,17 @@
 package order_test
 
 import (
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
 	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/promo"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -60,6 +65,18 @@ func driveOrderToDelivered(t *testing.T) *order.Order {
 	return o
 }
 
+// pullLifecycleEvents drains the order's events, leaving out the ChangedEvent raised
+// alongside every change.
+func pullLifecycleEvents(o *order.Order) []kernel.DomainEvent {
+	var events []kernel.DomainEvent
+	for _, e := range o.PullDomainEvents() {
+		if _, ok := e.(*order.ChangedEvent); !ok {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
 // ==================== Tests ==================== //
 
 func TestNewOrder(t *testing.T) {
@@ -74,6 +91,7 @@ func TestNewOrder(t *testing.T) {
 		assert.Equal(t, "cust-123", got.CustomerID)
 		assert.Equal(t, order.StatusPending, got.Status, "status should be Pending")
 		assert.Equal(t, 0.0, got.TotalAmount, "TotalAmount should be zero on creation")
+		assert.False(t, got.CreatedAt.IsZero(), "CreatedAt should be set on creation")
 		assert.Nil(t, got.UpdatedAt, "UpdatedAt should be nil on creation")
 	})
 
@@ -116,6 +134,49 @@ func TestNewOrder(t *testing.T) {
 	})
 }
 
+func TestNewOrderWithItems(t *testing.T) {
+	t.Run("should create the order holding every item", func(t *testing.T) {
+		items := []*orderitem.OrderItem{
+			kernel.Must(orderitem.NewOrderItem("prod-1", "Widget", 50.0, 2)),
+			kernel.Must(orderitem.NewOrderItem("prod-2", "Gadget", 10.0, 1)),
+		}
+
+		got, err := order.NewOrderWithItems("cust-123", createValidAddress(t), items)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusPending, got.Status)
+		assert.Len(t, got.Items(), 2)
+		assert.Equal(t, 110.0, got.TotalAmount)
+		assert.Nil(t, got.UpdatedAt, "UpdatedAt should be nil on creation")
+	})
+
+	t.Run("should merge lines for the same product into one line", func(t *testing.T) {
+		first := kernel.Must(orderitem.NewOrderItem("prod-1", "Widget", 50.0, 2))
+		items := []*orderitem.OrderItem{
+			first,
+			kernel.Must(orderitem.NewOrderItem("prod-1", "Widget", 50.0, 3)),
+		}
+
+		got, err := order.NewOrderWithItems("cust-123", createValidAddress(t), items)
+
+		require.NoError(t, err)
+		require.Len(t, got.Items(), 1, "duplicate product lines should be merged")
+		assert.Equal(t, first.ID, got.Items()[0].ID, "the first line should be kept")
+		assert.Equal(t, 5, got.Items()[0].Quantity, "quantities should be summed")
+		assert.Equal(t, 250.0, got.TotalAmount)
+		assert.Equal(t, 2, first.Quantity, "input items should not be modified")
+	})
+
+	t.Run("should return an error when order input is invalid", func(t *testing.T) {
+		items := []*orderitem.OrderItem{kernel.Must(orderitem.NewOrderItem("prod-1", "Widget", 50.0, 2))}
+
+		got, err := order.NewOrderWithItems("", createValidAddress(t), items)
+
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, order.ErrInvalidCustomerID)
+	})
+}
+
 func TestOrder_AddItem(t *testing.T) {
 	t.Run("should successfully add a new item and update TotalAmount", func(t *testing.T) {
 		o := createValidOrder(t)
@@ -210,270 +271,1530 @@ func TestOrder_RemoveItem(t *testing.T) {
 	})
 }
 
-func TestOrder_UpdateDeliveryAddress(t *testing.T) {
-	t.Run("should successfully update delivery address", func(t *testing.T) {
+func TestOrder_Items(t *testing.T) {
+	t.Run("should return copies that do not affect the order when mutated", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		items := o.Items()
+		require.Len(t, items, 1)
+		require.NoError(t, items[0].AddUnits(10))
+
+		assert.Equal(t, 2, o.Items()[0].Quantity, "stored quantity should be unchanged")
+		assert.Equal(t, 100.0, o.TotalAmount, "TotalAmount should be unchanged")
+	})
+}
+
+func TestOrder_FindItem(t *testing.T) {
+	o := createOrderWithItems(t)
+	itemID := o.Items()[0].ID
+
+	got, ok := o.FindItem(itemID)
+	assert.True(t, ok)
+	assert.Equal(t, "prod-1", got.ProductID)
+
+	_, ok = o.FindItem("unknown-item-id")
+	assert.False(t, ok)
+}
+
+func TestOrder_Payments(t *testing.T) {
+	t.Run("should return copies of every started payment", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		p, err := o.StartPayment(payment.MethodPix)
+		require.NoError(t, err)
+
+		got := o.Payments()
+
+		require.Len(t, got, 1)
+		assert.Equal(t, p.ID, got[0].ID)
+		require.NoError(t, got[0].DefineTransactionCode("TXN-1"))
+		assert.Nil(t, p.TransactionCode, "stored payment should be unchanged")
+	})
+}
+
+func TestOrder_UpdateProductPrice(t *testing.T) {
+	t.Run("should reprice the merged line of the product and recalculate the total", func(t *testing.T) {
 		o := createValidOrder(t)
-		newAddr := kernel.Must(order.NewDeliveryAddress("98765-432", "Av. Brasil", "500", "Apto 1", "Jardins", "Rio de Janeiro", "RJ", "Brasil"))
+		require.NoError(t, o.AddItem("prod-1", "Widget", 50.0, 2))
+		require.NoError(t, o.AddItem("prod-1", "Widget", 50.0, 1))
+		require.NoError(t, o.AddItem("prod-2", "Gadget", 10.0, 1))
 
-		err := o.UpdateDeliveryAddress(*newAddr)
+		err := o.UpdateProductPrice("prod-1", 40.0)
 
 		require.NoError(t, err)
-		assert.True(t, o.DeliveryAddress.Equals(newAddr), "DeliveryAddress should be replaced")
+		for _, item := range o.Items() {
+			if item.ProductID == "prod-1" {
+				assert.Equal(t, 40.0, item.UnitPrice)
+				assert.Equal(t, 120.0, item.TotalPrice, "TotalPrice should be 40 * 3 = 120")
+			} else {
+				assert.Equal(t, 10.0, item.UnitPrice, "other products should keep their price")
+			}
+		}
+		assert.Equal(t, 130.0, o.Total(), "Total should be 120 + 10 = 130")
 		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
 	})
 
-	t.Run("should return an error when order is not pending", func(t *testing.T) {
-		o := driveOrderToPaid(t)
-		newAddr := kernel.Must(order.NewDeliveryAddress("98765-432", "Av. Brasil", "500", "", "Jardins", "Rio de Janeiro", "RJ", "Brasil"))
+	t.Run("should return an error when no line matches the product", func(t *testing.T) {
+		o := createOrderWithItems(t)
 
-		err := o.UpdateDeliveryAddress(*newAddr)
+		err := o.UpdateProductPrice("unknown-product", 40.0)
 
-		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+		assert.ErrorIs(t, err, order.ErrItemNotFound)
+		assert.Equal(t, 100.0, o.Total(), "Total should be unchanged")
 	})
 
-	t.Run("should return an error when address is zero value", func(t *testing.T) {
-		o := createValidOrder(t)
+	t.Run("should return an error when the price is invalid", func(t *testing.T) {
+		o := createOrderWithItems(t)
 
-		err := o.UpdateDeliveryAddress(order.DeliveryAddress{})
+		err := o.UpdateProductPrice("prod-1", 0)
 
-		assert.ErrorIs(t, err, order.ErrInvalidDeliveryAddress)
+		assert.ErrorIs(t, err, orderitem.ErrInvalidUnitPrice)
+		assert.Equal(t, 100.0, o.Total(), "Total should be unchanged")
+	})
+
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.UpdateProductPrice("prod-1", 40.0)
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
 	})
 }
 
-func TestOrder_StartPayment(t *testing.T) {
-	t.Run("should successfully start a payment and store it", func(t *testing.T) {
+func TestOrder_AddUnitsToItem(t *testing.T) {
+	t.Run("should add units to the item and recalculate TotalAmount", func(t *testing.T) {
 		o := createOrderWithItems(t)
+		itemID := o.Items()[0].ID
 
-		p, err := o.StartPayment(payment.MethodCreditCard)
+		err := o.AddUnitsToItem(itemID, 3)
 
 		require.NoError(t, err)
-		require.NotNil(t, p)
-		assert.NotEmpty(t, p.ID)
-		assert.Equal(t, payment.StatusPending, p.Status, "payment status should be Pending")
+		assert.Equal(t, 5, o.Items()[0].Quantity)
+		assert.Equal(t, 250.0, o.TotalAmount, "TotalAmount should be 50 * 5 = 250")
 		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
 	})
 
-	t.Run("should return an error when order is not pending", func(t *testing.T) {
-		o := driveOrderToPaid(t)
+	t.Run("should return an error when item is not in the order", func(t *testing.T) {
+		o := createOrderWithItems(t)
 
-		p, err := o.StartPayment(payment.MethodCreditCard)
+		err := o.AddUnitsToItem("unknown-item-id", 1)
 
-		assert.Nil(t, p)
-		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+		assert.ErrorIs(t, err, order.ErrItemNotFound)
 	})
 
-	t.Run("should return an error when order has no items", func(t *testing.T) {
-		o := createValidOrder(t)
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+		itemID := o.Items()[0].ID
 
-		p, err := o.StartPayment(payment.MethodCreditCard)
+		err := o.AddUnitsToItem(itemID, 1)
 
-		assert.Nil(t, p)
-		assert.ErrorIs(t, err, order.ErrNoItems)
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+		assert.Equal(t, 100.0, o.TotalAmount, "TotalAmount should be unchanged")
 	})
 
-	t.Run("should return an error when a pending payment already exists", func(t *testing.T) {
+	t.Run("should return the item error when units are invalid", func(t *testing.T) {
 		o := createOrderWithItems(t)
-		_, err := o.StartPayment(payment.MethodCreditCard)
-		require.NoError(t, err)
+		itemID := o.Items()[0].ID
 
-		p2, err := o.StartPayment(payment.MethodCreditCard)
+		err := o.AddUnitsToItem(itemID, 0)
 
-		assert.Nil(t, p2)
-		assert.ErrorIs(t, err, order.ErrPaymentAlreadyPending)
+		assert.ErrorIs(t, err, orderitem.ErrInvalidUnits)
 	})
 }
 
-func TestOrder_HandleApprovedPaymentEvent(t *testing.T) {
-	t.Run("should transition order to Paid when payment is approved", func(t *testing.T) {
+func TestOrder_SetItemNote(t *testing.T) {
+	t.Run("should set the note of the item", func(t *testing.T) {
 		o := createOrderWithItems(t)
-		p, err := o.StartPayment(payment.MethodCreditCard)
-		require.NoError(t, err)
+		itemID := o.Items()[0].ID
 
-		err = o.HandleApprovedPaymentEvent(p.ID)
+		err := o.SetItemNote(itemID, "gift wrap this one")
 
 		require.NoError(t, err)
-		assert.Equal(t, order.StatusPaid, o.Status, "status should be Paid")
-		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+		item, _ := o.FindItem(itemID)
+		assert.Equal(t, "gift wrap this one", item.Note)
 	})
 
-	t.Run("should return an error when order is not pending", func(t *testing.T) {
-		o := driveOrderToPaid(t)
+	t.Run("should return an error when the item does not exist", func(t *testing.T) {
+		o := createOrderWithItems(t)
 
-		err := o.HandleApprovedPaymentEvent("any-id")
+		err := o.SetItemNote("missing", "gift wrap this one")
 
-		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+		assert.ErrorIs(t, err, order.ErrItemNotFound)
 	})
 
-	t.Run("should be a no-op when paymentID is unknown", func(t *testing.T) {
+	t.Run("should return an error when the order is not editable", func(t *testing.T) {
 		o := createOrderWithItems(t)
+		itemID := o.Items()[0].ID
+		_, err := o.StartPayment(payment.MethodPix)
+		require.NoError(t, err)
 
-		err := o.HandleApprovedPaymentEvent("unknown-payment-id")
+		err = o.SetItemNote(itemID, "gift wrap this one")
 
-		require.NoError(t, err)
-		assert.Equal(t, order.StatusPending, o.Status, "status should remain Pending")
+		assert.ErrorIs(t, err, order.ErrCannotEditPaidOrder)
 	})
 }
 
-func TestOrder_HandleRejectedPaymentEvent(t *testing.T) {
-	t.Run("should transition order to Cancelled when payment is rejected", func(t *testing.T) {
+func TestOrder_RemoveUnitsFromItem(t *testing.T) {
+	t.Run("should remove units from the item and recalculate TotalAmount", func(t *testing.T) {
 		o := createOrderWithItems(t)
-		p, err := o.StartPayment(payment.MethodCreditCard)
-		require.NoError(t, err)
+		itemID := o.Items()[0].ID
 
-		err = o.HandleRejectedPaymentEvent(p.ID)
+		err := o.RemoveUnitsFromItem(itemID, 1)
 
 		require.NoError(t, err)
-		assert.Equal(t, order.StatusCancelled, o.Status, "status should be Cancelled")
-		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+		assert.Equal(t, 1, o.Items()[0].Quantity)
+		assert.Equal(t, 50.0, o.TotalAmount, "TotalAmount should be 50 * 1 = 50")
+	})
+
+	t.Run("should return an error when item is not in the order", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.RemoveUnitsFromItem("unknown-item-id", 1)
+
+		assert.ErrorIs(t, err, order.ErrItemNotFound)
 	})
 
 	t.Run("should return an error when order is not pending", func(t *testing.T) {
 		o := driveOrderToPaid(t)
+		itemID := o.Items()[0].ID
 
-		err := o.HandleRejectedPaymentEvent("any-id")
+		err := o.RemoveUnitsFromItem(itemID, 1)
 
 		assert.ErrorIs(t, err, order.ErrOrderNotPending)
 	})
 
-	t.Run("should be a no-op when paymentID is unknown", func(t *testing.T) {
+	t.Run("should return the item error when removal would empty the line", func(t *testing.T) {
 		o := createOrderWithItems(t)
+		itemID := o.Items()[0].ID
 
-		err := o.HandleRejectedPaymentEvent("unknown-payment-id")
+		err := o.RemoveUnitsFromItem(itemID, 2)
 
-		require.NoError(t, err)
-		assert.Equal(t, order.StatusPending, o.Status, "status should remain Pending")
+		assert.ErrorIs(t, err, orderitem.ErrInsufficientQuantity)
 	})
 }
 
-func TestOrder_MarkAsSeparating(t *testing.T) {
-	t.Run("should transition order from Paid to Separating", func(t *testing.T) {
-		o := driveOrderToPaid(t)
+func TestOrder_ApplyItemDiscount(t *testing.T) {
+	t.Run("should apply the discount to the item and recalculate TotalAmount", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		itemID := o.Items()[0].ID
 
-		err := o.MarkAsSeparating()
+		err := o.ApplyItemDiscount(itemID, 20)
 
 		require.NoError(t, err)
-		assert.Equal(t, order.StatusSeparating, o.Status, "status should be Separating")
+		assert.Equal(t, 20.0, o.Items()[0].DiscountApplied)
+		assert.Equal(t, 80.0, o.TotalAmount, "TotalAmount should be 100 - 20 = 80")
 		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
 	})
 
-	t.Run("should return an error when order is not Paid", func(t *testing.T) {
-		tests := []struct {
-			name  string
-			setup func(t *testing.T) *order.Order
-		}{
-			{name: "status Pending", setup: createValidOrder},
-			{name: "status Separating", setup: driveOrderToSeparating},
-			{name: "status Shipped", setup: driveOrderToShipped},
-			{name: "status Delivered", setup: driveOrderToDelivered},
-			{
-				name: "status Cancelled",
-				setup: func(t *testing.T) *order.Order {
-					o := driveOrderToShipped(t)
-					require.NoError(t, o.Cancel(order.CancellationReasonCustomerCancelled))
-					return o
-				},
-			},
-		}
-		for _, tt := range tests {
-			t.Run(tt.name, func(t *testing.T) {
-				o := tt.setup(t)
+	t.Run("should return an error when item is not in the order", func(t *testing.T) {
+		o := createOrderWithItems(t)
 
-				err := o.MarkAsSeparating()
+		err := o.ApplyItemDiscount("unknown-item-id", 10)
 
-				assert.ErrorIs(t, err, order.ErrOrderNotPaid)
-			})
-		}
+		assert.ErrorIs(t, err, order.ErrItemNotFound)
 	})
-}
 
-func TestOrder_MarkAsShipped(t *testing.T) {
-	t.Run("should transition order from Separating to Shipped", func(t *testing.T) {
-		o := driveOrderToSeparating(t)
+	t.Run("should return an error when order is already paid", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+		itemID := o.Items()[0].ID
 
-		err := o.MarkAsShipped()
+		err := o.ApplyItemDiscount(itemID, 20)
 
-		require.NoError(t, err)
-		assert.Equal(t, order.StatusShipped, o.Status, "status should be Shipped")
-		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+		assert.Zero(t, o.Items()[0].DiscountApplied, "discount should be unchanged")
+		assert.Equal(t, 100.0, o.TotalAmount, "TotalAmount should be unchanged")
 	})
 
-	t.Run("should return an error when order is not Separating", func(t *testing.T) {
-		tests := []struct {
-			name  string
-			setup func(t *testing.T) *order.Order
-		}{
-			{name: "status Pending", setup: createValidOrder},
-			{name: "status Paid", setup: driveOrderToPaid},
-			{name: "status Shipped", setup: driveOrderToShipped},
-			{name: "status Delivered", setup: driveOrderToDelivered},
-		}
-		for _, tt := range tests {
-			t.Run(tt.name, func(t *testing.T) {
-				o := tt.setup(t)
+	t.Run("should still allow discounting a detached item of a paid order directly", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+		item := o.Items()[0]
 
-				err := o.MarkAsShipped()
+		err := item.ApplyDiscount(20)
 
-				assert.ErrorIs(t, err, order.ErrOrderNotSeparating)
-			})
-		}
+		require.NoError(t, err, "OrderItem knows nothing of its order's status")
+		assert.Equal(t, 20.0, item.DiscountApplied)
+		assert.Zero(t, o.Items()[0].DiscountApplied, "the order keeps its own copy")
 	})
-}
 
-func TestOrder_MarkAsDelivered(t *testing.T) {
-	t.Run("should transition order from Shipped to Delivered", func(t *testing.T) {
-		o := driveOrderToShipped(t)
+	t.Run("should return an error when the discount leaves credits above the items total", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.AddCredit(90, "loyalty"))
+		itemID := o.Items()[0].ID
 
-		err := o.MarkAsDelivered()
+		err := o.ApplyItemDiscount(itemID, 20)
 
-		require.NoError(t, err)
-		assert.Equal(t, order.StatusDelivered, o.Status, "status should be Delivered")
-		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+		assert.ErrorIs(t, err, order.ErrDiscountExceedsTotal)
+		assert.Zero(t, o.Items()[0].DiscountApplied, "discount should be unchanged")
 	})
 
-	t.Run("should return an error when order is not Shipped", func(t *testing.T) {
-		tests := []struct {
-			name  string
-			setup func(t *testing.T) *order.Order
-		}{
-			{name: "status Pending", setup: createValidOrder},
-			{name: "status Paid", setup: driveOrderToPaid},
-			{name: "status Separating", setup: driveOrderToSeparating},
-			{name: "status Delivered", setup: driveOrderToDelivered},
-		}
-		for _, tt := range tests {
-			t.Run(tt.name, func(t *testing.T) {
-				o := tt.setup(t)
+	t.Run("should return the item error when the discount is invalid", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		itemID := o.Items()[0].ID
 
-				err := o.MarkAsDelivered()
+		err := o.ApplyItemDiscount(itemID, -1)
 
-				assert.ErrorIs(t, err, order.ErrOrderNotShipped)
-			})
-		}
+		assert.ErrorIs(t, err, orderitem.ErrNegativeDiscount)
 	})
 }
 
-func TestOrder_Cancel(t *testing.T) {
-	t.Run("should successfully cancel from Shipped", func(t *testing.T) {
-		o := driveOrderToShipped(t)
+func TestOrder_UpdateDeliveryAddress(t *testing.T) {
+	t.Run("should successfully update delivery address", func(t *testing.T) {
+		o := createValidOrder(t)
+		newAddr := kernel.Must(order.NewDeliveryAddress("98765-432", "Av. Brasil", "500", "Apto 1", "Jardins", "Rio de Janeiro", "RJ", "Brasil"))
 
-		err := o.Cancel(order.CancellationReasonCustomerCancelled)
+		err := o.UpdateDeliveryAddress(*newAddr)
 
 		require.NoError(t, err)
-		assert.Equal(t, order.StatusCancelled, o.Status, "status should be Cancelled")
+		assert.True(t, o.DeliveryAddress.Equals(newAddr), "DeliveryAddress should be replaced")
 		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
 	})
 
-	t.Run("should successfully cancel from Delivered", func(t *testing.T) {
-		o := driveOrderToDelivered(t)
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+		newAddr := kernel.Must(order.NewDeliveryAddress("98765-432", "Av. Brasil", "500", "", "Jardins", "Rio de Janeiro", "RJ", "Brasil"))
 
-		err := o.Cancel(order.CancellationReasonCustomerCancelled)
+		err := o.UpdateDeliveryAddress(*newAddr)
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+	})
+
+	t.Run("should return an error when address is zero value", func(t *testing.T) {
+		o := createValidOrder(t)
+
+		err := o.UpdateDeliveryAddress(order.DeliveryAddress{})
+
+		assert.ErrorIs(t, err, order.ErrInvalidDeliveryAddress)
+	})
+}
+
+func TestOrder_ApplyCoupon(t *testing.T) {
+	t.Run("should apply an absolute coupon to the order total", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		c := kernel.Must(promo.NewCoupon("OFF15", promo.DiscountTypeAbsolute, 15, 0))
+
+		err := o.ApplyCoupon(*c)
 
 		require.NoError(t, err)
-		assert.Equal(t, order.StatusCancelled, o.Status, "status should be Cancelled")
+		assert.Equal(t, 15.0, o.DiscountAmount)
+		assert.Equal(t, 85.0, o.TotalAmount, "TotalAmount should be 100 - 15 = 85")
 		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
 	})
 
-	t.Run("should return an error when order cannot be cancelled", func(t *testing.T) {
+	t.Run("should apply a percentage coupon and follow later item changes", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		c := kernel.Must(promo.NewCoupon("TEN", promo.DiscountTypePercentage, 10, 0))
+
+		err := o.ApplyCoupon(*c)
+
+		require.NoError(t, err)
+		assert.Equal(t, 90.0, o.TotalAmount, "TotalAmount should be 100 - 10% = 90")
+		require.NoError(t, o.AddItem("prod-2", "Gadget", 100.0, 1))
+		assert.Equal(t, 180.0, o.TotalAmount, "TotalAmount should be 200 - 10% = 180")
+	})
+
+	t.Run("should return an error when the order total does not reach the coupon minimum", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		c := kernel.Must(promo.NewCoupon("BIG", promo.DiscountTypeAbsolute, 30, 150))
+
+		err := o.ApplyCoupon(*c)
+
+		assert.ErrorIs(t, err, order.ErrCouponMinNotMet)
+		assert.Nil(t, o.Coupon, "coupon should not be applied")
+		assert.Equal(t, 100.0, o.TotalAmount, "TotalAmount should be unchanged")
+	})
+
+	t.Run("should return an error when the coupon is invalid", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.ApplyCoupon(promo.Coupon{})
+
+		assert.ErrorIs(t, err, promo.ErrInvalidCouponCode)
+	})
+
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+		c := kernel.Must(promo.NewCoupon("OFF15", promo.DiscountTypeAbsolute, 15, 0))
+
+		err := o.ApplyCoupon(*c)
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+	})
+}
+
+func TestOrder_AddCredit(t *testing.T) {
+	t.Run("should partially offset the total and be reflected in the breakdown", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.AddCredit(30.0, "goodwill")
+
+		require.NoError(t, err)
+		assert.Equal(t, 70.0, o.Total(), "Total should be 100 - 30 = 70")
+		assert.Equal(t, order.Breakdown{Gross: 100.0, ItemsTotal: 100.0, Credits: 30.0, DiscountTotal: 30.0, Total: 70.0}, o.Breakdown())
+		require.Len(t, o.Credits, 1)
+		assert.Equal(t, "goodwill", o.Credits[0].Reason())
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should accept a credit that brings the total exactly to zero", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.AddCredit(100.0, "full refund")
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, o.Total())
+	})
+
+	t.Run("should return an error when the credit would drive the total negative", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.AddCredit(60.0, "goodwill"))
+
+		err := o.AddCredit(50.0, "return")
+
+		assert.ErrorIs(t, err, order.ErrCreditExceedsTotal)
+		assert.Equal(t, 40.0, o.Total(), "Total should be unchanged")
+		assert.Len(t, o.Credits, 1, "rejected credit should not be recorded")
+	})
+
+	t.Run("should return an error when the credit is invalid", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.AddCredit(-1.0, "")
+
+		assert.ErrorIs(t, err, order.ErrInvalidCreditAmount)
+		assert.ErrorIs(t, err, order.ErrInvalidCreditReason)
+	})
+
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.AddCredit(10.0, "goodwill")
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+	})
+
+	t.Run("should keep items that the credits depend on", func(t *testing.T) {
+		o := createValidOrder(t)
+		require.NoError(t, o.AddItem("prod-1", "Widget", 50.0, 2))
+		require.NoError(t, o.AddItem("prod-2", "Gadget", 10.0, 1))
+		require.NoError(t, o.AddCredit(105.0, "goodwill"))
+
+		err := o.RemoveItem(kernel.Must(orderitem.NewOrderItem("prod-2", "Gadget", 10.0, 1)))
+
+		assert.ErrorIs(t, err, order.ErrDiscountExceedsTotal)
+		assert.Len(t, o.Items(), 2)
+		assert.Equal(t, 5.0, o.Total())
+	})
+}
+
+func TestOrder_ApplyTaxPolicy(t *testing.T) {
+	policy := order.TaxPolicy{"SP": 0.18, "RJ": 0.2}
+
+	t.Run("should tax the order at the rate of the delivery state", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.ApplyTaxPolicy(policy)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.18, o.TaxRate)
+		assert.Equal(t, 18.0, o.TaxAmount())
+		assert.Equal(t, 118.0, o.Total(), "Total should be 100 + 18% = 118")
+		assert.Equal(t, order.Breakdown{Gross: 100.0, ItemsTotal: 100.0, Tax: 18.0, Total: 118.0}, o.Breakdown())
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should keep the total of an order without items at exactly zero", func(t *testing.T) {
+		o := createValidOrder(t)
+
+		err := o.ApplyTaxPolicy(policy)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, o.Total())
+		assert.False(t, math.Signbit(o.Total()), "Total should not be negative zero")
+	})
+
+	t.Run("should follow the rate of a new delivery state", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.ApplyTaxPolicy(policy))
+		newAddr := kernel.Must(order.NewDeliveryAddress("98765-432", "Av. Brasil", "500", "", "Jardins", "Rio de Janeiro", "RJ", "Brasil"))
+
+		require.NoError(t, o.UpdateDeliveryAddress(*newAddr))
+
+		assert.Equal(t, 0.2, o.TaxRate)
+		assert.Equal(t, 20.0, o.TaxAmount())
+		assert.Equal(t, 120.0, o.Total(), "Total should be 100 + 20% = 120")
+	})
+
+	t.Run("should tax the base left after coupon discount and credits", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		c := kernel.Must(promo.NewCoupon("SAVE10", promo.DiscountTypeAbsolute, 10.0, 0))
+		require.NoError(t, o.ApplyCoupon(*c))
+		require.NoError(t, o.AddCredit(40.0, "goodwill"))
+
+		require.NoError(t, o.ApplyTaxPolicy(policy))
+
+		assert.Equal(t, 9.0, o.TaxAmount(), "tax should be 18% of 100 - 10 - 40 = 50")
+		assert.Equal(t, 59.0, o.Total())
+	})
+
+	t.Run("should not tax a state absent from the policy", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		require.NoError(t, o.ApplyTaxPolicy(order.TaxPolicy{"RJ": 0.2}))
+
+		assert.Equal(t, 0.0, o.TaxAmount())
+		assert.Equal(t, 100.0, o.Total())
+	})
+
+	t.Run("should not follow later changes to the caller's policy", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		callers := order.TaxPolicy{"SP": 0.18}
+		require.NoError(t, o.ApplyTaxPolicy(callers))
+
+		callers["SP"] = 0.5
+		require.NoError(t, o.AddItem("prod-2", "Gadget", 100.0, 1))
+
+		assert.Equal(t, 0.18, o.TaxRate)
+		assert.Equal(t, 236.0, o.Total())
+	})
+
+	t.Run("should return an error when a rate is out of range", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.ApplyTaxPolicy(order.TaxPolicy{"SP": 1.0})
+
+		assert.ErrorIs(t, err, order.ErrInvalidTaxRate)
+		assert.Equal(t, 100.0, o.Total(), "Total should be unchanged")
+	})
+
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.ApplyTaxPolicy(policy)
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+	})
+}
+
+func TestOrder_SetItemTaxCategory(t *testing.T) {
+	policy := order.TaxPolicy{"SP": 0.2}
+
+	t.Run("should not tax an exempt item alongside standard ones", func(t *testing.T) {
+		o := createValidOrder(t)
+		require.NoError(t, o.AddItem("prod-1", "Widget", 50.0, 2))
+		require.NoError(t, o.AddItem("prod-2", "Book", 30.0, 1))
+		require.NoError(t, o.ApplyTaxPolicy(policy))
+		book := o.Items()[1]
+
+		err := o.SetItemTaxCategory(book.ID, orderitem.TaxCategoryExempt)
+
+		require.NoError(t, err)
+		assert.Equal(t, 20.0, o.TaxAmount(), "only the 100 of standard items should be taxed at 20%")
+		assert.Equal(t, 150.0, o.Total())
+		got, _ := o.FindItem(book.ID)
+		assert.Equal(t, orderitem.TaxCategoryExempt, got.TaxCategory)
+	})
+
+	t.Run("should tax a reduced item at its share of the rate", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.ApplyTaxPolicy(policy))
+
+		err := o.SetItemTaxCategory(o.Items()[0].ID, orderitem.TaxCategoryReduced)
+
+		require.NoError(t, err)
+		assert.Equal(t, 10.0, o.TaxAmount(), "tax should be 100 at half of 20%")
+	})
+
+	t.Run("should spread the coupon discount over the items by their totals", func(t *testing.T) {
+		o := createValidOrder(t)
+		require.NoError(t, o.AddItem("prod-1", "Widget", 50.0, 2))
+		require.NoError(t, o.AddItem("prod-2", "Book", 100.0, 1))
+		require.NoError(t, o.ApplyCoupon(*kernel.Must(promo.NewCoupon("OFF50", promo.DiscountTypeAbsolute, 50, 0))))
+		require.NoError(t, o.ApplyTaxPolicy(policy))
+
+		err := o.SetItemTaxCategory(o.Items()[1].ID, orderitem.TaxCategoryExempt)
+
+		require.NoError(t, err)
+		assert.Equal(t, 15.0, o.TaxAmount(), "the widgets carry 75 of the 150 base, taxed at 20%")
+		assert.Equal(t, 165.0, o.Total())
+	})
+
+	t.Run("should return an error for an undefined category", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.SetItemTaxCategory(o.Items()[0].ID, orderitem.TaxCategory{})
+
+		assert.ErrorIs(t, err, orderitem.ErrInvalidTaxCategory)
+	})
+
+	t.Run("should return an error when the item does not exist", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.SetItemTaxCategory("unknown-item-id", orderitem.TaxCategoryExempt)
+
+		assert.ErrorIs(t, err, order.ErrItemNotFound)
+	})
+}
+
+func TestOrder_DiscountTotal(t *testing.T) {
+	t.Run("should combine item discounts, the coupon discount and credits", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		item := o.Items()[0]
+		require.NoError(t, o.ApplyItemDiscount(item.ID, 20.0))
+		require.NoError(t, o.ApplyCoupon(*kernel.Must(promo.NewCoupon("OFF10", promo.DiscountTypeAbsolute, 10, 0))))
+		require.NoError(t, o.AddCredit(5.0, "goodwill"))
+		require.NoError(t, o.ApplyTaxPolicy(order.TaxPolicy{"SP": 0.1}))
+
+		got := o.DiscountTotal()
+
+		assert.InDelta(t, 35.0, got, 1e-9, "DiscountTotal should be 20 + 10 + 5 = 35")
+		b := o.Breakdown()
+		assert.Equal(t, got, b.DiscountTotal)
+		assert.InDelta(t, 100.0, b.Gross, 1e-9)
+		assert.InDelta(t, 6.5, b.Tax, 1e-9, "Tax should be 10% of 100 - 35 = 65")
+		assert.InDelta(t, b.Total, b.Gross-b.DiscountTotal+b.Tax, 1e-9, "Gross - DiscountTotal + Tax should equal Total")
+		assert.InDelta(t, 71.5, o.Total(), 1e-9)
+	})
+
+	t.Run("should be zero for an order without discounts", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		got := o.DiscountTotal()
+
+		assert.Equal(t, 0.0, got)
+	})
+}
+
+func TestOrder_Deduplicate(t *testing.T) {
+	// withRepeatedLine restores o with an extra line for "prod-1", as legacy data may hold.
+	withRepeatedLine := func(t *testing.T, o *order.Order, unitPrice float64, quantity int) *order.Order {
+		t.Helper()
+		snapshot := o.Snapshot()
+		snapshot.Items = append(snapshot.Items, *kernel.Must(orderitem.NewOrderItem("prod-1", "Widget", unitPrice, quantity)))
+		return kernel.Must(order.FromSnapshot(snapshot))
+	}
+
+	t.Run("should merge lines that repeat a product and recalculate the total", func(t *testing.T) {
+		o := withRepeatedLine(t, createOrderWithItems(t), 50.0, 3)
+
+		got := o.Deduplicate()
+
+		assert.Equal(t, 1, got)
+		require.Len(t, o.Items(), 1)
+		assert.Equal(t, 5, o.Items()[0].Quantity, "quantities should be summed")
+		assert.Equal(t, 250.0, o.Total())
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set when lines are merged")
+	})
+
+	t.Run("should keep the total a paid order was charged", func(t *testing.T) {
+		o := withRepeatedLine(t, driveOrderToPaid(t), 50.0, 3)
+		charged := o.TotalAmount
+
+		got := o.Deduplicate()
+
+		assert.Equal(t, 1, got)
+		assert.Len(t, o.Items(), 1)
+		assert.Equal(t, charged, o.TotalAmount)
+	})
+
+	t.Run("should keep a repeated line that cannot be merged", func(t *testing.T) {
+		o := withRepeatedLine(t, createOrderWithItems(t), 40.0, 1)
+		before := o.Items()
+
+		got := o.Deduplicate()
+
+		assert.Zero(t, got)
+		assert.Equal(t, before, o.Items(), "lines at differing unit prices should be left as they are")
+	})
+
+	t.Run("should report nothing for an order without repeated lines", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		updatedAt := o.UpdatedAt
+
+		got := o.Deduplicate()
+
+		assert.Zero(t, got)
+		assert.Len(t, o.Items(), 1)
+		assert.Equal(t, updatedAt, o.UpdatedAt)
+	})
+}
+
+func TestOrder_RecalculateFromScratch(t *testing.T) {
+	t.Run("should report a consistent total", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.ApplyTaxPolicy(order.TaxPolicy{"SP": 0.18}))
+
+		stored, recomputed, consistent := o.RecalculateFromScratch()
+
+		assert.True(t, consistent)
+		assert.Equal(t, 118.0, stored)
+		assert.Equal(t, stored, recomputed)
+	})
+
+	t.Run("should report drift of a stale stored total without fixing it", func(t *testing.T) {
+		snapshot := createOrderWithItems(t).Snapshot()
+		snapshot.TotalAmount = 90.0
+		o := kernel.Must(order.FromSnapshot(snapshot))
+
+		stored, recomputed, consistent := o.RecalculateFromScratch()
+
+		assert.False(t, consistent)
+		assert.Equal(t, 90.0, stored)
+		assert.Equal(t, 100.0, recomputed)
+		assert.Equal(t, 90.0, o.TotalAmount, "the stored total should be left untouched")
+	})
+
+	t.Run("should recompute line totals instead of trusting the stored ones", func(t *testing.T) {
+		snapshot := createOrderWithItems(t).Snapshot()
+		snapshot.Items[0].TotalPrice = 90.0
+		snapshot.TotalAmount = 90.0
+		o := kernel.Must(order.FromSnapshot(snapshot))
+
+		stored, recomputed, consistent := o.RecalculateFromScratch()
+
+		assert.False(t, consistent)
+		assert.Equal(t, 90.0, stored)
+		assert.Equal(t, 100.0, recomputed)
+		assert.Equal(t, 90.0, o.Items()[0].TotalPrice, "the stored line total should be left untouched")
+	})
+
+	t.Run("should tolerate differences below a cent", func(t *testing.T) {
+		snapshot := createOrderWithItems(t).Snapshot()
+		snapshot.TotalAmount = 100.004
+		o := kernel.Must(order.FromSnapshot(snapshot))
+
+		_, _, consistent := o.RecalculateFromScratch()
+
+		assert.True(t, consistent)
+	})
+}
+
+func TestOrder_StartPayment(t *testing.T) {
+	t.Run("should successfully start a payment and store it", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		p, err := o.StartPayment(payment.MethodCreditCard)
+
+		require.NoError(t, err)
+		require.NotNil(t, p)
+		assert.NotEmpty(t, p.ID)
+		assert.Equal(t, payment.StatusPending, p.Status, "payment status should be Pending")
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		p, err := o.StartPayment(payment.MethodCreditCard)
+
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+	})
+
+	t.Run("should return an error when order has no items", func(t *testing.T) {
+		o := createValidOrder(t)
+
+		p, err := o.StartPayment(payment.MethodCreditCard)
+
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, order.ErrNoItems)
+	})
+
+	t.Run("should return an error when order is a gift", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.MarkAsGift())
+
+		p, err := o.StartPayment(payment.MethodCreditCard)
+
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, order.ErrGiftOrderNotPayable)
+	})
+
+	t.Run("should return an error when a pending payment already exists", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		_, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+
+		p2, err := o.StartPayment(payment.MethodCreditCard)
+
+		assert.Nil(t, p2)
+		assert.ErrorIs(t, err, order.ErrPaymentAlreadyPending)
+	})
+}
+
+func TestOrder_DiscountExceedsTotal(t *testing.T) {
+	t.Run("should allow discounts stacking up to exactly the items total", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.AddCredit(80.0, "goodwill"))
+
+		err := o.ApplyCoupon(*kernel.Must(promo.NewCoupon("OFF20", promo.DiscountTypeAbsolute, 20, 0)))
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, o.Total())
+	})
+
+	t.Run("should reject a coupon that stacks above the credits", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.AddCredit(80.0, "goodwill"))
+
+		err := o.ApplyCoupon(*kernel.Must(promo.NewCoupon("OFF30", promo.DiscountTypeAbsolute, 30, 0)))
+
+		assert.ErrorIs(t, err, order.ErrDiscountExceedsTotal)
+		assert.Nil(t, o.Coupon)
+		assert.Equal(t, 20.0, o.Total())
+	})
+
+	t.Run("should reject removing units the discounts depend on", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.ApplyCoupon(*kernel.Must(promo.NewCoupon("HALF", promo.DiscountTypePercentage, 50, 0))))
+		require.NoError(t, o.AddCredit(40.0, "goodwill"))
+		item := o.Items()[0]
+
+		err := o.RemoveUnitsFromItem(item.ID, 1)
+
+		assert.ErrorIs(t, err, order.ErrDiscountExceedsTotal)
+		got, _ := o.FindItem(item.ID)
+		assert.Equal(t, 2, got.Quantity, "quantity should be unchanged")
+		assert.Equal(t, 10.0, o.Total())
+	})
+
+	t.Run("should reject a price drop the discounts depend on", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.AddCredit(90.0, "goodwill"))
+
+		err := o.UpdateProductPrice("prod-1", 40.0)
+
+		assert.ErrorIs(t, err, order.ErrDiscountExceedsTotal)
+		assert.Equal(t, 50.0, o.Items()[0].UnitPrice, "unit price should be unchanged")
+		assert.Equal(t, 10.0, o.Total())
+	})
+}
+
+func TestOrder_EditWithPendingPayment(t *testing.T) {
+	startPayment := func(t *testing.T) (*order.Order, *payment.Payment) {
+		t.Helper()
+		o := createOrderWithItems(t)
+		p, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+		return o, p
+	}
+
+	t.Run("should reject every edit while a payment is pending", func(t *testing.T) {
+		newAddr := kernel.Must(order.NewDeliveryAddress("98765-432", "Av. Brasil", "500", "", "Jardins", "Rio de Janeiro", "RJ", "Brasil"))
+		coupon := kernel.Must(promo.NewCoupon("OFF15", promo.DiscountTypeAbsolute, 15, 0))
+		tests := []struct {
+			name string
+			edit func(o *order.Order) error
+		}{
+			{name: "AddItem", edit: func(o *order.Order) error { return o.AddItem("prod-2", "Gadget", 10.0, 1) }},
+			{name: "RemoveItem", edit: func(o *order.Order) error {
+				return o.RemoveItem(kernel.Must(orderitem.NewOrderItem("prod-1", "Widget", 50.0, 2)))
+			}},
+			{name: "AddUnitsToItem", edit: func(o *order.Order) error { return o.AddUnitsToItem(o.Items()[0].ID, 1) }},
+			{name: "RemoveUnitsFromItem", edit: func(o *order.Order) error { return o.RemoveUnitsFromItem(o.Items()[0].ID, 1) }},
+			{name: "UpdateDeliveryAddress", edit: func(o *order.Order) error { return o.UpdateDeliveryAddress(*newAddr) }},
+			{name: "ApplyCoupon", edit: func(o *order.Order) error { return o.ApplyCoupon(*coupon) }},
+			{name: "AddCredit", edit: func(o *order.Order) error { return o.AddCredit(10.0, "goodwill") }},
+			{name: "UpdateProductPrice", edit: func(o *order.Order) error { return o.UpdateProductPrice("prod-1", 40.0) }},
+			{name: "ApplyTaxPolicy", edit: func(o *order.Order) error { return o.ApplyTaxPolicy(order.TaxPolicy{"SP": 0.18}) }},
+			{name: "SetItemTaxCategory", edit: func(o *order.Order) error {
+				return o.SetItemTaxCategory(o.Items()[0].ID, orderitem.TaxCategoryExempt)
+			}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				o, p := startPayment(t)
+
+				err := tt.edit(o)
+
+				assert.ErrorIs(t, err, order.ErrCannotEditPaidOrder)
+				assert.Equal(t, 100.0, o.Total(), "Total should be unchanged")
+				assert.Equal(t, o.Total(), p.Amount, "payment amount should still match the total")
+			})
+		}
+	})
+
+	t.Run("should allow edits again once the payment is refused", func(t *testing.T) {
+		o, p := startPayment(t)
+		require.NoError(t, p.DefineTransactionCode("TXN-1"))
+		require.NoError(t, p.RefusePayment())
+
+		err := o.AddItem("prod-2", "Gadget", 10.0, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, 110.0, o.Total())
+	})
+}
+
+func TestOrder_RetryPayment(t *testing.T) {
+	startPayment := func(t *testing.T, o *order.Order) *payment.Payment {
+		t.Helper()
+		p, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+		require.NoError(t, p.DefineTransactionCode("TXN-"+p.ID))
+		return p
+	}
+
+	t.Run("should start a fresh payment after the previous one was refused", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		refused := startPayment(t, o)
+		require.NoError(t, refused.RefusePayment())
+
+		got, err := o.RetryPayment(payment.MethodPix)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, refused.ID, got.ID, "a new payment should be created")
+		assert.Equal(t, payment.StatusPending, got.Status)
+		assert.Equal(t, payment.MethodPix, got.Method)
+		assert.Equal(t, payment.StatusRefused, refused.Status, "the refused payment should not be revived")
+		assert.Len(t, o.Payments(), 2)
+	})
+
+	t.Run("should start a fresh payment after the previous one was cancelled", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, startPayment(t, o).CancelPayment())
+
+		_, err := o.RetryPayment(payment.MethodPix)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should return an error when a payment is still active", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			setup func(p *payment.Payment) error
+		}{
+			{name: "pending payment", setup: func(*payment.Payment) error { return nil }},
+			{name: "authorized payment", setup: (*payment.Payment).ConfirmPayment},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				o := createOrderWithItems(t)
+				require.NoError(t, tt.setup(startPayment(t, o)))
+
+				got, err := o.RetryPayment(payment.MethodPix)
+
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, payment.ErrActivePaymentExists)
+				assert.Len(t, o.Payments(), 1, "no payment should be added")
+			})
+		}
+	})
+
+	t.Run("should return an error when there is no previous payment", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		got, err := o.RetryPayment(payment.MethodPix)
+
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, order.ErrNoPaymentToRetry)
+	})
+}
+
+func TestOrder_AddPayment(t *testing.T) {
+	newPayment := func(t *testing.T, o *order.Order) *payment.Payment {
+		t.Helper()
+		return kernel.Must(payment.NewPayment(o.ID, o.TotalAmount, payment.MethodPix))
+	}
+	refusedPayment := func(t *testing.T, o *order.Order) *payment.Payment {
+		t.Helper()
+		p := newPayment(t, o)
+		require.NoError(t, p.DefineTransactionCode("TXN-"+p.ID))
+		require.NoError(t, p.RefusePayment())
+		return p
+	}
+
+	t.Run("should attach a pending payment next to a refused one", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		refused := refusedPayment(t, o)
+		pending := newPayment(t, o)
+
+		require.NoError(t, o.AddPayment(refused))
+		err := o.AddPayment(pending)
+
+		require.NoError(t, err)
+		assert.Len(t, o.Payments(), 2)
+		active, ok := o.ActivePayment()
+		assert.True(t, ok)
+		assert.Equal(t, pending.ID, active.ID)
+		assert.NoError(t, o.Validate())
+	})
+
+	t.Run("should reject a second active payment", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.AddPayment(newPayment(t, o)))
+
+		err := o.AddPayment(newPayment(t, o))
+
+		assert.ErrorIs(t, err, order.ErrMultipleActivePayments)
+		assert.Len(t, o.Payments(), 1, "no payment should be added")
+		assert.NoError(t, o.Validate())
+	})
+
+	t.Run("should return an error for an invalid payment", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		attached := refusedPayment(t, o)
+		require.NoError(t, o.AddPayment(attached))
+
+		tests := []struct {
+			name    string
+			payment *payment.Payment
+		}{
+			{name: "nil payment", payment: nil},
+			{name: "payment of another order", payment: kernel.Must(payment.NewPayment("other-order", 100, payment.MethodPix))},
+			{name: "payment already attached", payment: attached},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := o.AddPayment(tt.payment)
+
+				assert.ErrorIs(t, err, order.ErrInvalidPayment)
+				assert.Len(t, o.Payments(), 1, "no payment should be added")
+			})
+		}
+	})
+
+	t.Run("should return an error when the order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.AddPayment(newPayment(t, o))
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+	})
+}
+
+func TestOrder_ActivePayment(t *testing.T) {
+	t.Run("should report no active payment once the only payment is refused", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		p, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+		require.NoError(t, p.DefineTransactionCode("TXN-1"))
+		require.NoError(t, p.RefusePayment())
+
+		got, ok := o.ActivePayment()
+
+		assert.False(t, ok)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return the started payment", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		p, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+
+		got, ok := o.ActivePayment()
+
+		assert.True(t, ok)
+		assert.Same(t, p, got)
+	})
+}
+
+func TestOrder_DefineTransactionCode(t *testing.T) {
+	t.Run("should assign the code to the identified payment", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		started, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+
+		p, err := o.DefineTransactionCode(started.ID, "TXN-1")
+
+		require.NoError(t, err)
+		code, ok := p.TransactionCodeValue()
+		assert.True(t, ok)
+		assert.Equal(t, "TXN-1", code)
+	})
+
+	t.Run("should reject a code already held by another payment of the order", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		first, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+		_, err = o.DefineTransactionCode(first.ID, "TXN-1")
+		require.NoError(t, err)
+		require.NoError(t, first.RefusePayment())
+		second, err := o.RetryPayment(payment.MethodPix)
+		require.NoError(t, err)
+
+		p, err := o.DefineTransactionCode(second.ID, "TXN-1")
+
+		assert.ErrorIs(t, err, payment.ErrDuplicateTransactionCode)
+		assert.Nil(t, p)
+		_, ok := second.TransactionCodeValue()
+		assert.False(t, ok, "second payment should have no code")
+	})
+
+	t.Run("should return an error when the payment does not belong to the order", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		p, err := o.DefineTransactionCode("missing", "TXN-1")
+
+		assert.ErrorIs(t, err, order.ErrPaymentNotFound)
+		assert.Nil(t, p)
+	})
+}
+
+func TestOrder_HandleApprovedPaymentEvent(t *testing.T) {
+	t.Run("should transition order to Paid when payment is approved", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		p, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+
+		err = o.HandleApprovedPaymentEvent(p.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusPaid, o.Status, "status should be Paid")
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+		events := pullLifecycleEvents(o)
+		require.Len(t, events, 1)
+		paid, ok := events[0].(*order.PaidEvent)
+		require.True(t, ok, "event should be a PaidEvent")
+		assert.Equal(t, p.ID, paid.PaymentID)
+	})
+
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.HandleApprovedPaymentEvent("any-id")
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+	})
+
+	t.Run("should return an error when the payment amount does not match the total", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		p := kernel.Must(payment.NewPayment(o.ID, o.TotalAmount-0.01, payment.MethodPix))
+		require.NoError(t, o.AddPayment(p))
+
+		err := o.HandleApprovedPaymentEvent(p.ID)
+
+		assert.ErrorIs(t, err, order.ErrPaymentAmountMismatch)
+		assert.Equal(t, order.StatusPending, o.Status, "status should remain Pending")
+		assert.Empty(t, pullLifecycleEvents(o))
+	})
+
+	t.Run("should be a no-op when paymentID is unknown", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.HandleApprovedPaymentEvent("unknown-payment-id")
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusPending, o.Status, "status should remain Pending")
+	})
+}
+
+func TestOrder_SetInstructions(t *testing.T) {
+	t.Run("should set the instructions of an editable order", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.SetInstructions("leave at the door")
+
+		require.NoError(t, err)
+		assert.Equal(t, "leave at the door", o.Instructions)
+	})
+
+	t.Run("should reject instructions on a shipped order", func(t *testing.T) {
+		o := driveOrderToShipped(t)
+
+		err := o.SetInstructions("leave at the door")
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+		assert.Empty(t, o.Instructions)
+	})
+
+	t.Run("should reject instructions longer than MaxInstructionsLength", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.SetInstructions(strings.Repeat("a", order.MaxInstructionsLength)))
+
+		err := o.SetInstructions(strings.Repeat("a", order.MaxInstructionsLength+1))
+
+		assert.ErrorIs(t, err, order.ErrInstructionsTooLong)
+		assert.Len(t, o.Instructions, order.MaxInstructionsLength, "the previous instructions should be kept")
+	})
+}
+
+func TestOrder_SetShippingMethod(t *testing.T) {
+	t.Run("should default to Standard on creation", func(t *testing.T) {
+		o := createValidOrder(t)
+
+		assert.Equal(t, order.ShippingMethodStandard, o.ShippingMethod)
+	})
+
+	t.Run("should set the shipping method on an editable order", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.SetShippingMethod(order.ShippingMethodExpress)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.ShippingMethodExpress, o.ShippingMethod)
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should return an error when the method is unknown", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.SetShippingMethod(order.ShippingMethod{})
+
+		assert.ErrorIs(t, err, order.ErrInvalidShippingMethod)
+		assert.Equal(t, order.ShippingMethodStandard, o.ShippingMethod, "method should be unchanged")
+	})
+
+	t.Run("should return an error when order is not editable", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			setup   func(t *testing.T) *order.Order
+			wantErr error
+		}{
+			{name: "status Paid", setup: driveOrderToPaid, wantErr: order.ErrOrderNotPending},
+			{name: "pending payment", setup: func(t *testing.T) *order.Order {
+				o := createOrderWithItems(t)
+				_, err := o.StartPayment(payment.MethodPix)
+				require.NoError(t, err)
+				return o
+			}, wantErr: order.ErrCannotEditPaidOrder},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				o := tt.setup(t)
+
+				err := o.SetShippingMethod(order.ShippingMethodExpress)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, order.ShippingMethodStandard, o.ShippingMethod, "method should be unchanged")
+			})
+		}
+	})
+}
+
+func TestOrder_MarkAsGift(t *testing.T) {
+	t.Run("should flag a pending order as a gift", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.MarkAsGift()
+
+		require.NoError(t, err)
+		assert.True(t, o.IsGift, "IsGift should be true")
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.MarkAsGift()
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+	})
+
+	t.Run("should return an error when a payment has already been started", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		_, err := o.StartPayment(payment.MethodPix)
+		require.NoError(t, err)
+
+		err = o.MarkAsGift()
+
+		assert.ErrorIs(t, err, order.ErrPaymentAlreadyPending)
+		assert.False(t, o.IsGift, "IsGift should remain false")
+	})
+}
+
+func TestOrder_MarkAsComped(t *testing.T) {
+	t.Run("should transition a gift order to Paid without a payment", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.MarkAsGift())
+
+		err := o.MarkAsComped()
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusPaid, o.Status, "status should be Paid")
+		events := pullLifecycleEvents(o)
+		require.Len(t, events, 1)
+		comped, ok := events[0].(*order.CompedEvent)
+		require.True(t, ok, "event should be a CompedEvent")
+		assert.Equal(t, o.ID, comped.OrderID)
+		assert.Equal(t, 100.0, comped.TotalAmount)
+	})
+
+	t.Run("should return an error when order is not a gift", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.MarkAsComped()
+
+		assert.ErrorIs(t, err, order.ErrOrderNotGift)
+		assert.Equal(t, order.StatusPending, o.Status, "status should remain Pending")
+	})
+
+	t.Run("should return an error when gift order has no items", func(t *testing.T) {
+		o := createValidOrder(t)
+		require.NoError(t, o.MarkAsGift())
+
+		err := o.MarkAsComped()
+
+		assert.ErrorIs(t, err, order.ErrNoItems)
+	})
+
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.MarkAsComped()
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+	})
+}
+
+func TestOrder_HandleRejectedPaymentEvent(t *testing.T) {
+	t.Run("should transition order to Cancelled when payment is rejected", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		p, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+
+		err = o.HandleRejectedPaymentEvent(p.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusCancelled, o.Status, "status should be Cancelled")
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.HandleRejectedPaymentEvent("any-id")
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+	})
+
+	t.Run("should be a no-op when paymentID is unknown", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.HandleRejectedPaymentEvent("unknown-payment-id")
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusPending, o.Status, "status should remain Pending")
+	})
+}
+
+func TestOrder_RecordReservation(t *testing.T) {
+	t.Run("should store the reservation ID of a paid order", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.RecordReservation("res-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "res-1", o.ReservationID)
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should return ErrInvalidReservationID for a blank ID", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.RecordReservation("  ")
+
+		assert.ErrorIs(t, err, order.ErrInvalidReservationID)
+		assert.Empty(t, o.ReservationID)
+	})
+
+	t.Run("should return ErrOrderNotPaid when order is not Paid", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.RecordReservation("res-1")
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPaid)
+		assert.Empty(t, o.ReservationID)
+	})
+}
+
+func TestOrder_ReservationLines(t *testing.T) {
+	o := createOrderWithItems(t)
+	require.NoError(t, o.AddItem("prod-2", "Gadget", 10.0, 3))
+
+	got := o.ReservationLines()
+
+	assert.Equal(t, []order.ReservationLine{
+		{ProductID: "prod-1", Quantity: 2},
+		{ProductID: "prod-2", Quantity: 3},
+	}, got)
+}
+
+func TestOrder_MarkAsSeparating(t *testing.T) {
+	t.Run("should transition order from Paid to Separating", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.MarkAsSeparating()
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusSeparating, o.Status, "status should be Separating")
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should return an error when order is not Paid", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			setup func(t *testing.T) *order.Order
+		}{
+			{name: "status Pending", setup: createValidOrder},
+			{name: "status Separating", setup: driveOrderToSeparating},
+			{name: "status Shipped", setup: driveOrderToShipped},
+			{name: "status Delivered", setup: driveOrderToDelivered},
+			{
+				name: "status Cancelled",
+				setup: func(t *testing.T) *order.Order {
+					o := driveOrderToShipped(t)
+					require.NoError(t, o.Cancel(order.CancellationReasonCustomerCancelled))
+					return o
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				o := tt.setup(t)
+
+				err := o.MarkAsSeparating()
+
+				assert.ErrorIs(t, err, order.ErrOrderNotPaid)
+			})
+		}
+	})
+}
+
+func TestOrder_CheckShippable(t *testing.T) {
+	t.Run("should accept a separating order", func(t *testing.T) {
+		o := driveOrderToSeparating(t)
+
+		err := o.CheckShippable()
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return the error Ship would return", func(t *testing.T) {
+		held := driveOrderToSeparating(t)
+		require.NoError(t, held.Hold("address check"))
+		tests := []struct {
+			name    string
+			order   *order.Order
+			wantErr error
+		}{
+			{name: "status Pending", order: createValidOrder(t), wantErr: order.ErrOrderNotSeparating},
+			{name: "status Shipped", order: driveOrderToShipped(t), wantErr: order.ErrOrderNotSeparating},
+			{name: "on hold", order: held, wantErr: order.ErrOrderOnHold},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := tt.order.CheckShippable()
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorIs(t, tt.order.Ship("BR0001"), tt.wantErr)
+			})
+		}
+	})
+}
+
+func TestOrder_MarkAsShipped(t *testing.T) {
+	t.Run("should transition order from Separating to Shipped", func(t *testing.T) {
+		o := driveOrderToSeparating(t)
+
+		err := o.MarkAsShipped()
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusShipped, o.Status, "status should be Shipped")
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should consume the stock reservation", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+		require.NoError(t, o.RecordReservation("res-1"))
+		require.NoError(t, o.MarkAsSeparating())
+
+		err := o.MarkAsShipped()
+
+		require.NoError(t, err)
+		assert.Empty(t, o.ReservationID, "the reserved units leave with the shipment")
+	})
+
+	t.Run("should return an error when order is not Separating", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			setup func(t *testing.T) *order.Order
+		}{
+			{name: "status Pending", setup: createValidOrder},
+			{name: "status Paid", setup: driveOrderToPaid},
+			{name: "status Shipped", setup: driveOrderToShipped},
+			{name: "status Delivered", setup: driveOrderToDelivered},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				o := tt.setup(t)
+
+				err := o.MarkAsShipped()
+
+				assert.ErrorIs(t, err, order.ErrOrderNotSeparating)
+			})
+		}
+	})
+}
+
+func TestOrder_Ship(t *testing.T) {
+	t.Run("should ship the order and record the trimmed tracking code", func(t *testing.T) {
+		o := driveOrderToSeparating(t)
+		o.PullDomainEvents()
+
+		err := o.Ship("  BR123456789  ")
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusShipped, o.Status, "status should be Shipped")
+		assert.Equal(t, "BR123456789", o.TrackingCode)
+		events := pullLifecycleEvents(o)
+		require.Len(t, events, 1)
+		shipped, ok := events[0].(*order.ShippedEvent)
+		require.True(t, ok, "event should be a ShippedEvent")
+		assert.Equal(t, "BR123456789", shipped.TrackingCode)
+	})
+
+	t.Run("should return ErrInvalidTrackingCode for a blank tracking code", func(t *testing.T) {
+		o := driveOrderToSeparating(t)
+
+		err := o.Ship("   ")
+
+		assert.ErrorIs(t, err, order.ErrInvalidTrackingCode)
+		assert.Equal(t, order.StatusSeparating, o.Status, "status should be unchanged")
+	})
+
+	t.Run("should return ErrOrderNotSeparating when order is not Separating", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.Ship("BR123456789")
+
+		assert.ErrorIs(t, err, order.ErrOrderNotSeparating)
+		assert.Empty(t, o.TrackingCode)
+	})
+}
+
+func TestOrder_MarkAsDelivered(t *testing.T) {
+	t.Run("should transition order from Shipped to Delivered", func(t *testing.T) {
+		o := driveOrderToShipped(t)
+
+		err := o.MarkAsDelivered()
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusDelivered, o.Status, "status should be Delivered")
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should record DeliveredAt from the clock", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		o := driveOrderToShipped(t)
+		clock.Advance(48 * time.Hour)
+
+		require.NoError(t, o.MarkAsDelivered())
+
+		got, ok := o.DeliveredAtValue()
+		assert.True(t, ok)
+		assert.Equal(t, testfixtures.Epoch.Add(48*time.Hour), got)
+	})
+
+	t.Run("should leave DeliveredAt unset before delivery", func(t *testing.T) {
+		o := driveOrderToShipped(t)
+
+		got, ok := o.DeliveredAtValue()
+
+		assert.False(t, ok)
+		assert.True(t, got.IsZero())
+		assert.Nil(t, o.DeliveredAt)
+	})
+
+	t.Run("should return an error when order is not Shipped", func(t *testing.T) {
 		tests := []struct {
 			name  string
 			setup func(t *testing.T) *order.Order
@@ -481,6 +1802,195 @@ func TestOrder_Cancel(t *testing.T) {
 			{name: "status Pending", setup: createValidOrder},
 			{name: "status Paid", setup: driveOrderToPaid},
 			{name: "status Separating", setup: driveOrderToSeparating},
+			{name: "status Delivered", setup: driveOrderToDelivered},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				o := tt.setup(t)
+
+				err := o.MarkAsDelivered()
+
+				assert.ErrorIs(t, err, order.ErrOrderNotShipped)
+			})
+		}
+	})
+}
+
+func TestOrder_RequestReturn(t *testing.T) {
+	t.Run("should record the request and raise a ReturnRequestedEvent within the window", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		o := driveOrderToDelivered(t)
+		o.PullDomainEvents()
+		itemID := o.Items()[0].ID
+		clock.Advance(order.ReturnWindow())
+
+		err := o.RequestReturn([]string{itemID}, order.CancellationReasonOther)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusDelivered, o.Status, "status should remain Delivered")
+		require.NotNil(t, o.ReturnRequest)
+		assert.Equal(t, []string{itemID}, o.ReturnRequest.ItemIDs)
+		assert.Equal(t, order.CancellationReasonOther, o.ReturnRequest.Reason)
+		assert.Equal(t, kernel.Now(), o.ReturnRequest.RequestedAt)
+		events := pullLifecycleEvents(o)
+		require.Len(t, events, 1)
+		requested, ok := events[0].(*order.ReturnRequestedEvent)
+		require.True(t, ok, "event should be a ReturnRequestedEvent")
+		assert.Equal(t, o.ID, requested.OrderID)
+		assert.Equal(t, []string{itemID}, requested.ItemIDs)
+	})
+
+	t.Run("should return an error when the return window has expired", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		o := driveOrderToDelivered(t)
+		clock.Advance(order.ReturnWindow() + time.Second)
+
+		err := o.RequestReturn([]string{o.Items()[0].ID}, order.CancellationReasonOther)
+
+		assert.ErrorIs(t, err, order.ErrReturnWindowExpired)
+		assert.Nil(t, o.ReturnRequest)
+	})
+
+	t.Run("should return an error when order is not delivered", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			setup func(t *testing.T) *order.Order
+		}{
+			{name: "status Pending", setup: createOrderWithItems},
+			{name: "status Paid", setup: driveOrderToPaid},
+			{name: "status Shipped", setup: driveOrderToShipped},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				o := tt.setup(t)
+
+				err := o.RequestReturn([]string{o.Items()[0].ID}, order.CancellationReasonOther)
+
+				assert.ErrorIs(t, err, order.ErrInvalidStatusTransition)
+			})
+		}
+	})
+
+	t.Run("should return an error when no items are given", func(t *testing.T) {
+		o := driveOrderToDelivered(t)
+
+		err := o.RequestReturn(nil, order.CancellationReasonOther)
+
+		assert.ErrorIs(t, err, order.ErrNoReturnItems)
+	})
+
+	t.Run("should return an error when an item is not in the order", func(t *testing.T) {
+		o := driveOrderToDelivered(t)
+
+		err := o.RequestReturn([]string{"unknown-item"}, order.CancellationReasonOther)
+
+		assert.ErrorIs(t, err, order.ErrItemNotFound)
+	})
+
+	t.Run("should return an error when a return was already requested", func(t *testing.T) {
+		o := driveOrderToDelivered(t)
+		itemID := o.Items()[0].ID
+		require.NoError(t, o.RequestReturn([]string{itemID}, order.CancellationReasonOther))
+
+		err := o.RequestReturn([]string{itemID}, order.CancellationReasonOther)
+
+		assert.ErrorIs(t, err, order.ErrReturnAlreadyRequested)
+	})
+}
+
+func TestOrder_Expire(t *testing.T) {
+	t.Run("should cancel an order past its TTL with reason PaymentTimeout", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		o := createOrderWithItems(t)
+		clock.Advance(order.UnpaidOrderTTL() + time.Second)
+
+		err := o.Expire(kernel.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusCancelled, o.Status, "status should be Cancelled")
+		events := pullLifecycleEvents(o)
+		require.Len(t, events, 1)
+		cancelled, ok := events[0].(*order.CancelledEvent)
+		require.True(t, ok, "event should be a CancelledEvent")
+		assert.Equal(t, order.CancellationReasonPaymentTimeout, cancelled.CancellationReason)
+	})
+
+	t.Run("should return an error when the order is still within its TTL", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		o := createOrderWithItems(t)
+		clock.Advance(order.UnpaidOrderTTL())
+		o.PullDomainEvents()
+
+		err := o.Expire(kernel.Now())
+
+		assert.ErrorIs(t, err, order.ErrOrderNotExpired)
+		assert.Equal(t, order.StatusPending, o.Status, "status should remain Pending")
+		assert.Empty(t, o.PullDomainEvents())
+	})
+
+	t.Run("should return an error when order is not pending", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		o := driveOrderToPaid(t)
+		clock.Advance(order.UnpaidOrderTTL() + time.Second)
+
+		err := o.Expire(kernel.Now())
+
+		assert.ErrorIs(t, err, order.ErrOrderNotPending)
+	})
+}
+
+func TestOrder_Cancel(t *testing.T) {
+	t.Run("should successfully cancel from Shipped", func(t *testing.T) {
+		o := driveOrderToShipped(t)
+
+		err := o.Cancel(order.CancellationReasonCustomerCancelled)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusCancelled, o.Status, "status should be Cancelled")
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should cancel a reserved order from Paid or Separating and clear its reservation", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			separating bool
+		}{
+			{name: "status Paid"},
+			{name: "status Separating", separating: true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				o := driveOrderToPaid(t)
+				require.NoError(t, o.RecordReservation("res-1"))
+				if tt.separating {
+					require.NoError(t, o.MarkAsSeparating())
+				}
+
+				err := o.Cancel(order.CancellationReasonCustomerCancelled)
+
+				require.NoError(t, err)
+				assert.Equal(t, order.StatusCancelled, o.Status)
+				assert.Empty(t, o.ReservationID, "the caller releases the reservation")
+			})
+		}
+	})
+
+	t.Run("should successfully cancel from Delivered", func(t *testing.T) {
+		o := driveOrderToDelivered(t)
+
+		err := o.Cancel(order.CancellationReasonCustomerCancelled)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusCancelled, o.Status, "status should be Cancelled")
+		assert.NotNil(t, o.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should return an error when order cannot be cancelled", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			setup func(t *testing.T) *order.Order
+		}{
+			{name: "status Pending", setup: createValidOrder},
 			{
 				name: "status Cancelled",
 				setup: func(t *testing.T) *order.Order {
@@ -501,3 +2011,209 @@ func TestOrder_Cancel(t *testing.T) {
 		}
 	})
 }
+
+func TestOrder_Hold(t *testing.T) {
+	t.Run("should flag the order as held with its reason", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+
+		err := o.Hold("fraud review")
+
+		require.NoError(t, err)
+		assert.True(t, o.OnHold)
+		assert.Equal(t, "fraud review", o.HoldReason)
+		assert.Equal(t, order.StatusPaid, o.Status, "status should be unchanged")
+	})
+
+	t.Run("should block shipping while held", func(t *testing.T) {
+		o := driveOrderToSeparating(t)
+		require.NoError(t, o.Hold("fraud review"))
+
+		err := o.MarkAsShipped()
+
+		assert.ErrorIs(t, err, order.ErrOrderOnHold)
+		assert.Equal(t, order.StatusSeparating, o.Status, "status should remain Separating")
+	})
+
+	t.Run("should block the payment approval while held", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		p, err := o.StartPayment(payment.MethodCreditCard)
+		require.NoError(t, err)
+		require.NoError(t, o.Hold("fraud review"))
+
+		err = o.HandleApprovedPaymentEvent(p.ID)
+
+		assert.ErrorIs(t, err, order.ErrOrderOnHold)
+		assert.Equal(t, order.StatusPending, o.Status, "status should remain Pending")
+	})
+
+	t.Run("should return an error when the reason is blank", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.Hold("  ")
+
+		assert.ErrorIs(t, err, order.ErrInvalidHoldReason)
+		assert.False(t, o.OnHold)
+	})
+
+	t.Run("should return an error when the order is already held", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.Hold("fraud review"))
+
+		err := o.Hold("second look")
+
+		assert.ErrorIs(t, err, order.ErrOrderOnHold)
+		assert.Equal(t, "fraud review", o.HoldReason, "reason should be unchanged")
+	})
+
+	t.Run("should return an error when the order is delivered", func(t *testing.T) {
+		o := driveOrderToDelivered(t)
+
+		err := o.Hold("fraud review")
+
+		assert.ErrorIs(t, err, order.ErrInvalidStatusTransition)
+		assert.False(t, o.OnHold)
+	})
+}
+
+func TestOrder_Release(t *testing.T) {
+	t.Run("should clear the hold and re-enable transitions", func(t *testing.T) {
+		o := driveOrderToSeparating(t)
+		require.NoError(t, o.Hold("fraud review"))
+
+		err := o.Release()
+
+		require.NoError(t, err)
+		assert.False(t, o.OnHold)
+		assert.Empty(t, o.HoldReason)
+		require.NoError(t, o.MarkAsShipped())
+		assert.Equal(t, order.StatusShipped, o.Status)
+	})
+
+	t.Run("should return an error when the order is not held", func(t *testing.T) {
+		o := createOrderWithItems(t)
+
+		err := o.Release()
+
+		assert.ErrorIs(t, err, order.ErrOrderNotOnHold)
+	})
+}
+
+func TestOrder_NilReceiver(t *testing.T) {
+	var o *order.Order
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "AddItem", call: func() error { return o.AddItem("prod-1", "Widget", 50.0, 2) }},
+		{name: "AddUnitsToItem", call: func() error { return o.AddUnitsToItem("item-1", 1) }},
+		{name: "StartPayment", call: func() error { _, err := o.StartPayment(payment.MethodPix); return err }},
+		{name: "MarkAsShipped", call: o.MarkAsShipped},
+		{name: "Cancel", call: func() error { return o.Cancel(order.CancellationReasonOther) }},
+		{name: "Hold", call: func() error { return o.Hold("fraud review") }},
+		{name: "Release", call: o.Release},
+		{name: "DefineTransactionCode", call: func() error { _, err := o.DefineTransactionCode("pay-1", "TXN-1"); return err }},
+	}
+	for _, tt := range tests {
+		t.Run("should return ErrNilAggregate from "+tt.name+" instead of panicking", func(t *testing.T) {
+			var err error
+			require.NotPanics(t, func() { err = tt.call() })
+
+			assert.ErrorIs(t, err, kernel.ErrNilAggregate)
+		})
+	}
+}
+
+func TestOrder_Summary(t *testing.T) {
+	t.Run("should project the order's identity, status, line count and total", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.AddItem("prod-2", "Gadget", 10.0, 3))
+
+		summary := o.Summary()
+
+		assert.Equal(t, o.ID, summary.OrderID)
+		assert.Equal(t, o.Number, summary.Number)
+		assert.Equal(t, "cust-123", summary.CustomerID)
+		assert.Equal(t, order.StatusPending, summary.Status)
+		assert.Equal(t, 2, summary.ItemCount)
+		assert.Equal(t, 130.0, summary.TotalAmount)
+		assert.Equal(t, o.UpdatedAt, summary.UpdatedAt)
+	})
+}
+
+func TestOrder_ChangedEvent(t *testing.T) {
+	lastChanged := func(t *testing.T, events []kernel.DomainEvent) *order.ChangedEvent {
+		t.Helper()
+		var changed *order.ChangedEvent
+		for _, e := range events {
+			if c, ok := e.(*order.ChangedEvent); ok {
+				changed = c
+			}
+		}
+		require.NotNil(t, changed, "a ChangedEvent should be raised")
+		return changed
+	}
+
+	t.Run("should raise a ChangedEvent carrying the new Summary after AddItem", func(t *testing.T) {
+		o := createValidOrder(t)
+		o.PullDomainEvents()
+
+		require.NoError(t, o.AddItem("prod-1", "Widget", 50.0, 2))
+
+		changed := lastChanged(t, o.PullDomainEvents())
+		assert.Equal(t, o.Summary(), changed.Summary)
+		assert.Equal(t, 1, changed.Summary.ItemCount)
+		assert.Equal(t, 100.0, changed.Summary.TotalAmount)
+	})
+
+	t.Run("should raise a ChangedEvent carrying the new status after a transition", func(t *testing.T) {
+		o := driveOrderToPaid(t)
+		o.PullDomainEvents()
+
+		require.NoError(t, o.MarkAsSeparating())
+
+		changed := lastChanged(t, o.PullDomainEvents())
+		assert.Equal(t, order.StatusSeparating, changed.Summary.Status)
+	})
+
+	t.Run("should not raise a ChangedEvent when the command fails", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		o.PullDomainEvents()
+
+		err := o.MarkAsShipped()
+
+		assert.ErrorIs(t, err, order.ErrOrderNotSeparating)
+		assert.Empty(t, o.PullDomainEvents())
+	})
+}
+
+func TestOrder_PullDomainEvents(t *testing.T) {
+	t.Run("should buffer lifecycle events in order and drain them on pull", func(t *testing.T) {
+		o := driveOrderToDelivered(t)
+
+		events := pullLifecycleEvents(o)
+
+		require.Len(t, events, 3)
+		paid, ok := events[0].(*order.PaidEvent)
+		require.True(t, ok, "first event should be a PaidEvent")
+		assert.Equal(t, o.ID, paid.OrderID)
+		shipped, ok := events[1].(*order.ShippedEvent)
+		require.True(t, ok, "second event should be a ShippedEvent")
+		assert.Equal(t, o.ID, shipped.OrderID)
+		delivered, ok := events[2].(*order.DeliveredEvent)
+		require.True(t, ok, "third event should be a DeliveredEvent")
+		assert.Equal(t, o.ID, delivered.OrderID)
+		assert.Empty(t, o.PullDomainEvents(), "buffer should be empty after pull")
+	})
+
+	t.Run("should return only the ChangedEvent for an order without transitions", func(t *testing.T) {
+		o := createValidOrder(t)
+
+		events := o.PullDomainEvents()
+
+		require.Len(t, events, 1)
+		changed, ok := events[0].(*order.ChangedEvent)
+		require.True(t, ok, "event should be a ChangedEvent")
+		assert.Equal(t, o.Summary(), changed.Summary)
+	})
+}
@@ -0,0 +1,94 @@
+package orderitem
+
+import (
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrInvalidDiscountMode = errs.New("ORDER_ITEM.INVALID_DISCOUNT_MODE", "invalid discount mode")
+
+// DiscountMode selects how [OrderItem.DiscountApplied] is read when computing TotalPrice:
+// as an amount off the whole line or off each unit.
+type DiscountMode struct {
+	value int
+}
+
+var (
+	// DiscountModePerLine subtracts DiscountApplied once from UnitPrice × Quantity.
+	DiscountModePerLine = DiscountMode{1}
+	// DiscountModePerUnit subtracts DiscountApplied from every unit, i.e.
+	// (UnitPrice − DiscountApplied) × Quantity.
+	DiscountModePerUnit = DiscountMode{2}
+)
+
+var discountModeToString = map[DiscountMode]string{
+	DiscountModePerLine: "per_line",
+	DiscountModePerUnit: "per_unit",
+}
+
+// String returns the string representation of the DiscountMode.
+func (m DiscountMode) String() string {
+	if str, ok := discountModeToString[m]; ok {
+		return str
+	}
+	return "unknown"
+}
+
+// MarshalText provides support for logging and any marshal needs.
+func (m DiscountMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidDiscountMode] and leave m unchanged instead of decoding to an uninitialized DiscountMode.
+func (m *DiscountMode) UnmarshalText(text []byte) error {
+	for v, str := range discountModeToString {
+		if str == string(text) {
+			*m = v
+			return nil
+		}
+	}
+	return ErrInvalidDiscountMode
+}
+
+// Equals checks if two DiscountMode values are equal.
+func (m DiscountMode) Equals(other DiscountMode) bool {
+	return m.value == other.value
+}
+
+// IsValid reports whether the DiscountMode is one of the defined values; the zero value is not.
+func (m DiscountMode) IsValid() bool {
+	_, ok := discountModeToString[m]
+	return ok
+}
+
+// ParseDiscountMode converts an int to the corresponding DiscountMode value.
+// If the input does not match any known discount mode, it returns an error and an empty DiscountMode value.
+func ParseDiscountMode(value int) (DiscountMode, error) {
+	m := DiscountMode{value: value}
+	if _, ok := discountModeToString[m]; !ok {
+		return DiscountMode{}, ErrInvalidDiscountMode
+	}
+	return m, nil
+}
+
+// DefaultDiscountMode is the discount convention used unless overridden with
+// [SetDiscountMode]. It is per line, so a discount of 5 on two units of 50 totals 95.
+var DefaultDiscountMode = DiscountModePerLine
+
+var discountMode = kernel.NewSetting(DefaultDiscountMode)
+
+// CurrentDiscountMode returns the configured discount convention.
+func CurrentDiscountMode() DiscountMode {
+	return discountMode.Load()
+}
+
+// SetDiscountMode configures the discount convention followed by every [OrderItem].
+// Items already built keep their TotalPrice until their next change.
+func SetDiscountMode(m DiscountMode) error {
+	if _, ok := discountModeToString[m]; !ok {
+		return ErrInvalidDiscountMode
+	}
+	discountMode.Store(m)
+	return nil
+}
@@ -0,0 +1,191 @@
+package orderitem_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscountMode_String(t *testing.T) {
+	// ==================== Success cases ==================== //
+	tests := []struct {
+		name string
+		mode orderitem.DiscountMode
+		want string
+	}{
+		{name: "should return 'per_line' for DiscountModePerLine", mode: orderitem.DiscountModePerLine, want: "per_line"},
+		{name: "should return 'per_unit' for DiscountModePerUnit", mode: orderitem.DiscountModePerUnit, want: "per_unit"},
+		// ==================== Failure cases ==================== //
+		{name: "should return 'unknown' for an unrecognized mode value", mode: orderitem.DiscountMode{}, want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.mode.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiscountMode_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		mode orderitem.DiscountMode
+		want string
+	}{
+		// ==================== Success cases ==================== //
+		{name: "should marshal DiscountModePerUnit to 'per_unit'", mode: orderitem.DiscountModePerUnit, want: "per_unit"},
+		// ==================== Failure cases ==================== //
+		{name: "should marshal unknown mode to 'unknown'", mode: orderitem.DiscountMode{}, want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.mode.MarshalText()
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestDiscountMode_UnmarshalText(t *testing.T) {
+	// ==================== Success cases ==================== //
+	successTests := []struct {
+		name string
+		text string
+		want orderitem.DiscountMode
+	}{
+		{name: "should unmarshal 'per_unit' to DiscountModePerUnit", text: "per_unit", want: orderitem.DiscountModePerUnit},
+		{name: "should unmarshal 'per_line' to DiscountModePerLine", text: "per_line", want: orderitem.DiscountModePerLine},
+	}
+	for _, tt := range successTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got orderitem.DiscountMode
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	failureTests := []struct {
+		name string
+		text string
+	}{
+		{name: "should return an error for a misspelled discount mode", text: "per_unit_typo"},
+		{name: "should return an error for the 'unknown' placeholder", text: "unknown"},
+		{name: "should return an error for an empty string", text: ""},
+	}
+	for _, tt := range failureTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := orderitem.DiscountModePerLine
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			assert.ErrorIs(t, err, orderitem.ErrInvalidDiscountMode)
+			assert.Equal(t, orderitem.DiscountModePerLine, got, "value should be unchanged")
+		})
+	}
+}
+
+func TestDiscountMode_Equals(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  orderitem.DiscountMode
+		other orderitem.DiscountMode
+		want  bool
+	}{
+		// ==================== Success cases ==================== //
+		{name: "should return true when both modes are the same", mode: orderitem.DiscountModePerUnit, other: orderitem.DiscountModePerUnit, want: true},
+		// ==================== Failure cases ==================== //
+		{name: "should return false when modes are different", mode: orderitem.DiscountModePerLine, other: orderitem.DiscountModePerUnit, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.mode.Equals(tt.other)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseDiscountMode(t *testing.T) {
+	// ==================== Success cases ==================== //
+	t.Run("should parse 2 to DiscountModePerUnit", func(t *testing.T) {
+		got, err := orderitem.ParseDiscountMode(2)
+
+		require.NoError(t, err)
+		assert.Equal(t, orderitem.DiscountModePerUnit, got)
+	})
+
+	// ==================== Failure cases ==================== //
+	t.Run("should return an error for an out-of-range value", func(t *testing.T) {
+		got, err := orderitem.ParseDiscountMode(999)
+
+		assert.ErrorIs(t, err, orderitem.ErrInvalidDiscountMode)
+		assert.Equal(t, orderitem.DiscountMode{}, got)
+	})
+}
+
+func TestSetDiscountMode(t *testing.T) {
+	t.Cleanup(func() { _ = orderitem.SetDiscountMode(orderitem.DefaultDiscountMode) })
+
+	t.Run("should default to per-line discounts", func(t *testing.T) {
+		assert.Equal(t, orderitem.DiscountModePerLine, orderitem.CurrentDiscountMode())
+	})
+
+	t.Run("should return an error and keep the current mode when the mode is unknown", func(t *testing.T) {
+		err := orderitem.SetDiscountMode(orderitem.DiscountMode{})
+
+		assert.ErrorIs(t, err, orderitem.ErrInvalidDiscountMode)
+		assert.Equal(t, orderitem.DiscountModePerLine, orderitem.CurrentDiscountMode())
+	})
+}
+
+func TestOrderItem_ApplyDiscount_DiscountMode(t *testing.T) {
+	t.Cleanup(func() { _ = orderitem.SetDiscountMode(orderitem.DefaultDiscountMode) })
+
+	tests := []struct {
+		name           string
+		mode           orderitem.DiscountMode
+		wantTotalPrice float64
+	}{
+		{name: "should subtract the discount once from the line under per-line mode", mode: orderitem.DiscountModePerLine, wantTotalPrice: 95.0}, // 50 * 2 - 5
+		{name: "should subtract the discount from every unit under per-unit mode", mode: orderitem.DiscountModePerUnit, wantTotalPrice: 90.0},    // (50 - 5) * 2
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, orderitem.SetDiscountMode(tt.mode))
+			oi := createValidOrderItem(t, 50.0, 2)
+
+			err := oi.ApplyDiscount(5.0)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotalPrice, oi.TotalPrice)
+		})
+	}
+
+	t.Run("should cap the discount at the unit price under per-unit mode", func(t *testing.T) {
+		require.NoError(t, orderitem.SetDiscountMode(orderitem.DiscountModePerUnit))
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.ApplyDiscount(11.0)
+
+		assert.ErrorIs(t, err, orderitem.ErrDiscountExceedsUnitPrice)
+		assert.Equal(t, 20.0, oi.TotalPrice, "TotalPrice should not change on error")
+	})
+
+	t.Run("should accept a discount above the unit price within the subtotal under per-line mode", func(t *testing.T) {
+		require.NoError(t, orderitem.SetDiscountMode(orderitem.DiscountModePerLine))
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.ApplyDiscount(15.0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5.0, oi.TotalPrice)
+	})
+}
@@ -0,0 +1,72 @@
+package orderitem
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+
+var ErrInvalidDiscountSource = errs.New("ORDER_ITEM.INVALID_DISCOUNT_SOURCE", "invalid discount source")
+
+// DiscountSource records where [OrderItem.DiscountApplied] came from, so invoices and
+// audits can explain why a line was discounted.
+type DiscountSource struct {
+	value int
+}
+
+var (
+	// DiscountSourceManual is a discount set by hand, e.g. by an operator.
+	DiscountSourceManual = DiscountSource{1}
+	// DiscountSourceCoupon is a discount granted by a coupon.
+	DiscountSourceCoupon = DiscountSource{2}
+	// DiscountSourceTier is a discount granted by a quantity tier, see [OrderItem.ApplyTieredDiscount].
+	DiscountSourceTier = DiscountSource{3}
+)
+
+var discountSourceToString = map[DiscountSource]string{
+	DiscountSourceManual: "manual",
+	DiscountSourceCoupon: "coupon",
+	DiscountSourceTier:   "tier",
+}
+
+// String returns the string representation of the DiscountSource.
+func (s DiscountSource) String() string {
+	if str, ok := discountSourceToString[s]; ok {
+		return str
+	}
+	return "unknown"
+}
+
+// MarshalText provides support for logging and any marshal needs.
+func (s DiscountSource) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidDiscountSource] and leave s unchanged instead of decoding to an uninitialized DiscountSource.
+func (s *DiscountSource) UnmarshalText(text []byte) error {
+	for v, str := range discountSourceToString {
+		if str == string(text) {
+			*s = v
+			return nil
+		}
+	}
+	return ErrInvalidDiscountSource
+}
+
+// Equals checks if two DiscountSource values are equal.
+func (s DiscountSource) Equals(other DiscountSource) bool {
+	return s.value == other.value
+}
+
+// IsValid reports whether the DiscountSource is one of the defined values; the zero value is not.
+func (s DiscountSource) IsValid() bool {
+	_, ok := discountSourceToString[s]
+	return ok
+}
+
+// ParseDiscountSource converts an int to the corresponding DiscountSource value.
+// If the input does not match any known discount source, it returns an error and an empty DiscountSource value.
+func ParseDiscountSource(value int) (DiscountSource, error) {
+	s := DiscountSource{value: value}
+	if _, ok := discountSourceToString[s]; !ok {
+		return DiscountSource{}, ErrInvalidDiscountSource
+	}
+	return s, nil
+}
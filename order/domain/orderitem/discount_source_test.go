@@ -0,0 +1,163 @@
+package orderitem_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscountSource_String(t *testing.T) {
+	// ==================== Success cases ==================== //
+	tests := []struct {
+		name   string
+		source orderitem.DiscountSource
+		want   string
+	}{
+		{name: "should return 'manual' for DiscountSourceManual", source: orderitem.DiscountSourceManual, want: "manual"},
+		{name: "should return 'coupon' for DiscountSourceCoupon", source: orderitem.DiscountSourceCoupon, want: "coupon"},
+		{name: "should return 'tier' for DiscountSourceTier", source: orderitem.DiscountSourceTier, want: "tier"},
+		// ==================== Failure cases ==================== //
+		{name: "should return 'unknown' for an unrecognized source value", source: orderitem.DiscountSource{}, want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.source.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiscountSource_UnmarshalText(t *testing.T) {
+	// ==================== Success cases ==================== //
+	t.Run("should unmarshal 'coupon' to DiscountSourceCoupon", func(t *testing.T) {
+		var got orderitem.DiscountSource
+
+		err := got.UnmarshalText([]byte("coupon"))
+
+		require.NoError(t, err)
+		assert.Equal(t, orderitem.DiscountSourceCoupon, got)
+	})
+
+	// ==================== Failure cases ==================== //
+	failureTests := []struct {
+		name string
+		text string
+	}{
+		{name: "should return an error for a misspelled discount source", text: "cupon"},
+		{name: "should return an error for the 'unknown' placeholder", text: "unknown"},
+		{name: "should return an error for an empty string", text: ""},
+	}
+	for _, tt := range failureTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := orderitem.DiscountSourceManual
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			assert.ErrorIs(t, err, orderitem.ErrInvalidDiscountSource)
+			assert.Equal(t, orderitem.DiscountSourceManual, got, "value should be unchanged")
+		})
+	}
+}
+
+func TestParseDiscountSource(t *testing.T) {
+	// ==================== Success cases ==================== //
+	t.Run("should parse 2 to DiscountSourceCoupon", func(t *testing.T) {
+		got, err := orderitem.ParseDiscountSource(2)
+
+		require.NoError(t, err)
+		assert.Equal(t, orderitem.DiscountSourceCoupon, got)
+	})
+
+	// ==================== Failure cases ==================== //
+	t.Run("should return an error for an out-of-range value", func(t *testing.T) {
+		got, err := orderitem.ParseDiscountSource(999)
+
+		assert.ErrorIs(t, err, orderitem.ErrInvalidDiscountSource)
+		assert.Equal(t, orderitem.DiscountSource{}, got)
+	})
+}
+
+func TestOrderItem_DiscountSource(t *testing.T) {
+	// ==================== Success cases ==================== //
+	t.Run("should have no source before any discount", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		_, ok := oi.DiscountSourceValue()
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should record a coupon-sourced discount", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.ApplyDiscountFrom(5.0, orderitem.DiscountSourceCoupon)
+
+		require.NoError(t, err)
+		got, ok := oi.DiscountSourceValue()
+		assert.True(t, ok)
+		assert.Equal(t, orderitem.DiscountSourceCoupon, got)
+		assert.Equal(t, 5.0, oi.DiscountApplied)
+		assert.Equal(t, 15.0, oi.TotalPrice)
+	})
+
+	t.Run("should default ApplyDiscount to a manual source", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+		require.NoError(t, oi.ApplyDiscountFrom(5.0, orderitem.DiscountSourceCoupon))
+
+		err := oi.ApplyDiscount(3.0)
+
+		require.NoError(t, err)
+		got, ok := oi.DiscountSourceValue()
+		assert.True(t, ok)
+		assert.Equal(t, orderitem.DiscountSourceManual, got)
+	})
+
+	t.Run("should record a tier source for a tiered discount", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 10)
+
+		err := oi.ApplyTieredDiscount([]orderitem.DiscountTier{{MinQuantity: 5, Percent: 5}})
+
+		require.NoError(t, err)
+		got, ok := oi.DiscountSourceValue()
+		assert.True(t, ok)
+		assert.Equal(t, orderitem.DiscountSourceTier, got)
+	})
+
+	t.Run("should clear the source when the discount is removed", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+		require.NoError(t, oi.ApplyDiscountFrom(5.0, orderitem.DiscountSourceCoupon))
+
+		err := oi.ApplyDiscountFrom(0, orderitem.DiscountSourceCoupon)
+
+		require.NoError(t, err)
+		_, ok := oi.DiscountSourceValue()
+		assert.False(t, ok)
+	})
+
+	// ==================== Failure cases ==================== //
+	t.Run("should reject an undefined source and leave the item unchanged", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+		require.NoError(t, oi.ApplyDiscountFrom(5.0, orderitem.DiscountSourceCoupon))
+
+		err := oi.ApplyDiscountFrom(3.0, orderitem.DiscountSource{})
+
+		assert.ErrorIs(t, err, orderitem.ErrInvalidDiscountSource)
+		got, _ := oi.DiscountSourceValue()
+		assert.Equal(t, orderitem.DiscountSourceCoupon, got)
+		assert.Equal(t, 5.0, oi.DiscountApplied)
+	})
+
+	t.Run("should keep the previous source when the discount is rejected", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+		require.NoError(t, oi.ApplyDiscountFrom(5.0, orderitem.DiscountSourceCoupon))
+
+		err := oi.ApplyDiscount(50.0)
+
+		assert.ErrorIs(t, err, orderitem.ErrDiscountExceedsSubtotal)
+		got, _ := oi.DiscountSourceValue()
+		assert.Equal(t, orderitem.DiscountSourceCoupon, got)
+	})
+}
@@ -0,0 +1,57 @@
+package orderitem
+
+import (
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrInvalidDiscountTier = errs.New("ORDER_ITEM.INVALID_DISCOUNT_TIER", "discount tier must have a positive minimum quantity and a percent in (0, 100)")
+
+// DiscountTier grants Percent off the whole line (e.g. 5 means 5%) once the item
+// quantity reaches MinQuantity.
+type DiscountTier struct {
+	MinQuantity int
+	Percent     float64
+}
+
+// Validate reports [ErrInvalidDiscountTier] unless MinQuantity is strictly positive and
+// Percent is in (0, 100).
+func (t DiscountTier) Validate() error {
+	if t.MinQuantity <= 0 || t.Percent <= 0 || t.Percent >= 100 {
+		return ErrInvalidDiscountTier
+	}
+	return nil
+}
+
+// ApplyTieredDiscount replaces the discount with the best tier the current quantity
+// reaches, i.e. the highest Percent among the tiers whose MinQuantity is at most
+// Quantity, applied to UnitPrice × Quantity (or to UnitPrice under [DiscountModePerUnit])
+// and rounded to cents with [kernel.RoundCents]. When no tier applies the discount is
+// cleared. The discount is recorded as [DiscountSourceTier]. Reapply it after the
+// quantity changes to recompute.
+//
+// The discount is a share of the line, so a Percent below 100 keeps TotalPrice positive.
+// Returns [ErrInvalidDiscountTier] if any tier is invalid, leaving the item unchanged.
+func (oi *OrderItem) ApplyTieredDiscount(tiers []DiscountTier) error {
+	best := 0.0
+	for _, tier := range tiers {
+		if err := tier.Validate(); err != nil {
+			return err
+		}
+		if tier.MinQuantity <= oi.Quantity {
+			best = max(best, tier.Percent)
+		}
+	}
+
+	base := oi.Subtotal()
+	if CurrentDiscountMode().Equals(DiscountModePerUnit) {
+		base = oi.UnitPrice
+	}
+
+	oi.DiscountApplied = kernel.RoundCents(base * best / 100)
+	oi.calculateTotalPrice()
+	oi.setDiscountSource(DiscountSourceTier)
+	oi.updateTimestamp()
+
+	return nil
+}
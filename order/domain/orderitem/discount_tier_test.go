@@ -0,0 +1,74 @@
+package orderitem_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderItem_ApplyTieredDiscount(t *testing.T) {
+	tiers := []orderitem.DiscountTier{
+		{MinQuantity: 10, Percent: 10},
+		{MinQuantity: 5, Percent: 5},
+	}
+
+	t.Run("should select the best tier reached by the quantity", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			quantity     int
+			wantDiscount float64
+			wantTotal    float64
+		}{
+			{name: "should apply no tier at 3 units", quantity: 3, wantDiscount: 0, wantTotal: 30.0},
+			{name: "should apply the 5-unit tier at 7 units", quantity: 7, wantDiscount: 3.5, wantTotal: 66.5},
+			{name: "should apply the 10-unit tier at 12 units", quantity: 12, wantDiscount: 12.0, wantTotal: 108.0},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				oi := createValidOrderItem(t, 10.0, tt.quantity)
+
+				err := oi.ApplyTieredDiscount(tiers)
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantDiscount, oi.DiscountApplied)
+				assert.Equal(t, tt.wantTotal, oi.TotalPrice)
+			})
+		}
+	})
+
+	t.Run("should recompute from the current quantity when reapplied", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 7)
+		require.NoError(t, oi.ApplyTieredDiscount(tiers))
+		require.NoError(t, oi.AddUnits(5))
+
+		err := oi.ApplyTieredDiscount(tiers)
+
+		require.NoError(t, err)
+		assert.Equal(t, 12.0, oi.DiscountApplied, "12 units should reach the 10% tier")
+		assert.Equal(t, 108.0, oi.TotalPrice)
+	})
+
+	t.Run("should return an error when a tier is invalid", func(t *testing.T) {
+		tests := []struct {
+			name string
+			tier orderitem.DiscountTier
+		}{
+			{name: "should return an error when MinQuantity is zero", tier: orderitem.DiscountTier{MinQuantity: 0, Percent: 5}},
+			{name: "should return an error when Percent is zero", tier: orderitem.DiscountTier{MinQuantity: 5, Percent: 0}},
+			{name: "should return an error when Percent is 100", tier: orderitem.DiscountTier{MinQuantity: 5, Percent: 100}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				oi := createValidOrderItem(t, 10.0, 7)
+
+				err := oi.ApplyTieredDiscount([]orderitem.DiscountTier{tt.tier})
+
+				assert.ErrorIs(t, err, orderitem.ErrInvalidDiscountTier)
+				assert.Equal(t, 70.0, oi.TotalPrice, "TotalPrice should not change on error")
+				assert.Nil(t, oi.UpdatedAt, "UpdatedAt should remain nil on error")
+			})
+		}
+	})
+}
@@ -1,7 +1,7 @@
 package orderitem
 
 import (
-	"errors"
+	"strings"
 	"time"
 
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
@@ -16,49 +16,71 @@ var (
 	ErrInvalidQuantity          = errs.New("ORDER_ITEM.INVALID_QUANTITY", "quantity must be greater than zero")
 	ErrNegativeDiscount         = errs.New("ORDER_ITEM.NEGATIVE_DISCOUNT", "discount cannot be negative")
 	ErrDiscountExceedsUnitPrice = errs.New("ORDER_ITEM.DISCOUNT_EXCEEDS_PRICE", "discount cannot be greater than unit price")
+	ErrDiscountExceedsSubtotal  = errs.New("ORDER_ITEM.DISCOUNT_EXCEEDS_SUBTOTAL", "discount cannot be greater than the line subtotal")
 	ErrInvalidUnits             = errs.New("ORDER_ITEM.INVALID_UNITS", "units cannot be zero or negative")
+	ErrInvalidNumber            = errs.New("ORDER_ITEM.INVALID_NUMBER", "price and discount must be finite numbers")
 	ErrInsufficientQuantity     = errs.New("ORDER_ITEM.INSUFFICIENT_QUANTITY", "units to remove cannot be greater than or equal to current quantity")
+	ErrNoteTooLong              = errs.New("ORDER_ITEM.NOTE_TOO_LONG", "note cannot be longer than 200 characters")
+	ErrProductMismatch          = errs.New("ORDER_ITEM.PRODUCT_MISMATCH", "items for different products cannot be merged")
+	ErrUnitPriceMismatch        = errs.New("ORDER_ITEM.UNIT_PRICE_MISMATCH", "items with different unit prices cannot be merged")
+	ErrQuantityExceedsLimit     = errs.New("ORDER_ITEM.QUANTITY_EXCEEDS_LIMIT", "quantity cannot exceed the maximum integer value")
 )
 
+// MaxNoteLength is the maximum number of characters of an [OrderItem] note.
+const MaxNoteLength = 200
+
 // OrderItem is an entity of the Order aggregate that represents a single line item
 // within an order, associating a product with a quantity, unit price, and optional
-// discount. TotalPrice is automatically maintained as (UnitPrice × Quantity) − DiscountApplied.
+// discount. TotalPrice is automatically maintained as (UnitPrice × Quantity) − DiscountApplied,
+// or (UnitPrice − DiscountApplied) × Quantity under [DiscountModePerUnit].
+//
+// OrderItem methods validate the item alone and know nothing of its order's status.
+// Lines of an order are changed through the Order methods (ApplyItemDiscount,
+// UpdateProductPrice, AddUnitsToItem, ...), which also check that the order is still
+// editable; mutating an OrderItem directly bypasses those checks.
 type OrderItem struct {
-	ID              string
-	ProductID       string
-	ProductName     string
-	UnitPrice       float64
-	Quantity        int
-	DiscountApplied float64
-	TotalPrice      float64
-	CreatedAt       time.Time
-	UpdatedAt       *time.Time
+	ID              string          `json:"id"`
+	ProductID       string          `json:"product_id"`
+	ProductName     string          `json:"product_name"`
+	UnitPrice       float64         `json:"unit_price"`
+	Quantity        int             `json:"quantity"`
+	DiscountApplied float64         `json:"discount_applied"`
+	TotalPrice      float64         `json:"total_price"`
+	DiscountSource  *DiscountSource `json:"discount_source"` // nil while no discount is applied
+	Note            string          `json:"note"`            // customer instructions for this line, e.g. "gift wrap this one"
+	TaxCategory     TaxCategory     `json:"tax_category"`    // how the line is taxed; TaxCategoryStandard for new items
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       *time.Time      `json:"updated_at"`
 }
 
 // NewOrderItem constructs and validates a new [OrderItem] for the given product.
 // productID and productName must be non-empty and non-whitespace; unitPrice and
-// quantity must be strictly positive. DiscountApplied is initialized to zero and
-// TotalPrice is computed immediately.
+// quantity must be strictly positive. productName is stored without its leading and
+// trailing whitespace. DiscountApplied is initialized to zero and TotalPrice is
+// computed immediately.
 //
 // If multiple fields are invalid, all violations are collected and returned as a
 // single joined error, allowing callers to inspect every failure via [errors.Is].
 func NewOrderItem(productID, productName string, unitPrice float64, quantity int) (*OrderItem, error) {
-	if err := errors.Join(
-		guard.CheckNotNullOrWhiteSpace(productID, ErrInvalidProductID),
-		guard.CheckNotNullOrWhiteSpace(productName, ErrInvalidProductName),
-		guard.CheckNotZeroOrNegative(unitPrice, ErrInvalidUnitPrice),
-		guard.CheckNotZeroOrNegative(float64(quantity), ErrInvalidQuantity),
+	productName = strings.TrimSpace(productName)
+	if err := guard.CheckAllOf(
+		func() error { return guard.CheckNotNullOrWhiteSpace(productID, ErrInvalidProductID) },
+		func() error { return guard.CheckNotNullOrWhiteSpace(productName, ErrInvalidProductName) },
+		func() error { return guard.CheckFinite(unitPrice, ErrInvalidNumber) },
+		func() error { return guard.CheckNotZeroOrNegative(unitPrice, ErrInvalidUnitPrice) },
+		func() error { _, err := NewQuantity(quantity); return err },
 	); err != nil {
 		return nil, err
 	}
 
 	oi := OrderItem{
-		ID:          kernel.NewID().String(),
+		ID:          kernel.GenerateID(),
 		ProductID:   productID,
 		ProductName: productName,
 		UnitPrice:   unitPrice,
 		Quantity:    quantity,
-		CreatedAt:   time.Now().UTC(),
+		TaxCategory: TaxCategoryStandard,
+		CreatedAt:   kernel.Now(),
 	}
 
 	oi.calculateTotalPrice()
@@ -66,35 +88,76 @@ func NewOrderItem(productID, productName string, unitPrice float64, quantity int
 	return &oi, nil
 }
 
-// ApplyDiscount sets the discount applied to this item's unit price.
-// discount must be non-negative and must not exceed [OrderItem.UnitPrice].
-// TotalPrice is recalculated after a successful update.
+// ApplyDiscount sets the discount applied to this item, read per the configured
+// [DiscountMode]. discount must be a finite, non-negative number not exceeding
+// [OrderItem.Subtotal] under [DiscountModePerLine] ([ErrDiscountExceedsSubtotal]), or
+// [OrderItem.UnitPrice] under [DiscountModePerUnit] ([ErrDiscountExceedsUnitPrice]).
+// TotalPrice is recalculated after a successful update. The discount is recorded as
+// [DiscountSourceManual]; use [OrderItem.ApplyDiscountFrom] to record another source.
 func (oi *OrderItem) ApplyDiscount(discount float64) error {
-	if discount < 0 {
-		return ErrNegativeDiscount
+	return oi.ApplyDiscountFrom(discount, DiscountSourceManual)
+}
+
+// ApplyDiscountFrom behaves like [OrderItem.ApplyDiscount] but records source as the
+// origin of the discount, see [OrderItem.DiscountSourceValue]. source must be a defined
+// [DiscountSource] ([ErrInvalidDiscountSource]). A zero discount clears the source.
+func (oi *OrderItem) ApplyDiscountFrom(discount float64, source DiscountSource) error {
+	if err := guard.CheckValidEnum(source, ErrInvalidDiscountSource); err != nil {
+		return err
 	}
-	if discount > oi.UnitPrice {
+	if err := guard.CheckFinite(discount, ErrInvalidNumber); err != nil {
+		return err
+	}
+	if err := guard.CheckNonNegative(discount, ErrNegativeDiscount); err != nil {
+		return err
+	}
+	mode := CurrentDiscountMode()
+	if mode.Equals(DiscountModePerUnit) && discount > oi.UnitPrice {
 		return ErrDiscountExceedsUnitPrice
 	}
+	if mode.Equals(DiscountModePerLine) && discount > oi.Subtotal() {
+		return ErrDiscountExceedsSubtotal
+	}
 
-	oi.DiscountApplied = discount
-	oi.calculateTotalPrice()
+	if err := oi.recalculate(oi.Quantity, oi.UnitPrice, discount); err != nil {
+		return err
+	}
+	oi.setDiscountSource(source)
 	oi.updateTimestamp()
 
 	return nil
 }
 
+// DiscountSourceValue returns where the current discount came from and whether a
+// discount is applied, sparing callers from dereferencing DiscountSource after a nil check.
+func (oi *OrderItem) DiscountSourceValue() (DiscountSource, bool) {
+	if oi.DiscountSource == nil {
+		return DiscountSource{}, false
+	}
+	return *oi.DiscountSource, true
+}
+
+// setDiscountSource records source for a non-zero DiscountApplied and clears it otherwise.
+func (oi *OrderItem) setDiscountSource(source DiscountSource) {
+	if oi.DiscountApplied == 0 {
+		oi.DiscountSource = nil
+		return
+	}
+	oi.DiscountSource = &source
+}
+
 // AddUnits increases the item quantity by units, which must be strictly positive.
-// units must be strictly positive.
+// A sum that would overflow int returns [ErrQuantityExceedsLimit].
 // TotalPrice is recalculated after a successful update.
 func (oi *OrderItem) AddUnits(units int) error {
-	// the units to add must be greater than zero.
-	if units <= 0 {
-		return ErrInvalidUnits
+	quantity, err := oi.quantity().Add(units)
+	if err != nil {
+		return err
 	}
 
-	oi.Quantity += units
-	oi.calculateTotalPrice()
+	if err := oi.recalculate(quantity.Value(), oi.UnitPrice, oi.DiscountApplied); err != nil {
+		return err
+	}
 	oi.updateTimestamp()
 
 	return nil
@@ -102,38 +165,148 @@ func (oi *OrderItem) AddUnits(units int) error {
 
 // RemoveUnits decreases the item quantity by units.
 // units must be strictly positive and less than the current quantity
-// (at least one unit must remain). TotalPrice is recalculated after a successful update.
+// (at least one unit must remain), and the remaining units must still cover the discount
+// ([ErrDiscountExceedsSubtotal]). TotalPrice is recalculated after a successful update.
 func (oi *OrderItem) RemoveUnits(units int) error {
-	// the units to remove must be greater than zero and less than the current quantity.
-	if units <= 0 {
-		return ErrInvalidUnits
+	quantity, err := oi.quantity().Sub(units)
+	if err != nil {
+		return err
 	}
-	if units >= oi.Quantity {
-		return ErrInsufficientQuantity
+
+	if err := oi.recalculate(quantity.Value(), oi.UnitPrice, oi.DiscountApplied); err != nil {
+		return err
 	}
+	oi.updateTimestamp()
 
-	oi.Quantity -= units
-	oi.calculateTotalPrice()
+	return nil
+}
+
+// Merge folds other, a line for the same product at the same unit price, into oi by
+// summing their quantities; other is left unchanged. The discounts are combined so the
+// merged TotalPrice equals the sum of both lines: added up under [DiscountModePerLine],
+// averaged by quantity under [DiscountModePerUnit]. oi keeps its discount source, or
+// takes the one of other when it had no discount. Returns [ErrProductMismatch] when other
+// is nil or for another product, [ErrUnitPriceMismatch] when the unit prices differ, and
+// [ErrQuantityExceedsLimit] when the summed quantity would overflow int.
+func (oi *OrderItem) Merge(other *OrderItem) error {
+	if other == nil || other.ProductID != oi.ProductID {
+		return ErrProductMismatch
+	}
+	if other.UnitPrice != oi.UnitPrice {
+		return ErrUnitPriceMismatch
+	}
+	merged, err := oi.quantity().Add(other.Quantity)
+	if err != nil {
+		return err
+	}
+
+	quantity := merged.Value()
+	discount := oi.DiscountApplied + other.DiscountApplied
+	if CurrentDiscountMode().Equals(DiscountModePerUnit) {
+		discount = (oi.DiscountApplied*float64(oi.Quantity) + other.DiscountApplied*float64(other.Quantity)) / float64(quantity)
+	}
+
+	source, hasSource := oi.DiscountSourceValue()
+	if !hasSource {
+		source, hasSource = other.DiscountSourceValue()
+	}
+
+	if err := oi.recalculate(quantity, oi.UnitPrice, discount); err != nil {
+		return err
+	}
+	if hasSource {
+		oi.setDiscountSource(source)
+	}
 	oi.updateTimestamp()
 
 	return nil
 }
 
 // UpdateUnitPrice sets a new unit price for the item.
-// value must be strictly positive. TotalPrice is recalculated after a successful update.
+// value must be a finite, strictly positive number that still covers the discount
+// ([ErrDiscountExceedsSubtotal] or [ErrDiscountExceedsUnitPrice]). TotalPrice is
+// recalculated after a successful update.
 func (oi *OrderItem) UpdateUnitPrice(value float64) error {
+	if err := guard.CheckFinite(value, ErrInvalidNumber); err != nil {
+		return err
+	}
 	// the unit price must be greater than zero.
 	if value <= 0 {
 		return ErrInvalidUnitPrice
 	}
 
-	oi.UnitPrice = value
-	oi.calculateTotalPrice()
+	if err := oi.recalculate(oi.Quantity, value, oi.DiscountApplied); err != nil {
+		return err
+	}
 	oi.updateTimestamp()
 
 	return nil
 }
 
+// SetProductName renames the item, as [NewOrderItem] names it: name is trimmed of
+// leading and trailing whitespace and must not be empty afterwards, otherwise
+// [ErrInvalidProductName] is returned and the name is kept.
+func (oi *OrderItem) SetProductName(name string) error {
+	name = strings.TrimSpace(name)
+	if err := guard.CheckNotNullOrWhiteSpace(name, ErrInvalidProductName); err != nil {
+		return err
+	}
+
+	oi.ProductName = name
+	oi.updateTimestamp()
+
+	return nil
+}
+
+// SetNote replaces the customer note of the item; an empty note clears it. note may
+// hold at most [MaxNoteLength] characters, otherwise [ErrNoteTooLong] is returned.
+func (oi *OrderItem) SetNote(note string) error {
+	if err := guard.CheckLength(note, 0, MaxNoteLength, ErrNoteTooLong); err != nil {
+		return err
+	}
+
+	oi.Note = note
+	oi.updateTimestamp()
+
+	return nil
+}
+
+// SetTaxCategory changes how the item is taxed; category must be a defined
+// [TaxCategory], otherwise [ErrInvalidTaxCategory] is returned.
+func (oi *OrderItem) SetTaxCategory(category TaxCategory) error {
+	if err := guard.CheckValidEnum(category, ErrInvalidTaxCategory); err != nil {
+		return err
+	}
+
+	oi.TaxCategory = category
+	oi.updateTimestamp()
+
+	return nil
+}
+
+// Subtotal returns the line amount before discount, UnitPrice × Quantity.
+func (oi *OrderItem) Subtotal() float64 {
+	return oi.UnitPrice * float64(oi.Quantity)
+}
+
+// ExpectedTotalPrice returns the TotalPrice derived from UnitPrice, Quantity and
+// DiscountApplied under the configured [DiscountMode]. It differs from TotalPrice only
+// when the stored value is stale.
+func (oi *OrderItem) ExpectedTotalPrice() float64 {
+	line := *oi
+	line.calculateTotalPrice()
+	return line.TotalPrice
+}
+
+// LastModified returns when the item was last touched: UpdatedAt once it has been
+// mutated, CreatedAt otherwise. It gives callers a single non-nil chronological field.
+func (oi *OrderItem) LastModified() time.Time {
+	if oi.UpdatedAt != nil {
+		return *oi.UpdatedAt
+	}
+	return oi.CreatedAt
+}
+
 // Equals reports whether oi and other represent the same order item by comparing IDs.
 // It returns false if other is nil.
 func (oi *OrderItem) Equals(other *OrderItem) bool {
@@ -143,10 +316,40 @@ func (oi *OrderItem) Equals(other *OrderItem) bool {
 	return oi.ID == other.ID
 }
 
+// recalculate applies quantity, unitPrice and discount and recomputes TotalPrice, unless
+// the new TotalPrice would be negative, i.e. the discount would no longer fit the line
+// under the configured [DiscountMode]. That happens when units are removed or the price
+// drops below an earlier discount, or after a mode switch; the change is then rejected
+// with [ErrDiscountExceedsSubtotal] or [ErrDiscountExceedsUnitPrice] and the item is
+// left unchanged.
+func (oi *OrderItem) recalculate(quantity int, unitPrice, discount float64) error {
+	next := *oi
+	next.Quantity, next.UnitPrice, next.DiscountApplied = quantity, unitPrice, discount
+	next.calculateTotalPrice()
+	if next.TotalPrice < 0 {
+		if CurrentDiscountMode().Equals(DiscountModePerUnit) {
+			return ErrDiscountExceedsUnitPrice
+		}
+		return ErrDiscountExceedsSubtotal
+	}
+
+	*oi = next
+	return nil
+}
+
+// quantity returns the Quantity field as the [Quantity] value object enforcing its rules.
+func (oi *OrderItem) quantity() Quantity {
+	return Quantity{value: oi.Quantity}
+}
+
 func (oi *OrderItem) calculateTotalPrice() {
-	oi.TotalPrice = (oi.UnitPrice * float64(oi.Quantity)) - oi.DiscountApplied
+	if CurrentDiscountMode().Equals(DiscountModePerUnit) {
+		oi.TotalPrice = (oi.UnitPrice - oi.DiscountApplied) * float64(oi.Quantity)
+		return
+	}
+	oi.TotalPrice = oi.Subtotal() - oi.DiscountApplied
 }
 
 func (oi *OrderItem) updateTimestamp() {
-	oi.UpdatedAt = new(time.Now().UTC())
+	oi.UpdatedAt = new(kernel.Now())
 }
@@ -0,0 +1,22 @@
+package orderitem_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderItem_JSON(t *testing.T) {
+	t.Run("should serialize every field with snake_case names", func(t *testing.T) {
+		o := testfixtures.ValidOrder(t)
+		item := o.Items()[0]
+		require.NoError(t, item.ApplyDiscount(5.0))
+
+		got, err := json.Marshal(item)
+
+		require.NoError(t, err)
+		testfixtures.Golden(t, "order_item", got)
+	})
+}
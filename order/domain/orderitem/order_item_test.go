@@ -1,7 +1,10 @@
 package orderitem_test
 
 import (
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -28,9 +31,28 @@ func TestNewOrderItem(t *testing.T) {
 			Quantity:        2,
 			DiscountApplied: 0.0,
 			TotalPrice:      20.0,
+			TaxCategory:     orderitem.TaxCategoryStandard,
 		}
 		ignoreFields := cmpopts.IgnoreFields(orderitem.OrderItem{}, "ID", "CreatedAt") // ignore ID and CreatedAt since they are generated and not predictable
-		assert.True(t, cmp.Equal(got, want, ignoreFields), "got and want should be equal ignoring ID and createdAt: %v", cmp.Diff(got, want, ignoreFields))
+		enums := cmpopts.EquateComparable(orderitem.TaxCategory{})
+		assert.True(t, cmp.Equal(got, want, ignoreFields, enums), "got and want should be equal ignoring ID and createdAt: %v", cmp.Diff(got, want, ignoreFields, enums))
+	})
+
+	t.Run("should trim the product name and keep its internal spaces", func(t *testing.T) {
+		got, err := orderitem.NewOrderItem("prod-123", "  Blue T-Shirt \t", 10.0, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Blue T-Shirt", got.ProductName)
+	})
+
+	t.Run("should assign IDs from the installed ID generator", func(t *testing.T) {
+		t.Cleanup(kernel.SetIDGenerator(kernel.SequentialIDGenerator("id")))
+
+		first := createValidOrderItem(t, 10.0, 1)
+		second := createValidOrderItem(t, 10.0, 1)
+
+		assert.Equal(t, "id-1", first.ID)
+		assert.Equal(t, "id-2", second.ID)
 	})
 
 	t.Run("should return an error when invalid input is provided", func(t *testing.T) {
@@ -55,6 +77,11 @@ func TestNewOrderItem(t *testing.T) {
 				args:    args{productID: "prod-123", productName: "", unitPrice: 10.0, quantity: 2},
 				wantErr: orderitem.ErrInvalidProductName,
 			},
+			{
+				name:    "should return an error if product name is only whitespace",
+				args:    args{productID: "prod-123", productName: "\t\n ", unitPrice: 10.0, quantity: 2},
+				wantErr: orderitem.ErrInvalidProductName,
+			},
 			{
 				name:    "should return an error if unit price is zero",
 				args:    args{productID: "prod-123", productName: "Product Name", unitPrice: 0.0, quantity: 2},
@@ -65,6 +92,11 @@ func TestNewOrderItem(t *testing.T) {
 				args:    args{productID: "prod-123", productName: "Product Name", unitPrice: -0.1, quantity: 2},
 				wantErr: orderitem.ErrInvalidUnitPrice,
 			},
+			{
+				name:    "should return an error if unit price is NaN",
+				args:    args{productID: "prod-123", productName: "Product Name", unitPrice: math.NaN(), quantity: 2},
+				wantErr: orderitem.ErrInvalidNumber,
+			},
 			{
 				name:    "should return an error if quantity is zero",
 				args:    args{productID: "prod-123", productName: "Product Name", unitPrice: 10.0, quantity: 0},
@@ -99,6 +131,16 @@ func TestOrderItem_ApplyDiscount(t *testing.T) {
 		assert.NotNil(t, oi.UpdatedAt, "UpdatedAt should be set on success")
 	})
 
+	t.Run("should accept a zero discount", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.ApplyDiscount(0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, oi.DiscountApplied)
+		assert.Equal(t, 20.0, oi.TotalPrice, "TotalPrice should be unchanged")
+	})
+
 	t.Run("should return an error when discount is invalid", func(t *testing.T) {
 		type fields struct {
 			unitPrice float64
@@ -119,11 +161,18 @@ func TestOrderItem_ApplyDiscount(t *testing.T) {
 				wantErr:        orderitem.ErrNegativeDiscount,
 			},
 			{
-				name:           "should return an error when discount is greater than unit price",
+				name:           "should return an error when discount is greater than the line subtotal",
+				fields:         fields{unitPrice: 10.0, quantity: 2},
+				discount:       21.0,
+				wantTotalPrice: 20.0, // no change
+				wantErr:        orderitem.ErrDiscountExceedsSubtotal,
+			},
+			{
+				name:           "should return an error when discount is NaN",
 				fields:         fields{unitPrice: 10.0, quantity: 2},
-				discount:       11.0,
+				discount:       math.NaN(),
 				wantTotalPrice: 20.0, // no change
-				wantErr:        orderitem.ErrDiscountExceedsUnitPrice,
+				wantErr:        orderitem.ErrInvalidNumber,
 			},
 		}
 		for _, tt := range tests {
@@ -226,6 +275,25 @@ func TestOrderItem_AddUnits(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("should return ErrQuantityExceedsLimit when the sum would overflow", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.AddUnits(math.MaxInt - 1)
+
+		assert.ErrorIs(t, err, orderitem.ErrQuantityExceedsLimit)
+		assert.Equal(t, 2, oi.Quantity, "Quantity should not change on error")
+		assert.Equal(t, 20.0, oi.TotalPrice, "TotalPrice should not change on error")
+	})
+
+	t.Run("should add units up to math.MaxInt", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.AddUnits(math.MaxInt - 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, math.MaxInt, oi.Quantity)
+	})
 }
 
 func TestOrderItem_RemoveUnits(t *testing.T) {
@@ -402,6 +470,22 @@ func TestOrderItem_UpdateUnitPrice(t *testing.T) {
 				wantTotalPrice: 20.0, // no change
 				wantErr:        orderitem.ErrInvalidUnitPrice,
 			},
+			{
+				name:           "should return an error when unit price is NaN",
+				fields:         fields{unitPrice: 10.0, quantity: 2},
+				value:          math.NaN(),
+				wantUnitPrice:  10.0, // no change
+				wantTotalPrice: 20.0, // no change
+				wantErr:        orderitem.ErrInvalidNumber,
+			},
+			{
+				name:           "should return an error when unit price is infinite",
+				fields:         fields{unitPrice: 10.0, quantity: 2},
+				value:          math.Inf(1),
+				wantUnitPrice:  10.0, // no change
+				wantTotalPrice: 20.0, // no change
+				wantErr:        orderitem.ErrInvalidNumber,
+			},
 		}
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
@@ -418,6 +502,301 @@ func TestOrderItem_UpdateUnitPrice(t *testing.T) {
 	})
 }
 
+func TestOrderItem_TotalPriceNeverNegative(t *testing.T) {
+	t.Run("should total zero for a single unit discounted by its whole price", func(t *testing.T) {
+		for _, mode := range []orderitem.DiscountMode{orderitem.DiscountModePerLine, orderitem.DiscountModePerUnit} {
+			t.Run(mode.String(), func(t *testing.T) {
+				t.Cleanup(func() { _ = orderitem.SetDiscountMode(orderitem.DefaultDiscountMode) })
+				require.NoError(t, orderitem.SetDiscountMode(mode))
+				oi := createValidOrderItem(t, 10.0, 1)
+
+				err := oi.ApplyDiscount(10.0)
+
+				require.NoError(t, err)
+				assert.Equal(t, 0.0, oi.TotalPrice)
+			})
+		}
+	})
+
+	t.Run("should reject changes that would leave the discount larger than the line", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			mode     orderitem.DiscountMode
+			discount float64
+			change   func(oi *orderitem.OrderItem) error
+			wantErr  error
+		}{
+			{
+				name:     "should reject removing units below a per-line discount",
+				mode:     orderitem.DiscountModePerLine,
+				discount: 15.0,
+				change:   func(oi *orderitem.OrderItem) error { return oi.RemoveUnits(1) },
+				wantErr:  orderitem.ErrDiscountExceedsSubtotal,
+			},
+			{
+				name:     "should reject a price drop below a per-line discount",
+				mode:     orderitem.DiscountModePerLine,
+				discount: 15.0,
+				change:   func(oi *orderitem.OrderItem) error { return oi.UpdateUnitPrice(5.0) },
+				wantErr:  orderitem.ErrDiscountExceedsSubtotal,
+			},
+			{
+				name:     "should reject a price drop below a per-unit discount",
+				mode:     orderitem.DiscountModePerUnit,
+				discount: 8.0,
+				change:   func(oi *orderitem.OrderItem) error { return oi.UpdateUnitPrice(5.0) },
+				wantErr:  orderitem.ErrDiscountExceedsUnitPrice,
+			},
+			{
+				name:     "should reject a change after switching a large per-line discount to per-unit",
+				mode:     orderitem.DiscountModePerLine,
+				discount: 15.0,
+				change: func(oi *orderitem.OrderItem) error {
+					if err := orderitem.SetDiscountMode(orderitem.DiscountModePerUnit); err != nil {
+						return err
+					}
+					return oi.AddUnits(1)
+				},
+				wantErr: orderitem.ErrDiscountExceedsUnitPrice,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Cleanup(func() { _ = orderitem.SetDiscountMode(orderitem.DefaultDiscountMode) })
+				require.NoError(t, orderitem.SetDiscountMode(tt.mode))
+				oi := createValidOrderItem(t, 10.0, 2)
+				require.NoError(t, oi.ApplyDiscount(tt.discount))
+				before := *oi
+
+				err := tt.change(oi)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, before, *oi, "the item should be unchanged")
+				assert.GreaterOrEqual(t, oi.TotalPrice, 0.0)
+			})
+		}
+	})
+}
+
+func TestOrderItem_Merge(t *testing.T) {
+	t.Run("should sum quantities and keep the total of both lines", func(t *testing.T) {
+		tests := []struct {
+			mode           orderitem.DiscountMode
+			otherDiscount  float64
+			wantDiscount   float64
+			wantTotalPrice float64
+		}{
+			// 10*2 - 5 = 15 plus 10*3 - 3 = 27
+			{mode: orderitem.DiscountModePerLine, otherDiscount: 3.0, wantDiscount: 8.0, wantTotalPrice: 42.0},
+			// (10 - 5) * 2 = 10 plus (10 - 1) * 3 = 27; (5*2 + 1*3) / 5 = 2.6 per unit
+			{mode: orderitem.DiscountModePerUnit, otherDiscount: 1.0, wantDiscount: 2.6, wantTotalPrice: 37.0},
+		}
+		for _, tt := range tests {
+			t.Run(tt.mode.String(), func(t *testing.T) {
+				t.Cleanup(func() { _ = orderitem.SetDiscountMode(orderitem.DefaultDiscountMode) })
+				require.NoError(t, orderitem.SetDiscountMode(tt.mode))
+				item := createValidOrderItem(t, 10.0, 2)
+				require.NoError(t, item.ApplyDiscount(5.0))
+				other := createValidOrderItem(t, 10.0, 3)
+				require.NoError(t, other.ApplyDiscountFrom(tt.otherDiscount, orderitem.DiscountSourceCoupon))
+
+				err := item.Merge(other)
+
+				require.NoError(t, err)
+				assert.Equal(t, 5, item.Quantity)
+				assert.InDelta(t, tt.wantDiscount, item.DiscountApplied, 1e-9)
+				assert.InDelta(t, tt.wantTotalPrice, item.TotalPrice, 1e-9, "merged total should equal the sum of both lines")
+				source, _ := item.DiscountSourceValue()
+				assert.Equal(t, orderitem.DiscountSourceManual, source, "the item should keep its own discount source")
+				assert.Equal(t, 3, other.Quantity, "other should be left unchanged")
+			})
+		}
+	})
+
+	t.Run("should take the discount source of other when the item has no discount", func(t *testing.T) {
+		item := createValidOrderItem(t, 10.0, 2)
+		other := createValidOrderItem(t, 10.0, 3)
+		require.NoError(t, other.ApplyDiscountFrom(3.0, orderitem.DiscountSourceCoupon))
+
+		err := item.Merge(other)
+
+		require.NoError(t, err)
+		source, ok := item.DiscountSourceValue()
+		require.True(t, ok)
+		assert.Equal(t, orderitem.DiscountSourceCoupon, source)
+	})
+
+	t.Run("should return ErrProductMismatch for another product", func(t *testing.T) {
+		item := createValidOrderItem(t, 10.0, 2)
+		other := kernel.Must(orderitem.NewOrderItem("prod-456", "Other Product", 10.0, 1))
+
+		err := item.Merge(other)
+
+		assert.ErrorIs(t, err, orderitem.ErrProductMismatch)
+		assert.Equal(t, 2, item.Quantity, "quantity should be unchanged")
+	})
+
+	t.Run("should return ErrProductMismatch for a nil item", func(t *testing.T) {
+		item := createValidOrderItem(t, 10.0, 2)
+
+		err := item.Merge(nil)
+
+		assert.ErrorIs(t, err, orderitem.ErrProductMismatch)
+	})
+
+	t.Run("should return ErrUnitPriceMismatch for another unit price", func(t *testing.T) {
+		item := createValidOrderItem(t, 10.0, 2)
+		other := createValidOrderItem(t, 12.0, 1)
+
+		err := item.Merge(other)
+
+		assert.ErrorIs(t, err, orderitem.ErrUnitPriceMismatch)
+		assert.Equal(t, 20.0, item.TotalPrice, "TotalPrice should be unchanged")
+	})
+}
+
+func TestOrderItem_SetProductName(t *testing.T) {
+	t.Run("should rename the item and refresh UpdatedAt", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.SetProductName("Blue T-Shirt")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Blue T-Shirt", oi.ProductName)
+		assert.NotNil(t, oi.UpdatedAt)
+	})
+
+	t.Run("should trim the name and keep its internal spaces", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.SetProductName("\t Blue T-Shirt  ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Blue T-Shirt", oi.ProductName)
+	})
+
+	t.Run("should reject a whitespace-only name", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.SetProductName("\t\n ")
+
+		assert.ErrorIs(t, err, orderitem.ErrInvalidProductName)
+		assert.Equal(t, "Test Product", oi.ProductName, "the previous name should be kept")
+		assert.Nil(t, oi.UpdatedAt)
+	})
+}
+
+func TestOrderItem_SetTaxCategory(t *testing.T) {
+	t.Run("should set the category and refresh UpdatedAt", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.SetTaxCategory(orderitem.TaxCategoryExempt)
+
+		require.NoError(t, err)
+		assert.Equal(t, orderitem.TaxCategoryExempt, oi.TaxCategory)
+		assert.NotNil(t, oi.UpdatedAt)
+	})
+
+	t.Run("should reject an undefined category", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.SetTaxCategory(orderitem.TaxCategory{})
+
+		assert.ErrorIs(t, err, orderitem.ErrInvalidTaxCategory)
+		assert.Equal(t, orderitem.TaxCategoryStandard, oi.TaxCategory)
+		assert.Nil(t, oi.UpdatedAt)
+	})
+}
+
+func TestOrderItem_SetNote(t *testing.T) {
+	t.Run("should set the note and refresh UpdatedAt", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.SetNote("gift wrap this one")
+
+		require.NoError(t, err)
+		assert.Equal(t, "gift wrap this one", oi.Note)
+		assert.NotNil(t, oi.UpdatedAt)
+	})
+
+	t.Run("should clear the note when given an empty one", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+		require.NoError(t, oi.SetNote("gift wrap this one"))
+
+		err := oi.SetNote("")
+
+		require.NoError(t, err)
+		assert.Empty(t, oi.Note)
+	})
+
+	t.Run("should accept a note of exactly MaxNoteLength characters", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		err := oi.SetNote(strings.Repeat("é", orderitem.MaxNoteLength))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject an over-length note", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+		require.NoError(t, oi.SetNote("keep me"))
+
+		err := oi.SetNote(strings.Repeat("a", orderitem.MaxNoteLength+1))
+
+		assert.ErrorIs(t, err, orderitem.ErrNoteTooLong)
+		assert.Equal(t, "keep me", oi.Note, "the previous note should be kept")
+	})
+}
+
+func TestOrderItem_ExpectedTotalPrice(t *testing.T) {
+	t.Run("should derive the line total from price, quantity and discount", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 3)
+		require.NoError(t, oi.ApplyDiscount(5.0))
+		oi.TotalPrice = 1.0 // stale value, e.g. written by a buggy migration
+
+		got := oi.ExpectedTotalPrice()
+
+		assert.Equal(t, 25.0, got)
+		assert.Equal(t, 1.0, oi.TotalPrice, "the stored total should be left untouched")
+	})
+
+	t.Run("should follow the per-unit discount mode", func(t *testing.T) {
+		t.Cleanup(func() { _ = orderitem.SetDiscountMode(orderitem.DefaultDiscountMode) })
+		require.NoError(t, orderitem.SetDiscountMode(orderitem.DiscountModePerUnit))
+		oi := createValidOrderItem(t, 10.0, 3)
+		require.NoError(t, oi.ApplyDiscount(2.0))
+		oi.TotalPrice = 1.0
+
+		got := oi.ExpectedTotalPrice()
+
+		assert.Equal(t, 24.0, got)
+	})
+}
+
+func TestOrderItem_LastModified(t *testing.T) {
+	clock := kernel.NewFrozenClock(time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC))
+	t.Cleanup(kernel.SetClock(clock))
+
+	t.Run("should return CreatedAt for an untouched item", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+
+		got := oi.LastModified()
+
+		assert.Equal(t, oi.CreatedAt, got)
+	})
+
+	t.Run("should return UpdatedAt for a mutated item", func(t *testing.T) {
+		oi := createValidOrderItem(t, 10.0, 2)
+		clock.Advance(time.Hour)
+		require.NoError(t, oi.AddUnits(1))
+
+		got := oi.LastModified()
+
+		assert.Equal(t, *oi.UpdatedAt, got)
+		assert.True(t, got.After(oi.CreatedAt), "LastModified should follow the mutation")
+	})
+}
+
 func TestOrderItem_Equals(t *testing.T) {
 	tests := []struct {
 		name  string
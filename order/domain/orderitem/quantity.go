@@ -0,0 +1,48 @@
+package orderitem
+
+import "math"
+
+// Quantity is an immutable value object holding the number of units of an [OrderItem].
+// It is always strictly positive and its arithmetic never overflows; the zero value is
+// not a valid Quantity.
+type Quantity struct {
+	value int
+}
+
+// NewQuantity returns n as a [Quantity]; n must be strictly positive
+// ([ErrInvalidQuantity]).
+func NewQuantity(n int) (Quantity, error) {
+	if n <= 0 {
+		return Quantity{}, ErrInvalidQuantity
+	}
+	return Quantity{value: n}, nil
+}
+
+// Value returns the number of units.
+func (q Quantity) Value() int {
+	return q.value
+}
+
+// Add returns q increased by units, which must be strictly positive ([ErrInvalidUnits]).
+// A sum that would overflow int returns [ErrQuantityExceedsLimit].
+func (q Quantity) Add(units int) (Quantity, error) {
+	if units <= 0 {
+		return Quantity{}, ErrInvalidUnits
+	}
+	if units > math.MaxInt-q.value {
+		return Quantity{}, ErrQuantityExceedsLimit
+	}
+	return Quantity{value: q.value + units}, nil
+}
+
+// Sub returns q decreased by units, which must be strictly positive ([ErrInvalidUnits])
+// and less than q, so at least one unit remains ([ErrInsufficientQuantity]).
+func (q Quantity) Sub(units int) (Quantity, error) {
+	if units <= 0 {
+		return Quantity{}, ErrInvalidUnits
+	}
+	if units >= q.value {
+		return Quantity{}, ErrInsufficientQuantity
+	}
+	return Quantity{value: q.value - units}, nil
+}
@@ -0,0 +1,85 @@
+package orderitem_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQuantity(t *testing.T) {
+	t.Run("should accept a strictly positive value", func(t *testing.T) {
+		for _, n := range []int{1, 42, math.MaxInt} {
+			q, err := orderitem.NewQuantity(n)
+
+			require.NoError(t, err)
+			assert.Equal(t, n, q.Value())
+		}
+	})
+
+	t.Run("should return ErrInvalidQuantity for zero or a negative value", func(t *testing.T) {
+		for _, n := range []int{0, -1, math.MinInt} {
+			q, err := orderitem.NewQuantity(n)
+
+			assert.ErrorIs(t, err, orderitem.ErrInvalidQuantity)
+			assert.Zero(t, q)
+		}
+	})
+}
+
+func TestQuantity_Add(t *testing.T) {
+	q, err := orderitem.NewQuantity(2)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		units   int
+		want    int
+		wantErr error
+	}{
+		{name: "should add units", units: 3, want: 5},
+		{name: "should add up to math.MaxInt", units: math.MaxInt - 2, want: math.MaxInt},
+		{name: "should reject zero units", units: 0, wantErr: orderitem.ErrInvalidUnits},
+		{name: "should reject negative units", units: -1, wantErr: orderitem.ErrInvalidUnits},
+		{name: "should reject an overflowing sum", units: math.MaxInt - 1, wantErr: orderitem.ErrQuantityExceedsLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := q.Add(tt.units)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got.Value())
+			assert.Equal(t, 2, q.Value(), "the receiver should be immutable")
+		})
+	}
+}
+
+func TestQuantity_Sub(t *testing.T) {
+	q, err := orderitem.NewQuantity(5)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		units   int
+		want    int
+		wantErr error
+	}{
+		{name: "should subtract units", units: 3, want: 2},
+		{name: "should leave a single unit", units: 4, want: 1},
+		{name: "should reject zero units", units: 0, wantErr: orderitem.ErrInvalidUnits},
+		{name: "should reject negative units", units: math.MinInt, wantErr: orderitem.ErrInvalidUnits},
+		{name: "should reject removing every unit", units: 5, wantErr: orderitem.ErrInsufficientQuantity},
+		{name: "should reject removing more units than held", units: 6, wantErr: orderitem.ErrInsufficientQuantity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := q.Sub(tt.units)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got.Value())
+			assert.Equal(t, 5, q.Value(), "the receiver should be immutable")
+		})
+	}
+}
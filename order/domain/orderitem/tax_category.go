@@ -0,0 +1,88 @@
+package orderitem
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+
+var ErrInvalidTaxCategory = errs.New("ORDER_ITEM.INVALID_TAX_CATEGORY", "invalid tax category")
+
+// TaxCategory classifies how a line item is taxed: at the full rate of the destination
+// state, at a reduced share of it, or not at all.
+type TaxCategory struct {
+	value int
+}
+
+var (
+	// TaxCategoryStandard is taxed at the full rate; it is the category of new items.
+	TaxCategoryStandard = TaxCategory{1}
+	// TaxCategoryReduced is taxed at [ReducedTaxShare] of the full rate.
+	TaxCategoryReduced = TaxCategory{2}
+	// TaxCategoryExempt is not taxed.
+	TaxCategoryExempt = TaxCategory{3}
+)
+
+// ReducedTaxShare is the fraction of the full tax rate charged on [TaxCategoryReduced] items.
+const ReducedTaxShare = 0.5
+
+var taxCategoryToString = map[TaxCategory]string{
+	TaxCategoryStandard: "standard",
+	TaxCategoryReduced:  "reduced",
+	TaxCategoryExempt:   "exempt",
+}
+
+// String returns the string representation of the TaxCategory.
+func (s TaxCategory) String() string {
+	if str, ok := taxCategoryToString[s]; ok {
+		return str
+	}
+	return "unknown"
+}
+
+// MarshalText provides support for logging and any marshal needs.
+func (s TaxCategory) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidTaxCategory] and leave s unchanged instead of decoding to an uninitialized TaxCategory.
+func (s *TaxCategory) UnmarshalText(text []byte) error {
+	for v, str := range taxCategoryToString {
+		if str == string(text) {
+			*s = v
+			return nil
+		}
+	}
+	return ErrInvalidTaxCategory
+}
+
+// Equals checks if two TaxCategory values are equal.
+func (s TaxCategory) Equals(other TaxCategory) bool {
+	return s.value == other.value
+}
+
+// IsValid reports whether the TaxCategory is one of the defined values; the zero value is not.
+func (s TaxCategory) IsValid() bool {
+	_, ok := taxCategoryToString[s]
+	return ok
+}
+
+// ParseTaxCategory converts an int to the corresponding TaxCategory value.
+// If the input does not match any known tax category, it returns an error and an empty TaxCategory value.
+func ParseTaxCategory(value int) (TaxCategory, error) {
+	s := TaxCategory{value: value}
+	if _, ok := taxCategoryToString[s]; !ok {
+		return TaxCategory{}, ErrInvalidTaxCategory
+	}
+	return s, nil
+}
+
+// RateFor returns the rate charged on items of this category when the full rate is
+// rate. Undefined categories are taxed at the full rate.
+func (s TaxCategory) RateFor(rate float64) float64 {
+	switch s {
+	case TaxCategoryReduced:
+		return rate * ReducedTaxShare
+	case TaxCategoryExempt:
+		return 0
+	default:
+		return rate
+	}
+}
@@ -0,0 +1,86 @@
+package orderitem_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxCategory_String(t *testing.T) {
+	// ==================== Success cases ==================== //
+	tests := []struct {
+		name     string
+		category orderitem.TaxCategory
+		want     string
+	}{
+		{name: "should return 'standard' for TaxCategoryStandard", category: orderitem.TaxCategoryStandard, want: "standard"},
+		{name: "should return 'reduced' for TaxCategoryReduced", category: orderitem.TaxCategoryReduced, want: "reduced"},
+		{name: "should return 'exempt' for TaxCategoryExempt", category: orderitem.TaxCategoryExempt, want: "exempt"},
+		// ==================== Failure cases ==================== //
+		{name: "should return 'unknown' for an unrecognized category value", category: orderitem.TaxCategory{}, want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.category.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTaxCategory_UnmarshalText(t *testing.T) {
+	t.Run("should unmarshal 'exempt' to TaxCategoryExempt", func(t *testing.T) {
+		var got orderitem.TaxCategory
+
+		err := got.UnmarshalText([]byte("exempt"))
+
+		require.NoError(t, err)
+		assert.Equal(t, orderitem.TaxCategoryExempt, got)
+	})
+
+	t.Run("should return an error for an unknown category", func(t *testing.T) {
+		var got orderitem.TaxCategory
+
+		err := got.UnmarshalText([]byte("luxury"))
+
+		assert.ErrorIs(t, err, orderitem.ErrInvalidTaxCategory)
+		assert.Equal(t, orderitem.TaxCategory{}, got)
+	})
+}
+
+func TestParseTaxCategory(t *testing.T) {
+	t.Run("should parse 3 to TaxCategoryExempt", func(t *testing.T) {
+		got, err := orderitem.ParseTaxCategory(3)
+
+		require.NoError(t, err)
+		assert.Equal(t, orderitem.TaxCategoryExempt, got)
+	})
+
+	t.Run("should return an error for zero value (uninitialized)", func(t *testing.T) {
+		got, err := orderitem.ParseTaxCategory(0)
+
+		assert.ErrorIs(t, err, orderitem.ErrInvalidTaxCategory)
+		assert.Equal(t, orderitem.TaxCategory{}, got)
+	})
+}
+
+func TestTaxCategory_RateFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		category orderitem.TaxCategory
+		want     float64
+	}{
+		{name: "should charge the full rate on standard items", category: orderitem.TaxCategoryStandard, want: 0.2},
+		{name: "should charge the reduced share on reduced items", category: orderitem.TaxCategoryReduced, want: 0.1},
+		{name: "should charge nothing on exempt items", category: orderitem.TaxCategoryExempt, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.category.RateFor(0.2)
+
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
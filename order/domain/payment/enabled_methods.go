@@ -0,0 +1,39 @@
+package payment
+
+import (
+	"slices"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrMethodNotEnabled = errs.New("PAYMENT.METHOD_NOT_ENABLED", "payment method is not enabled in this deployment")
+
+// enabledMethods are the methods [NewPayment] accepts, configured with
+// [SetEnabledMethods]. nil enables every method.
+var enabledMethods kernel.Setting[[]Method]
+
+// EnabledMethods returns the methods enabled with [SetEnabledMethods], or nil when every
+// method is enabled.
+func EnabledMethods() []Method {
+	return slices.Clone(enabledMethods.Load())
+}
+
+// SetEnabledMethods restricts [NewPayment] to methods, e.g. to turn off cash and bank
+// slip where a deployment cannot take them; an empty list enables every method again.
+// [NewFreePayment] is not affected.
+func SetEnabledMethods(methods []Method) {
+	if len(methods) == 0 {
+		enabledMethods.Store(nil)
+		return
+	}
+	enabledMethods.Store(slices.Clone(methods))
+}
+
+func checkMethodEnabled(method Method) error {
+	enabled := enabledMethods.Load()
+	if enabled == nil || slices.Contains(enabled, method) {
+		return nil
+	}
+	return ErrMethodNotEnabled
+}
@@ -0,0 +1,43 @@
+package payment_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetEnabledMethods(t *testing.T) {
+	t.Cleanup(func() { payment.SetEnabledMethods(nil) })
+	payment.SetEnabledMethods([]payment.Method{payment.MethodCreditCard, payment.MethodPix})
+
+	t.Run("should create a payment with an enabled method", func(t *testing.T) {
+		p, err := payment.NewPayment("order-123", 100.0, payment.MethodPix)
+
+		require.NoError(t, err)
+		assert.Equal(t, payment.MethodPix, p.Method)
+	})
+
+	t.Run("should reject a payment with a disabled method", func(t *testing.T) {
+		p, err := payment.NewPayment("order-123", 100.0, payment.MethodCash)
+
+		assert.ErrorIs(t, err, payment.ErrMethodNotEnabled)
+		assert.Nil(t, p)
+	})
+
+	t.Run("should still create free payments", func(t *testing.T) {
+		_, err := payment.NewFreePayment("order-123")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should enable every method again when cleared", func(t *testing.T) {
+		payment.SetEnabledMethods(nil)
+
+		_, err := payment.NewPayment("order-123", 100.0, payment.MethodCash)
+
+		assert.NoError(t, err)
+		assert.Nil(t, payment.EnabledMethods())
+	})
+}
@@ -0,0 +1,18 @@
+package payment
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+
+// FeeSchedule maps each payment [Method] to the processing fee the gateway charges,
+// expressed as a percentage of the payment amount (e.g. 2.5 means 2.5%).
+// Methods absent from the schedule carry no fee.
+type FeeSchedule map[Method]float64
+
+// ProcessingFee returns the fee charged for p under schedule: Amount × percentage / 100,
+// rounded to cents with [kernel.RoundCents]. It returns 0 for methods not in the schedule.
+func (p *Payment) ProcessingFee(schedule FeeSchedule) float64 {
+	percentage, ok := schedule[p.Method]
+	if !ok {
+		return 0
+	}
+	return kernel.RoundCents(p.Amount * percentage / 100)
+}
@@ -0,0 +1,40 @@
+package payment_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayment_ProcessingFee(t *testing.T) {
+	schedule := payment.FeeSchedule{
+		payment.MethodCreditCard: 2.5,
+		payment.MethodDebitCard:  1.99,
+		payment.MethodPix:        0,
+	}
+
+	tests := []struct {
+		name   string
+		amount float64
+		method payment.Method
+		want   float64
+	}{
+		{name: "should charge the credit card percentage", amount: 100.0, method: payment.MethodCreditCard, want: 2.5},
+		{name: "should charge no fee for Pix", amount: 100.0, method: payment.MethodPix, want: 0},
+		{name: "should charge no fee for a method missing from the schedule", amount: 100.0, method: payment.MethodCash, want: 0},
+		{name: "should round half a cent away from zero", amount: 0.20, method: payment.MethodCreditCard, want: 0.01},
+		{name: "should round down below half a cent", amount: 10.10, method: payment.MethodCreditCard, want: 0.25},
+		{name: "should round up above half a cent", amount: 12.34, method: payment.MethodCreditCard, want: 0.31},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := kernel.Must(payment.NewPayment("order-123", tt.amount, tt.method))
+
+			got := p.ProcessingFee(schedule)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,61 +1,106 @@
 package payment
 
 import (
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/money"
 )
 
 var (
 	ErrInvalidOrderID                             = errs.New("PAYMENT.INVALID_ORDER_ID", "order ID cannot be null or whitespace")
 	ErrInvalidPaymentAmount                       = errs.New("PAYMENT.INVALID_AMOUNT", "payment amount must be greater than zero")
 	ErrInvalidTransactionCode                     = errs.New("PAYMENT.INVALID_TRANSACTION_CODE", "transaction code cannot be null or whitespace")
-	ErrTransactionCodeAlreadyDefined              = errs.New("PAYMENT.TRANSACTION_CODE_ALREADY_DEFINED", "transaction code has already been defined")
-	ErrCannotDefineTransactionCodeAfterCompletion = errs.New("PAYMENT.TRANSACTION_CODE_AFTER_COMPLETION", "transaction code cannot be defined after payment has been confirmed or refused")
-	ErrPaymentNotPending                          = errs.New("PAYMENT.NOT_PENDING", "payment is not in pending status")
-	ErrTransactionCodeNotDefined                  = errs.New("PAYMENT.TRANSACTION_CODE_NOT_DEFINED", "transaction code has not been defined yet")
+	ErrTransactionCodeAlreadyDefined              = errs.NewConflict("PAYMENT.TRANSACTION_CODE_ALREADY_DEFINED", "transaction code has already been defined")
+	ErrCannotDefineTransactionCodeAfterCompletion = errs.NewConflict("PAYMENT.TRANSACTION_CODE_AFTER_COMPLETION", "transaction code cannot be defined after payment has been confirmed or refused")
+	ErrPaymentNotPending                          = errs.NewConflict("PAYMENT.NOT_PENDING", "payment is not in pending status")
+	ErrTransactionCodeNotDefined                  = errs.NewConflict("PAYMENT.TRANSACTION_CODE_NOT_DEFINED", "transaction code has not been defined yet")
+	ErrActivePaymentExists                        = errs.NewConflict("PAYMENT.ACTIVE_EXISTS", "a pending or authorized payment already exists")
+	ErrDuplicateTransactionCode                   = errs.NewConflict("PAYMENT.DUPLICATE_TRANSACTION_CODE", "transaction code is already held by another payment of the order")
+	ErrPaymentNotAuthorized                       = errs.NewConflict("PAYMENT.NOT_AUTHORIZED", "payment is not in authorized status")
+	ErrInvalidChargebackReason                    = errs.New("PAYMENT.INVALID_CHARGEBACK_REASON", "chargeback reason cannot be null or whitespace")
+	ErrTransactionCodeMismatch                    = errs.NewConflict("PAYMENT.TRANSACTION_CODE_MISMATCH", "transaction code differs from the one recorded on the payment")
+	ErrAmountMismatch                             = errs.NewConflict("PAYMENT.AMOUNT_MISMATCH", "payment amount no longer matches the order total")
 )
 
 // Payment is an entity of the Order aggregate that represents a payment transaction.
 // It is created in [StatusPending] and transitions to [StatusAuthorized] or [StatusRefused]
 // via [ConfirmPayment] or [RefusePayment] respectively, after a transaction code has been
-// assigned with [DefineTransactionCode].
+// assigned with [DefineTransactionCode]. Its command methods return
+// [kernel.ErrNilAggregate] instead of panicking when called on a nil *Payment.
 type Payment struct {
-	ID              string
-	OrderID         string
-	Amount          float64 // TODO: create a value object using a more precise type for money
-	Method          Method
-	Status          Status
-	PaidAt          *time.Time
-	UpdatedAt       *time.Time
-	TransactionCode *string
+	kernel.AggregateRoot
+	OrderID          string     `json:"order_id"`
+	Amount           float64    `json:"amount"` // TODO: create a value object using a more precise type for money
+	Method           Method     `json:"method"`
+	Status           Status     `json:"status"`
+	CreatedAt        time.Time  `json:"created_at"`
+	PaidAt           *time.Time `json:"paid_at"`
+	TransactionCode  *string    `json:"transaction_code"`  // masked by [Payment.MarshalJSON]
+	CodeDefinedAt    *time.Time `json:"code_defined_at"`   // when TransactionCode was defined
+	ChargebackAt     *time.Time `json:"chargeback_at"`     // when the bank reversed the payment
+	ChargebackReason string     `json:"chargeback_reason"` // reason given by the bank for the chargeback
 }
 
 // NewPayment creates a new [Payment] for the given order with the specified amount and payment method.
-// orderID must be non-empty and non-whitespace; amount must be strictly positive.
-// The payment is initialized in [StatusPending] with no transaction code assigned.
+// orderID must be non-empty and non-whitespace; amount must be strictly positive, and
+// method must be a defined [Method] other than [MethodFree], enabled in this deployment
+// ([ErrMethodNotEnabled], see [SetEnabledMethods]). The payment is initialized
+// in [StatusPending] with no transaction code assigned, and raises a [CreatedEvent].
 //
 // If multiple fields are invalid, all violations are collected and returned as a
 // single joined error, allowing callers to inspect every failure via [errors.Is].
 func NewPayment(orderID string, amount float64, method Method) (*Payment, error) {
-	// the order ID cannot be null or whitespace, and the amount must be greater than zero.
-	if err := errors.Join(
+	// the order ID cannot be null or whitespace, and the amount must be greater than zero;
+	// zero-amount payments go through NewFreePayment.
+	if err := errs.Join(
 		guard.CheckNotNullOrWhiteSpace(orderID, ErrInvalidOrderID),
 		guard.CheckNotZeroOrNegative(amount, ErrInvalidPaymentAmount),
+		guard.CheckValidEnum(method, ErrInvalidPaymentMethod),
+		checkNotFreeMethod(method),
+		checkMethodEnabled(method),
 	); err != nil {
 		return nil, err
 	}
 
-	return &Payment{
-		ID:      kernel.NewID().String(),
-		OrderID: orderID,
-		Method:  method,
-		Status:  StatusPending,
-		Amount:  amount,
-	}, nil
+	p := &Payment{
+		AggregateRoot: kernel.NewAggregateRoot(),
+		OrderID:       orderID,
+		Method:        method,
+		Status:        StatusPending,
+		Amount:        amount,
+	}
+	created := NewCreatedEvent(p.ID, p.OrderID, p.Amount, p.Method)
+	p.CreatedAt = created.OccurredAt()
+	p.AddDomainEvent(created)
+
+	return p, nil
+}
+
+// NewFreePayment creates a zero-amount [MethodFree] payment for the given order, kept
+// as an accounting record of a free or promotional order. Nothing is charged, so it is
+// authorized right away without a transaction code, raising a [CreatedEvent] followed
+// by an [ApprovedEvent]. orderID must be non-empty and non-whitespace.
+func NewFreePayment(orderID string) (*Payment, error) {
+	if err := guard.CheckNotNullOrWhiteSpace(orderID, ErrInvalidOrderID); err != nil {
+		return nil, err
+	}
+
+	p := &Payment{
+		AggregateRoot: kernel.NewAggregateRoot(),
+		OrderID:       orderID,
+		Method:        MethodFree,
+		Status:        StatusAuthorized,
+	}
+	created := NewCreatedEvent(p.ID, p.OrderID, p.Amount, p.Method)
+	p.CreatedAt = created.OccurredAt()
+	p.AddDomainEvent(created)
+	p.PaidAt = new(p.record(NewApprovedEvent(p.ID, p.OrderID, p.Amount, nil)))
+
+	return p, nil
 }
 
 // ConfirmPayment transitions the payment from [StatusPending] to [StatusAuthorized],
@@ -63,18 +108,20 @@ func NewPayment(orderID string, amount float64, method Method) (*Payment, error)
 // Returns [ErrPaymentNotPending] if the payment is not pending, or
 // [ErrTransactionCodeNotDefined] if no transaction code has been set.
 func (p *Payment) ConfirmPayment() error {
+	if p == nil {
+		return kernel.ErrNilAggregate
+	}
+
 	// the payment can only be confirmed if it is currently pending and has a transaction code defined.
-	if err := errors.Join(
+	if err := errs.Join(
 		p.checkStatusEqual(StatusPending, ErrPaymentNotPending),
 		guard.CheckNotNil(p.TransactionCode, ErrTransactionCodeNotDefined),
 	); err != nil {
 		return err
 	}
 
-	p.PaidAt = new(time.Now().UTC())
 	p.Status = StatusAuthorized
-	p.updateTimestamp()
-	p.AddDomainEvent(ApprovedEvent{}) // TODO: add more details to the event (e.g. order ID, amount, etc.) and test that it is emitted correctly.
+	p.PaidAt = new(p.record(NewApprovedEvent(p.ID, p.OrderID, p.Amount, p.TransactionCode)))
 
 	return nil
 }
@@ -84,8 +131,12 @@ func (p *Payment) ConfirmPayment() error {
 // Returns [ErrPaymentNotPending] if the payment is not pending, or
 // [ErrTransactionCodeNotDefined] if no transaction code has been set.
 func (p *Payment) RefusePayment() error {
+	if p == nil {
+		return kernel.ErrNilAggregate
+	}
+
 	// the payment can only be refused if it is currently pending and has a transaction code defined.
-	if err := errors.Join(
+	if err := errs.Join(
 		p.checkStatusEqual(StatusPending, ErrPaymentNotPending),
 		guard.CheckNotNil(p.TransactionCode, ErrTransactionCodeNotDefined),
 	); err != nil {
@@ -93,41 +144,172 @@ func (p *Payment) RefusePayment() error {
 	}
 
 	p.Status = StatusRefused
-	p.updateTimestamp()
-	p.AddDomainEvent(RefusedEvent{}) // TODO: add more details to the event (e.g. order ID, amount, etc.) and test that it is emitted correctly.
+	p.record(NewRefusedEvent(p.ID, p.OrderID, p.Amount, p.TransactionCode))
+
+	return nil
+}
+
+// RefundPayment transitions the payment from [StatusAuthorized] to [StatusRefunded],
+// refreshing UpdatedAt. Returns [ErrPaymentNotAuthorized] if the payment is not authorized.
+func (p *Payment) RefundPayment() error {
+	if p == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if err := p.checkStatusEqual(StatusAuthorized, ErrPaymentNotAuthorized); err != nil {
+		return err
+	}
+
+	p.Status = StatusRefunded
+	p.record(NewRefundedEvent(p.ID, p.OrderID, p.Amount, p.TransactionCode))
+
+	return nil
+}
+
+// Chargeback transitions the payment from [StatusAuthorized] to [StatusChargedBack] when
+// the bank reverses it, recording the current UTC time as ChargebackAt together with
+// reason, and refreshing UpdatedAt. Unlike [Payment.RefundPayment], it is initiated by
+// the bank rather than the merchant. Returns [ErrPaymentNotAuthorized] if the payment is
+// not authorized, or [ErrInvalidChargebackReason] if reason is blank.
+func (p *Payment) Chargeback(reason string) error {
+	if p == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if err := errs.Join(
+		p.checkStatusEqual(StatusAuthorized, ErrPaymentNotAuthorized),
+		guard.CheckNotNullOrWhiteSpace(reason, ErrInvalidChargebackReason),
+	); err != nil {
+		return err
+	}
+
+	p.Status = StatusChargedBack
+	p.ChargebackReason = reason
+	p.ChargebackAt = new(p.record(NewChargebackEvent(p.ID, p.OrderID, p.Amount, p.TransactionCode, reason)))
+
+	return nil
+}
+
+// CancelPayment transitions the payment from [StatusPending] to [StatusCancelled] before
+// it completes, refreshing UpdatedAt. Returns [ErrPaymentNotPending] if the payment is
+// not pending.
+func (p *Payment) CancelPayment() error {
+	if p == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	if err := p.checkStatusEqual(StatusPending, ErrPaymentNotPending); err != nil {
+		return err
+	}
+
+	p.Status = StatusCancelled
+	p.record(NewCancelledEvent(p.ID, p.OrderID, p.Amount))
 
 	return nil
 }
 
 // DefineTransactionCode assigns the external transaction code returned by the payment gateway.
-// code must be non-empty and non-whitespace.
+// code must be non-empty and non-whitespace and match [TransactionCodePattern] when set.
 // Returns [ErrCannotDefineTransactionCodeAfterCompletion] if the payment is no longer pending,
 // [ErrTransactionCodeAlreadyDefined] if a code has already been set, or
-// [ErrInvalidTransactionCode] if code is blank.
+// [ErrInvalidTransactionCode] if code is blank or malformed.
 func (p *Payment) DefineTransactionCode(code string) error {
-	// validate that the code is not null or whitespace, that no code has been defined yet,
-	// and that the payment is pending (i.e. not already approved or refused).
-	if err := errors.Join(
+	if p == nil {
+		return kernel.ErrNilAggregate
+	}
+
+	return p.defineTransactionCode(code, true)
+}
+
+// defineTransactionCode implements [Payment.DefineTransactionCode]; checkFormat is false
+// only for locally generated codes, which need not follow the gateway format.
+func (p *Payment) defineTransactionCode(code string, checkFormat bool) error {
+	var formatErr error
+	if checkFormat {
+		formatErr = checkTransactionCodeFormat(code)
+	}
+
+	// validate that the code is not null or whitespace and well formed, that no code has
+	// been defined yet, and that the payment is pending (i.e. not already approved or refused).
+	if err := errs.Join(
 		p.checkStatusEqual(StatusPending, ErrCannotDefineTransactionCodeAfterCompletion),
 		guard.CheckNotNullOrWhiteSpace(code, ErrInvalidTransactionCode),
+		formatErr,
 		guard.CheckNil(p.TransactionCode, ErrTransactionCodeAlreadyDefined),
 	); err != nil {
 		return err
 	}
 
 	p.TransactionCode = &code
-	p.updateTimestamp()
+	p.CodeDefinedAt = new(p.record(NewTransactionCodeDefinedEvent(p.ID, p.OrderID, code)))
 
 	return nil
 }
 
-// AddDomainEvent registers a payment domain event (stub; implementation pending).
-func (p *Payment) AddDomainEvent(event kernel.DomainEvent) {
-	// TODO: implement and test...
+// TransactionCodeValue returns the transaction code and whether it has been defined,
+// sparing callers from dereferencing TransactionCode after a nil check.
+func (p *Payment) TransactionCodeValue() (string, bool) {
+	if p.TransactionCode == nil {
+		return "", false
+	}
+	return *p.TransactionCode, true
 }
 
-func (p *Payment) updateTimestamp() {
-	p.UpdatedAt = new(time.Now().UTC())
+// TimeToAuthorize returns how long the payment took from creation to authorization,
+// PaidAt − CreatedAt, and whether it was ever authorized. A refunded payment keeps
+// reporting the duration of its original authorization.
+func (p *Payment) TimeToAuthorize() (time.Duration, bool) {
+	if p.PaidAt == nil {
+		return 0, false
+	}
+	return p.PaidAt.Sub(p.CreatedAt), true
+}
+
+// IsAuthorizationExpired reports whether the payment is still pending more than window
+// after its transaction code was defined at now, i.e. the gateway took the payment but
+// never confirmed nor refused it in time. A payment without a code has not reached the
+// gateway yet and never expires.
+func (p *Payment) IsAuthorizationExpired(now time.Time, window time.Duration) bool {
+	if !p.Status.Equals(StatusPending) || p.CodeDefinedAt == nil {
+		return false
+	}
+	return now.Sub(*p.CodeDefinedAt) > window
+}
+
+// IsActive reports whether the payment is still pending or has been authorized, i.e. it
+// has neither failed nor been undone.
+func (p *Payment) IsActive() bool {
+	return p.Status.Equals(StatusPending) || p.Status.Equals(StatusAuthorized)
+}
+
+// Describe returns a one-line summary of the payment for support tooling, e.g.
+// "Payment <id> for order <orderID>: R$ 100,00 via credit_card — authorized (TXN-123)".
+// The amount is written by [money.Format] in the default locale and the transaction code,
+// shown once defined, is not masked, so the summary is for internal use only.
+func (p *Payment) Describe() string {
+	s := fmt.Sprintf("Payment %s for order %s: %s via %s — %s",
+		p.ID, p.OrderID, money.Format(p.Amount, ""), p.Method, p.Status)
+	if code, ok := p.TransactionCodeValue(); ok {
+		s += " (" + code + ")"
+	}
+	return s
+}
+
+// record raises event and stamps UpdatedAt with the time it occurred, returning that
+// time for the command to stamp its own fields with. Taking every timestamp from the
+// event keeps the state a command leaves identical to what [FromEvents] rebuilds.
+func (p *Payment) record(event kernel.DomainEvent) time.Time {
+	at := event.OccurredAt()
+	p.UpdatedAt = new(at)
+	p.AddDomainEvent(event)
+	return at
+}
+
+func checkNotFreeMethod(method Method) error {
+	if method.Equals(MethodFree) {
+		return ErrInvalidPaymentMethod
+	}
+	return nil
 }
 
 func (p *Payment) checkStatusEqual(other Status, err error) error {
@@ -142,6 +324,7 @@ func (p *Payment) generateTransactionCode() {
 		return
 	}
 
+	// local codes are not issued by a gateway, so they bypass TransactionCodePattern.
 	c := "LOCAL-" + kernel.NewID().String()[:8] // TODO: reimplement
-	_ = p.DefineTransactionCode(c)
+	_ = p.defineTransactionCode(c, false)
 }
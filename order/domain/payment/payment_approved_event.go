@@ -1,10 +1,6 @@
 package payment
 
-import (
-	"time"
-
-	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
-)
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
 
 // ApprovedEvent represents the event when a payment is approved.
 type ApprovedEvent struct {
@@ -16,11 +12,11 @@ type ApprovedEvent struct {
 }
 
 // NewApprovedEvent constructs an ApprovedEvent with the current UTC timestamp.
-func NewApprovedEvent(paymentID, orderID string, amount float64, transactionCode *string) RefusedEvent {
-	return RefusedEvent{
+func NewApprovedEvent(paymentID, orderID string, amount float64, transactionCode *string) ApprovedEvent {
+	return ApprovedEvent{
 		Event: kernel.Event{
-			ID:           kernel.NewID().String(),
-			DateOccurred: time.Now().UTC(),
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
 		},
 		PaymentID:       paymentID,
 		OrderID:         orderID,
@@ -28,3 +24,9 @@ func NewApprovedEvent(paymentID, orderID string, amount float64, transactionCode
 		TransactionCode: transactionCode,
 	}
 }
+
+// Name returns "payment.approved", the stable name of the event, which does not
+// change when the Go type is renamed.
+func (ApprovedEvent) Name() string {
+	return "payment.approved"
+}
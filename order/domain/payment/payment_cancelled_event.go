@@ -0,0 +1,30 @@
+package payment
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+
+// CancelledEvent represents the event when a pending payment is cancelled before completion.
+type CancelledEvent struct {
+	kernel.Event
+	PaymentID string  `json:"payment_id"`
+	OrderID   string  `json:"order_id"`
+	Amount    float64 `json:"amount"`
+}
+
+// NewCancelledEvent constructs a CancelledEvent with the current UTC timestamp.
+func NewCancelledEvent(paymentID, orderID string, amount float64) CancelledEvent {
+	return CancelledEvent{
+		Event: kernel.Event{
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
+		},
+		PaymentID: paymentID,
+		OrderID:   orderID,
+		Amount:    amount,
+	}
+}
+
+// Name returns "payment.cancelled", the stable name of the event, which does not
+// change when the Go type is renamed.
+func (CancelledEvent) Name() string {
+	return "payment.cancelled"
+}
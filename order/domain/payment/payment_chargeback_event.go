@@ -0,0 +1,34 @@
+package payment
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+
+// ChargebackEvent represents the event when the bank reverses an authorized payment.
+type ChargebackEvent struct {
+	kernel.Event
+	PaymentID       string  `json:"payment_id"`
+	OrderID         string  `json:"order_id"`
+	Amount          float64 `json:"amount"`
+	TransactionCode *string `json:"transaction_code"`
+	Reason          string  `json:"reason"`
+}
+
+// NewChargebackEvent constructs a ChargebackEvent with the current UTC timestamp.
+func NewChargebackEvent(paymentID, orderID string, amount float64, transactionCode *string, reason string) ChargebackEvent {
+	return ChargebackEvent{
+		Event: kernel.Event{
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
+		},
+		PaymentID:       paymentID,
+		OrderID:         orderID,
+		Amount:          amount,
+		TransactionCode: transactionCode,
+		Reason:          reason,
+	}
+}
+
+// Name returns "payment.chargeback", the stable name of the event, which does not
+// change when the Go type is renamed.
+func (ChargebackEvent) Name() string {
+	return "payment.chargeback"
+}
@@ -0,0 +1,32 @@
+package payment
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+
+// CreatedEvent represents the event when a payment is created.
+type CreatedEvent struct {
+	kernel.Event
+	PaymentID string  `json:"payment_id"`
+	OrderID   string  `json:"order_id"`
+	Amount    float64 `json:"amount"`
+	Method    Method  `json:"method"`
+}
+
+// NewCreatedEvent constructs a CreatedEvent with the current UTC timestamp.
+func NewCreatedEvent(paymentID, orderID string, amount float64, method Method) CreatedEvent {
+	return CreatedEvent{
+		Event: kernel.Event{
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
+		},
+		PaymentID: paymentID,
+		OrderID:   orderID,
+		Amount:    amount,
+		Method:    method,
+	}
+}
+
+// Name returns "payment.created", the stable name of the event, which does not
+// change when the Go type is renamed.
+func (CreatedEvent) Name() string {
+	return "payment.created"
+}
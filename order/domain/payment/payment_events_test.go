@@ -0,0 +1,83 @@
+package payment_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countEvents tallies events by their dynamic type name, e.g. "payment.ApprovedEvent".
+func countEvents(events []kernel.DomainEvent) map[string]int {
+	counts := make(map[string]int)
+	for _, e := range events {
+		counts[reflect.TypeOf(e).String()]++
+	}
+	return counts
+}
+
+// This test ensures every Payment transition raises exactly one event of its own type
+// and that repeating the transition, which then fails, raises nothing.
+func TestPayment_TransitionEventsEmittedOnce(t *testing.T) {
+	authorized := func(t *testing.T) *payment.Payment {
+		t.Helper()
+		p := createPaymentWithCode(t)
+		require.NoError(t, p.ConfirmPayment())
+		return p
+	}
+
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T) *payment.Payment
+		transition func(p *payment.Payment) error
+		wantEvent  string
+	}{
+		{name: "DefineTransactionCode", setup: createValidPayment, transition: func(p *payment.Payment) error { return p.DefineTransactionCode("TXN-123") }, wantEvent: "payment.TransactionCodeDefinedEvent"},
+		{name: "ConfirmPayment", setup: createPaymentWithCode, transition: (*payment.Payment).ConfirmPayment, wantEvent: "payment.ApprovedEvent"},
+		{name: "RefusePayment", setup: createPaymentWithCode, transition: (*payment.Payment).RefusePayment, wantEvent: "payment.RefusedEvent"},
+		{name: "RefundPayment", setup: authorized, transition: (*payment.Payment).RefundPayment, wantEvent: "payment.RefundedEvent"},
+		{name: "Chargeback", setup: authorized, transition: func(p *payment.Payment) error { return p.Chargeback("fraud claim") }, wantEvent: "payment.ChargebackEvent"},
+		{name: "CancelPayment", setup: createValidPayment, transition: (*payment.Payment).CancelPayment, wantEvent: "payment.CancelledEvent"},
+	}
+	for _, tt := range tests {
+		t.Run("should emit exactly one event for "+tt.name, func(t *testing.T) {
+			p := tt.setup(t)
+			p.PullDomainEvents()
+
+			require.NoError(t, tt.transition(p))
+			assert.Equal(t, map[string]int{tt.wantEvent: 1}, countEvents(p.PullDomainEvents()))
+
+			require.Error(t, tt.transition(p), "repeating the transition should fail")
+			assert.Empty(t, p.PullDomainEvents(), "a failed transition should emit nothing")
+		})
+	}
+
+	t.Run("should emit exactly one event for NewPayment", func(t *testing.T) {
+		p := createValidPayment(t)
+
+		assert.Equal(t, map[string]int{"payment.CreatedEvent": 1}, countEvents(p.PullDomainEvents()))
+	})
+}
+
+func TestPaymentEvents_Name(t *testing.T) {
+	tests := []struct {
+		event interface{ Name() string }
+		want  string
+	}{
+		{event: payment.CreatedEvent{}, want: "payment.created"},
+		{event: payment.TransactionCodeDefinedEvent{}, want: "payment.transaction_code_defined"},
+		{event: payment.ApprovedEvent{}, want: "payment.approved"},
+		{event: payment.RefusedEvent{}, want: "payment.refused"},
+		{event: payment.RefundedEvent{}, want: "payment.refunded"},
+		{event: payment.ChargebackEvent{}, want: "payment.chargeback"},
+		{event: payment.CancelledEvent{}, want: "payment.cancelled"},
+	}
+	for _, tt := range tests {
+		t.Run("should name "+reflect.TypeOf(tt.event).Name()+" "+tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.event.Name())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package payment
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// visibleCodeChars is how many trailing characters of the transaction code remain
+// readable in client responses.
+const visibleCodeChars = 4
+
+// paymentJSON is the wire shape shared by the client and persistence serializers.
+type paymentJSON struct {
+	ID               string     `json:"id"`
+	OrderID          string     `json:"order_id"`
+	Amount           float64    `json:"amount"`
+	Method           Method     `json:"method"`
+	Status           Status     `json:"status"`
+	TransactionCode  *string    `json:"transaction_code"`
+	CodeDefinedAt    *time.Time `json:"code_defined_at"`
+	ChargebackAt     *time.Time `json:"chargeback_at"`
+	ChargebackReason string     `json:"chargeback_reason"`
+	CreatedAt        time.Time  `json:"created_at"`
+	PaidAt           *time.Time `json:"paid_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+}
+
+// MarshalJSON serializes the payment for client responses, masking all but the last
+// four characters of the transaction code. Use [Payment.MarshalPersistence] to store
+// the code in full.
+func (p Payment) MarshalJSON() ([]byte, error) {
+	v := p.toJSON()
+	if code, ok := p.TransactionCodeValue(); ok {
+		v.TransactionCode = new(maskTransactionCode(code))
+	}
+	return json.Marshal(v)
+}
+
+// MarshalPersistence serializes the payment with the full transaction code, for
+// internal storage only.
+func (p Payment) MarshalPersistence() ([]byte, error) {
+	return json.Marshal(p.toJSON())
+}
+
+func (p Payment) toJSON() paymentJSON {
+	return paymentJSON{
+		ID:               p.ID,
+		OrderID:          p.OrderID,
+		Amount:           p.Amount,
+		Method:           p.Method,
+		Status:           p.Status,
+		TransactionCode:  p.TransactionCode,
+		CodeDefinedAt:    p.CodeDefinedAt,
+		ChargebackAt:     p.ChargebackAt,
+		ChargebackReason: p.ChargebackReason,
+		CreatedAt:        p.CreatedAt,
+		PaidAt:           p.PaidAt,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
+
+// maskTransactionCode replaces every character but the last four with '*'. Codes of
+// four characters or fewer are masked entirely.
+func maskTransactionCode(code string) string {
+	runes := []rune(code)
+	if len(runes) <= visibleCodeChars {
+		return strings.Repeat("*", len(runes))
+	}
+	hidden := len(runes) - visibleCodeChars
+	return strings.Repeat("*", hidden) + string(runes[hidden:])
+}
@@ -0,0 +1,88 @@
+package payment_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayment_MarshalJSON(t *testing.T) {
+	t.Run("should mask all but the last four characters of the transaction code", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+
+		got, err := json.Marshal(p)
+
+		require.NoError(t, err)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(got, &body))
+		assert.Equal(t, "***-123", body["transaction_code"])
+		assert.Equal(t, "order-123", body["order_id"])
+		assert.Equal(t, "credit_card", body["method"])
+		assert.Equal(t, "pending", body["status"])
+	})
+
+	t.Run("should mask a short transaction code entirely", func(t *testing.T) {
+		p := createValidPayment(t)
+		require.NoError(t, p.DefineTransactionCode("T12"))
+
+		got, err := json.Marshal(p)
+
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `"transaction_code":"***"`)
+	})
+
+	t.Run("should serialize an undefined transaction code as null", func(t *testing.T) {
+		p := createValidPayment(t)
+
+		got, err := json.Marshal(p)
+
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `"transaction_code":null`)
+	})
+}
+
+func TestPayment_MarshalPersistence(t *testing.T) {
+	t.Run("should keep the full transaction code", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+
+		got, err := p.MarshalPersistence()
+
+		require.NoError(t, err)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(got, &body))
+		assert.Equal(t, "TXN-123", body["transaction_code"])
+		assert.Equal(t, p.ID, body["id"])
+	})
+
+	t.Run("should not alter the payment transaction code", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+
+		_, err := json.Marshal(p)
+
+		require.NoError(t, err)
+		code, _ := p.TransactionCodeValue()
+		assert.Equal(t, "TXN-123", code)
+	})
+}
+
+func TestPayment_JSONGolden(t *testing.T) {
+	p := testfixtures.ValidPayment(t, testfixtures.WithTransactionCode("TXN-0001-ABCD"))
+	require.NoError(t, p.ConfirmPayment())
+
+	t.Run("should match the client response shape", func(t *testing.T) {
+		got, err := json.Marshal(p)
+
+		require.NoError(t, err)
+		testfixtures.Golden(t, "payment_client", got)
+	})
+
+	t.Run("should match the persistence shape", func(t *testing.T) {
+		got, err := p.MarshalPersistence()
+
+		require.NoError(t, err)
+		testfixtures.Golden(t, "payment_persistence", got)
+	})
+}
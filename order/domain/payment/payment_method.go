@@ -15,6 +15,7 @@ var (
 	MethodPix          = Method{4} // MethodPix represents payment via Pix instant transfer.
 	MethodBankTransfer = Method{5} // MethodBankTransfer represents payment via bank transfer (TED/DOC).
 	MethodBancSlip     = Method{6} // MethodBancSlip represents payment via bank slip (boleto bancário).
+	MethodFree         = Method{7} // MethodFree represents a zero-amount payment recorded for free orders (see NewFreePayment).
 )
 
 // methodToString maps Method values to their string representations.
@@ -25,6 +26,7 @@ var methodToString = map[Method]string{
 	MethodPix:          "pix",
 	MethodBankTransfer: "bank_transfer",
 	MethodBancSlip:     "banc_slip",
+	MethodFree:         "free",
 }
 
 // String returns the string representation of the Method.
@@ -40,13 +42,32 @@ func (m Method) MarshalText() ([]byte, error) {
 	return []byte(m.String()), nil
 }
 
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidPaymentMethod] and leave m unchanged instead of decoding to an uninitialized Method.
+func (m *Method) UnmarshalText(text []byte) error {
+	for v, str := range methodToString {
+		if str == string(text) {
+			*m = v
+			return nil
+		}
+	}
+	return ErrInvalidPaymentMethod
+}
+
 // Equals checks if two Method values are equal.
 func (m Method) Equals(other Method) bool {
 	return m.value == other.value
 }
 
+// IsValid reports whether the Method is one of the defined values; the zero value is not.
+func (m Method) IsValid() bool {
+	_, ok := methodToString[m]
+	return ok
+}
+
 // ParseMethod converts an int to the corresponding Method value.
-// If the input does not match any known method, it returns an error and an empty Method value.
+// If the input does not match any known method, it returns an error and an empty Method value;
+// 0, the uninitialized value, is always rejected.
 func ParseMethod(value int) (Method, error) {
 	m := Method{value}
 	if _, ok := methodToString[m]; !ok {
@@ -21,6 +21,7 @@ func TestMethod_String(t *testing.T) {
 		{name: "should return 'pix' for MethodPix", method: payment.MethodPix, want: "pix"},
 		{name: "should return 'bank_transfer' for MethodBankTransfer", method: payment.MethodBankTransfer, want: "bank_transfer"},
 		{name: "should return 'banc_slip' for MethodBancSlip", method: payment.MethodBancSlip, want: "banc_slip"},
+		{name: "should return 'free' for MethodFree", method: payment.MethodFree, want: "free"},
 		// ==================== Failure cases ==================== //
 		{name: "should return 'unknown' for zero value (uninitialized)", method: payment.Method{}, want: "unknown"},
 	}
@@ -55,6 +56,48 @@ func TestMethod_MarshalText(t *testing.T) {
 	}
 }
 
+func TestMethod_UnmarshalText(t *testing.T) {
+	// ==================== Success cases ==================== //
+	successTests := []struct {
+		name string
+		text string
+		want payment.Method
+	}{
+		{name: "should unmarshal 'credit_card' to MethodCreditCard", text: "credit_card", want: payment.MethodCreditCard},
+		{name: "should unmarshal 'cash' to MethodCash", text: "cash", want: payment.MethodCash},
+	}
+	for _, tt := range successTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got payment.Method
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	failureTests := []struct {
+		name string
+		text string
+	}{
+		{name: "should return an error for a misspelled method", text: "credit_card_typo"},
+		{name: "should return an error for the 'unknown' placeholder", text: "unknown"},
+		{name: "should return an error for an empty string", text: ""},
+	}
+	for _, tt := range failureTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := payment.MethodCash
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			assert.ErrorIs(t, err, payment.ErrInvalidPaymentMethod)
+			assert.Equal(t, payment.MethodCash, got, "value should be unchanged")
+		})
+	}
+}
+
 func TestMethod_Equals(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -91,6 +134,7 @@ func TestParseMethod(t *testing.T) {
 		{name: "should parse 4 to MethodPix", value: 4, wantMethod: payment.MethodPix},
 		{name: "should parse 5 to MethodBankTransfer", value: 5, wantMethod: payment.MethodBankTransfer},
 		{name: "should parse 6 to MethodBancSlip", value: 6, wantMethod: payment.MethodBancSlip},
+		{name: "should parse 7 to MethodFree", value: 7, wantMethod: payment.MethodFree},
 	}
 	for _, tt := range successTests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,32 @@
+package payment
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+
+// RefundedEvent represents the event when an authorized payment is refunded.
+type RefundedEvent struct {
+	kernel.Event
+	PaymentID       string  `json:"payment_id"`
+	OrderID         string  `json:"order_id"`
+	Amount          float64 `json:"amount"`
+	TransactionCode *string `json:"transaction_code"`
+}
+
+// NewRefundedEvent constructs a RefundedEvent with the current UTC timestamp.
+func NewRefundedEvent(paymentID, orderID string, amount float64, transactionCode *string) RefundedEvent {
+	return RefundedEvent{
+		Event: kernel.Event{
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
+		},
+		PaymentID:       paymentID,
+		OrderID:         orderID,
+		Amount:          amount,
+		TransactionCode: transactionCode,
+	}
+}
+
+// Name returns "payment.refunded", the stable name of the event, which does not
+// change when the Go type is renamed.
+func (RefundedEvent) Name() string {
+	return "payment.refunded"
+}
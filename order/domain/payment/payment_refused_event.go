@@ -1,10 +1,6 @@
 package payment
 
-import (
-	"time"
-
-	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
-)
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
 
 // RefusedEvent represents the event when a payment is refused.
 type RefusedEvent struct {
@@ -19,8 +15,8 @@ type RefusedEvent struct {
 func NewRefusedEvent(paymentID, orderID string, amount float64, transactionCode *string) RefusedEvent {
 	return RefusedEvent{
 		Event: kernel.Event{
-			ID:           kernel.NewID().String(),
-			DateOccurred: time.Now().UTC(),
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
 		},
 		PaymentID:       paymentID,
 		OrderID:         orderID,
@@ -28,3 +24,9 @@ func NewRefusedEvent(paymentID, orderID string, amount float64, transactionCode
 		TransactionCode: transactionCode,
 	}
 }
+
+// Name returns "payment.refused", the stable name of the event, which does not
+// change when the Go type is renamed.
+func (RefusedEvent) Name() string {
+	return "payment.refused"
+}
@@ -9,20 +9,22 @@ type Status struct{ value int }
 
 // Define vars for each payment status, starting from 1 to avoid the zero value which can be used as a default or uninitialized state.
 var (
-	StatusPending    = Status{1} // StatusPending is the initial state; payment is awaiting processing.
-	StatusAuthorized = Status{2} // StatusAuthorized indicates the payment was successfully confirmed.
-	StatusRefused    = Status{3} // StatusRefused indicates the payment was declined by the gateway.
-	StatusRefunded   = Status{4} // StatusRefunded indicates a previously authorized payment was refunded.
-	StatusCancelled  = Status{5} // StatusCancelled indicates the payment was cancelled before completion.
+	StatusPending     = Status{1} // StatusPending is the initial state; payment is awaiting processing.
+	StatusAuthorized  = Status{2} // StatusAuthorized indicates the payment was successfully confirmed.
+	StatusRefused     = Status{3} // StatusRefused indicates the payment was declined by the gateway.
+	StatusRefunded    = Status{4} // StatusRefunded indicates a previously authorized payment was refunded.
+	StatusCancelled   = Status{5} // StatusCancelled indicates the payment was cancelled before completion.
+	StatusChargedBack = Status{6} // StatusChargedBack indicates the bank reversed a previously authorized payment.
 )
 
 // statusToString maps Status values to their string representations.
 var statusToString = map[Status]string{
-	StatusPending:    "pending",
-	StatusAuthorized: "authorized",
-	StatusRefused:    "refused",
-	StatusRefunded:   "refunded",
-	StatusCancelled:  "cancelled",
+	StatusPending:     "pending",
+	StatusAuthorized:  "authorized",
+	StatusRefused:     "refused",
+	StatusRefunded:    "refunded",
+	StatusCancelled:   "cancelled",
+	StatusChargedBack: "charged_back",
 }
 
 // String returns the string representation of the Status.
@@ -38,13 +40,32 @@ func (s Status) MarshalText() ([]byte, error) {
 	return []byte(s.String()), nil
 }
 
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidPaymentStatus] and leave s unchanged instead of decoding to an uninitialized Status.
+func (s *Status) UnmarshalText(text []byte) error {
+	for v, str := range statusToString {
+		if str == string(text) {
+			*s = v
+			return nil
+		}
+	}
+	return ErrInvalidPaymentStatus
+}
+
 // Equals checks if two Status values are equal.
 func (s Status) Equals(other Status) bool {
 	return s.value == other.value
 }
 
+// IsValid reports whether the Status is one of the defined values; the zero value is not.
+func (s Status) IsValid() bool {
+	_, ok := statusToString[s]
+	return ok
+}
+
 // ParseStatus converts an int to the corresponding Status value.
-// If the input does not match any known status, it returns an error and an empty Status value.
+// If the input does not match any known status, it returns an error and an empty Status value;
+// 0, the uninitialized value, is always rejected.
 func ParseStatus(value int) (Status, error) {
 	s := Status{value}
 	if _, ok := statusToString[s]; !ok {
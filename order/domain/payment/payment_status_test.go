@@ -20,6 +20,7 @@ func TestStatus_String(t *testing.T) {
 		{name: "should return 'refused' for StatusRefused", status: payment.StatusRefused, want: "refused"},
 		{name: "should return 'refunded' for StatusRefunded", status: payment.StatusRefunded, want: "refunded"},
 		{name: "should return 'cancelled' for StatusCancelled", status: payment.StatusCancelled, want: "cancelled"},
+		{name: "should return 'charged_back' for StatusChargedBack", status: payment.StatusChargedBack, want: "charged_back"},
 		// ==================== Failure cases ==================== //
 		{name: "should return 'unknown' for an unrecognized status value", status: payment.Status{}, want: "unknown"},
 	}
@@ -54,6 +55,49 @@ func TestStatus_MarshalText(t *testing.T) {
 	}
 }
 
+func TestStatus_UnmarshalText(t *testing.T) {
+	// ==================== Success cases ==================== //
+	successTests := []struct {
+		name string
+		text string
+		want payment.Status
+	}{
+		{name: "should unmarshal 'authorized' to StatusAuthorized", text: "authorized", want: payment.StatusAuthorized},
+		{name: "should unmarshal 'pending' to StatusPending", text: "pending", want: payment.StatusPending},
+		{name: "should unmarshal 'charged_back' to StatusChargedBack", text: "charged_back", want: payment.StatusChargedBack},
+	}
+	for _, tt := range successTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got payment.Status
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	failureTests := []struct {
+		name string
+		text string
+	}{
+		{name: "should return an error for a misspelled status", text: "authorized_typo"},
+		{name: "should return an error for the 'unknown' placeholder", text: "unknown"},
+		{name: "should return an error for an empty string", text: ""},
+	}
+	for _, tt := range failureTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := payment.StatusPending
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			assert.ErrorIs(t, err, payment.ErrInvalidPaymentStatus)
+			assert.Equal(t, payment.StatusPending, got, "value should be unchanged")
+		})
+	}
+}
+
 func TestStatus_Equals(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -89,6 +133,7 @@ func TestParseStatus(t *testing.T) {
 		{name: "should parse 3 to StatusRefused", value: 3, wantStatus: payment.StatusRefused},
 		{name: "should parse 4 to StatusRefunded", value: 4, wantStatus: payment.StatusRefunded},
 		{name: "should parse 5 to StatusCancelled", value: 5, wantStatus: payment.StatusCancelled},
+		{name: "should parse 6 to StatusChargedBack", value: 6, wantStatus: payment.StatusChargedBack},
 	}
 	for _, tt := range successTests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -105,6 +150,7 @@ func TestParseStatus(t *testing.T) {
 		value   int
 		wantErr error
 	}{
+		{name: "should return an error for zero value (uninitialized)", value: 0, wantErr: payment.ErrInvalidPaymentStatus},
 		{name: "should return an error for a negative value", value: -1, wantErr: payment.ErrInvalidPaymentStatus},
 		{name: "should return an error for an out-of-range value", value: 999, wantErr: payment.ErrInvalidPaymentStatus},
 	}
@@ -1,12 +1,15 @@
 package payment_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
 	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -34,9 +37,9 @@ func TestNewPayment(t *testing.T) {
 			Method:  payment.MethodCreditCard,
 			Status:  payment.StatusPending,
 		}
-		ignoreFields := cmpopts.IgnoreFields(payment.Payment{}, "ID") // ignore ID since it's generated and not predictable
+		ignoreFields := cmpopts.IgnoreFields(payment.Payment{}, "AggregateRoot", "CreatedAt") // ignore the embedded AggregateRoot and CreatedAt since they are generated and not predictable
 		equatable := cmpopts.EquateComparable(payment.Method{}, payment.Status{})
-		assert.True(t, cmp.Equal(got, want, ignoreFields, equatable), "got and want should be equal ignoring ID: %v", cmp.Diff(got, want, ignoreFields, equatable))
+		assert.True(t, cmp.Equal(got, want, ignoreFields, equatable), "got and want should be equal ignoring AggregateRoot and CreatedAt: %v", cmp.Diff(got, want, ignoreFields, equatable))
 	})
 
 	t.Run("should return an error when invalid input is provided", func(t *testing.T) {
@@ -70,6 +73,16 @@ func TestNewPayment(t *testing.T) {
 				args:    args{orderID: "order-123", amount: -10.0, method: payment.MethodCreditCard},
 				wantErr: payment.ErrInvalidPaymentAmount,
 			},
+			{
+				name:    "should return an error when method is uninitialized",
+				args:    args{orderID: "order-123", amount: 100.0, method: payment.Method{}},
+				wantErr: payment.ErrInvalidPaymentMethod,
+			},
+			{
+				name:    "should return an error when method is MethodFree",
+				args:    args{orderID: "order-123", amount: 100.0, method: payment.MethodFree},
+				wantErr: payment.ErrInvalidPaymentMethod,
+			},
 			{
 				name:    "should return an error for invalid order ID when both fields are invalid",
 				args:    args{orderID: "", amount: 0.0, method: payment.MethodCreditCard},
@@ -162,6 +175,34 @@ func TestPayment_DefineTransactionCode(t *testing.T) {
 	})
 }
 
+func TestNewFreePayment(t *testing.T) {
+	t.Run("should create a zero-amount payment that is confirmed immediately", func(t *testing.T) {
+		got, err := payment.NewFreePayment("order-123")
+
+		require.NoError(t, err)
+		assert.Equal(t, "order-123", got.OrderID)
+		assert.Equal(t, 0.0, got.Amount)
+		assert.Equal(t, payment.MethodFree, got.Method)
+		assert.Equal(t, payment.StatusAuthorized, got.Status)
+		assert.NotNil(t, got.PaidAt, "PaidAt should be set")
+		assert.Nil(t, got.TransactionCode, "no transaction code should be required")
+		events := got.PullDomainEvents()
+		require.Len(t, events, 2)
+		assert.IsType(t, payment.CreatedEvent{}, events[0])
+		approved, ok := events[1].(payment.ApprovedEvent)
+		require.True(t, ok, "second event should be an ApprovedEvent")
+		assert.Equal(t, got.ID, approved.PaymentID)
+		assert.Equal(t, 0.0, approved.Amount)
+	})
+
+	t.Run("should return an error when order ID is blank", func(t *testing.T) {
+		got, err := payment.NewFreePayment("  ")
+
+		assert.ErrorIs(t, err, payment.ErrInvalidOrderID)
+		assert.Nil(t, got)
+	})
+}
+
 func TestPayment_ConfirmPayment(t *testing.T) {
 	t.Run("should successfully confirm payment when transaction code has been defined", func(t *testing.T) {
 		p := createValidPayment(t)
@@ -262,3 +303,368 @@ func TestPayment_RefusePayment(t *testing.T) {
 		}
 	})
 }
+
+func TestPayment_RefundPayment(t *testing.T) {
+	t.Run("should transition an authorized payment to Refunded", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+		require.NoError(t, p.ConfirmPayment())
+
+		err := p.RefundPayment()
+
+		require.NoError(t, err)
+		assert.Equal(t, payment.StatusRefunded, p.Status)
+	})
+
+	t.Run("should return an error when payment is not authorized", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+
+		err := p.RefundPayment()
+
+		assert.ErrorIs(t, err, payment.ErrPaymentNotAuthorized)
+		assert.Equal(t, payment.StatusPending, p.Status, "status should be unchanged")
+	})
+}
+
+func TestPayment_Chargeback(t *testing.T) {
+	t.Run("should transition an authorized payment to ChargedBack", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+		require.NoError(t, p.ConfirmPayment())
+		p.PullDomainEvents()
+
+		err := p.Chargeback("fraud claim")
+
+		require.NoError(t, err)
+		assert.Equal(t, payment.StatusChargedBack, p.Status)
+		assert.Equal(t, "fraud claim", p.ChargebackReason)
+		assert.NotNil(t, p.ChargebackAt)
+		assert.False(t, p.IsActive(), "a charged back payment should not be active")
+		events := p.PullDomainEvents()
+		require.Len(t, events, 1)
+		chargeback, ok := events[0].(payment.ChargebackEvent)
+		require.True(t, ok, "event should be a ChargebackEvent")
+		assert.Equal(t, p.ID, chargeback.PaymentID)
+		assert.Equal(t, "fraud claim", chargeback.Reason)
+	})
+
+	t.Run("should return an error when payment is not authorized", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			setup func(p *payment.Payment) error
+		}{
+			{name: "pending", setup: func(*payment.Payment) error { return nil }},
+			{name: "refused", setup: (*payment.Payment).RefusePayment},
+			{name: "refunded", setup: func(p *payment.Payment) error {
+				return errors.Join(p.ConfirmPayment(), p.RefundPayment())
+			}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				p := createPaymentWithCode(t)
+				require.NoError(t, tt.setup(p))
+				status := p.Status
+
+				err := p.Chargeback("fraud claim")
+
+				assert.ErrorIs(t, err, payment.ErrPaymentNotAuthorized)
+				assert.Equal(t, status, p.Status, "status should be unchanged")
+				assert.Nil(t, p.ChargebackAt)
+			})
+		}
+	})
+
+	t.Run("should return an error when the reason is blank", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+		require.NoError(t, p.ConfirmPayment())
+
+		err := p.Chargeback("  ")
+
+		assert.ErrorIs(t, err, payment.ErrInvalidChargebackReason)
+		assert.Equal(t, payment.StatusAuthorized, p.Status, "status should be unchanged")
+	})
+}
+
+func TestPayment_CancelPayment(t *testing.T) {
+	t.Run("should transition a pending payment to Cancelled", func(t *testing.T) {
+		p := createValidPayment(t)
+
+		err := p.CancelPayment()
+
+		require.NoError(t, err)
+		assert.Equal(t, payment.StatusCancelled, p.Status)
+		assert.NotNil(t, p.UpdatedAt, "UpdatedAt should be set on success")
+	})
+
+	t.Run("should return an error when payment is not pending", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+		require.NoError(t, p.ConfirmPayment())
+
+		err := p.CancelPayment()
+
+		assert.ErrorIs(t, err, payment.ErrPaymentNotPending)
+		assert.Equal(t, payment.StatusAuthorized, p.Status, "status should be unchanged")
+	})
+}
+
+func TestPayment_IsActive(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(p *payment.Payment) error
+		want  bool
+	}{
+		{name: "should be active while pending", setup: func(*payment.Payment) error { return nil }, want: true},
+		{name: "should be active once authorized", setup: (*payment.Payment).ConfirmPayment, want: true},
+		{name: "should not be active once refused", setup: (*payment.Payment).RefusePayment, want: false},
+		{name: "should not be active once cancelled", setup: (*payment.Payment).CancelPayment, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := createPaymentWithCode(t)
+			require.NoError(t, tt.setup(p))
+
+			assert.Equal(t, tt.want, p.IsActive())
+		})
+	}
+}
+
+func TestPayment_TransactionCodeValue(t *testing.T) {
+	t.Run("should return the code when it has been defined", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+
+		code, ok := p.TransactionCodeValue()
+
+		assert.True(t, ok)
+		assert.Equal(t, "TXN-123", code)
+	})
+
+	t.Run("should report an undefined code without panicking", func(t *testing.T) {
+		p := createValidPayment(t)
+
+		code, ok := p.TransactionCodeValue()
+
+		assert.False(t, ok)
+		assert.Empty(t, code)
+	})
+}
+
+func TestPayment_IsAuthorizationExpired(t *testing.T) {
+	const window = 15 * time.Minute
+
+	t.Run("should record when the transaction code was defined", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		p := createPaymentWithCode(t)
+
+		require.NotNil(t, p.CodeDefinedAt)
+		assert.Equal(t, clock.Now(), *p.CodeDefinedAt)
+	})
+
+	t.Run("should not expire within the window", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		p := createPaymentWithCode(t)
+		clock.Advance(window)
+
+		assert.False(t, p.IsAuthorizationExpired(clock.Now(), window))
+	})
+
+	t.Run("should expire past the window", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		p := createPaymentWithCode(t)
+		clock.Advance(window + time.Second)
+
+		assert.True(t, p.IsAuthorizationExpired(clock.Now(), window))
+	})
+
+	t.Run("should never expire without a transaction code", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		p := createValidPayment(t)
+		clock.Advance(24 * time.Hour)
+
+		assert.False(t, p.IsAuthorizationExpired(clock.Now(), window))
+	})
+
+	t.Run("should never expire once settled", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		p := createPaymentWithCode(t)
+		require.NoError(t, p.ConfirmPayment())
+		clock.Advance(24 * time.Hour)
+
+		assert.False(t, p.IsAuthorizationExpired(clock.Now(), window))
+	})
+}
+
+func TestPayment_TimeToAuthorize(t *testing.T) {
+	t.Run("should return the time from creation to authorization", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		p := createPaymentWithCode(t)
+		clock.Advance(90 * time.Second)
+		require.NoError(t, p.ConfirmPayment())
+
+		got, ok := p.TimeToAuthorize()
+
+		assert.True(t, ok)
+		assert.Equal(t, 90*time.Second, got)
+	})
+
+	t.Run("should report false for a pending payment", func(t *testing.T) {
+		p := createValidPayment(t)
+
+		got, ok := p.TimeToAuthorize()
+
+		assert.False(t, ok)
+		assert.Zero(t, got)
+	})
+
+	t.Run("should report false for a refused payment", func(t *testing.T) {
+		clock := testfixtures.Clock(t)
+		p := createPaymentWithCode(t)
+		clock.Advance(time.Minute)
+		require.NoError(t, p.RefusePayment())
+
+		got, ok := p.TimeToAuthorize()
+
+		assert.False(t, ok)
+		assert.Zero(t, got)
+	})
+}
+
+func TestPayment_Describe(t *testing.T) {
+	t.Run("should describe a pending payment without a code", func(t *testing.T) {
+		p := createValidPayment(t)
+
+		got := p.Describe()
+
+		assert.Equal(t, "Payment "+p.ID+" for order order-123: R$ 100,00 via credit_card — pending", got)
+	})
+
+	t.Run("should describe an authorized payment with its unmasked code", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+		require.NoError(t, p.ConfirmPayment())
+
+		got := p.Describe()
+
+		assert.Equal(t, "Payment "+p.ID+" for order order-123: R$ 100,00 via credit_card — authorized (TXN-123)", got)
+	})
+}
+
+func TestPayment_NilReceiver(t *testing.T) {
+	var p *payment.Payment
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "DefineTransactionCode", call: func() error { return p.DefineTransactionCode("TXN-123") }},
+		{name: "ConfirmPayment", call: p.ConfirmPayment},
+		{name: "RefusePayment", call: p.RefusePayment},
+	}
+	for _, tt := range tests {
+		t.Run("should return ErrNilAggregate from "+tt.name+" instead of panicking", func(t *testing.T) {
+			var err error
+			require.NotPanics(t, func() { err = tt.call() })
+
+			assert.ErrorIs(t, err, kernel.ErrNilAggregate)
+		})
+	}
+}
+
+func TestPayment_PullDomainEvents(t *testing.T) {
+	t.Run("should buffer an ApprovedEvent on confirmation and drain it on pull", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+		p.PullDomainEvents() // drop the creation and transaction code events
+		require.NoError(t, p.ConfirmPayment())
+
+		events := p.PullDomainEvents()
+
+		require.Len(t, events, 1)
+		got, ok := events[0].(payment.ApprovedEvent)
+		require.True(t, ok, "event should be an ApprovedEvent")
+		assert.NotEmpty(t, got.EventID())
+		assert.Equal(t, p.ID, got.PaymentID)
+		assert.Equal(t, p.OrderID, got.OrderID)
+		assert.Equal(t, p.Amount, got.Amount)
+		assert.Equal(t, p.TransactionCode, got.TransactionCode)
+		assert.Empty(t, p.PullDomainEvents(), "buffer should be empty after pull")
+	})
+
+	t.Run("should buffer a RefusedEvent on refusal and drain it on pull", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+		p.PullDomainEvents() // drop the creation and transaction code events
+		require.NoError(t, p.RefusePayment())
+
+		events := p.PullDomainEvents()
+
+		require.Len(t, events, 1)
+		got, ok := events[0].(payment.RefusedEvent)
+		require.True(t, ok, "event should be a RefusedEvent")
+		assert.Equal(t, p.ID, got.PaymentID)
+		assert.Empty(t, p.PullDomainEvents(), "buffer should be empty after pull")
+	})
+	t.Run("should buffer a CreatedEvent on creation", func(t *testing.T) {
+		p := createValidPayment(t)
+
+		events := p.PullDomainEvents()
+
+		require.Len(t, events, 1)
+		got, ok := events[0].(payment.CreatedEvent)
+		require.True(t, ok, "event should be a CreatedEvent")
+		assert.Equal(t, p.ID, got.PaymentID)
+		assert.Equal(t, p.OrderID, got.OrderID)
+		assert.Equal(t, p.Amount, got.Amount)
+		assert.Equal(t, p.Method, got.Method)
+	})
+
+	t.Run("should buffer a TransactionCodeDefinedEvent when the code is defined", func(t *testing.T) {
+		p := createValidPayment(t)
+		p.PullDomainEvents()
+		require.NoError(t, p.DefineTransactionCode("TXN-123"))
+
+		events := p.PullDomainEvents()
+
+		require.Len(t, events, 1)
+		got, ok := events[0].(payment.TransactionCodeDefinedEvent)
+		require.True(t, ok, "event should be a TransactionCodeDefinedEvent")
+		assert.Equal(t, p.ID, got.PaymentID)
+		assert.Equal(t, p.OrderID, got.OrderID)
+		assert.Equal(t, "TXN-123", got.TransactionCode)
+	})
+
+	t.Run("should buffer a RefundedEvent on refund", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+		require.NoError(t, p.ConfirmPayment())
+		p.PullDomainEvents()
+		require.NoError(t, p.RefundPayment())
+
+		events := p.PullDomainEvents()
+
+		require.Len(t, events, 1)
+		got, ok := events[0].(payment.RefundedEvent)
+		require.True(t, ok, "event should be a RefundedEvent")
+		assert.Equal(t, p.ID, got.PaymentID)
+		assert.Equal(t, p.OrderID, got.OrderID)
+		assert.Equal(t, p.Amount, got.Amount)
+		assert.Equal(t, p.TransactionCode, got.TransactionCode)
+	})
+
+	t.Run("should buffer a CancelledEvent on cancellation", func(t *testing.T) {
+		p := createValidPayment(t)
+		p.PullDomainEvents()
+		require.NoError(t, p.CancelPayment())
+
+		events := p.PullDomainEvents()
+
+		require.Len(t, events, 1)
+		got, ok := events[0].(payment.CancelledEvent)
+		require.True(t, ok, "event should be a CancelledEvent")
+		assert.Equal(t, p.ID, got.PaymentID)
+		assert.Equal(t, p.OrderID, got.OrderID)
+		assert.Equal(t, p.Amount, got.Amount)
+	})
+
+	t.Run("should not buffer events when a transition fails", func(t *testing.T) {
+		p := createValidPayment(t)
+		p.PullDomainEvents()
+
+		require.Error(t, p.RefundPayment())
+
+		assert.Empty(t, p.PullDomainEvents())
+	})
+}
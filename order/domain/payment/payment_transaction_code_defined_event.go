@@ -0,0 +1,31 @@
+package payment
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+
+// TransactionCodeDefinedEvent represents the event when the gateway transaction code is
+// assigned to a payment.
+type TransactionCodeDefinedEvent struct {
+	kernel.Event
+	PaymentID       string `json:"payment_id"`
+	OrderID         string `json:"order_id"`
+	TransactionCode string `json:"transaction_code"`
+}
+
+// NewTransactionCodeDefinedEvent constructs a TransactionCodeDefinedEvent with the current UTC timestamp.
+func NewTransactionCodeDefinedEvent(paymentID, orderID, transactionCode string) TransactionCodeDefinedEvent {
+	return TransactionCodeDefinedEvent{
+		Event: kernel.Event{
+			ID:           kernel.GenerateID(),
+			DateOccurred: kernel.Now(),
+		},
+		PaymentID:       paymentID,
+		OrderID:         orderID,
+		TransactionCode: transactionCode,
+	}
+}
+
+// Name returns "payment.transaction_code_defined", the stable name of the event, which does not
+// change when the Go type is renamed.
+func (TransactionCodeDefinedEvent) Name() string {
+	return "payment.transaction_code_defined"
+}
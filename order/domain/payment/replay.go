@@ -0,0 +1,89 @@
+package payment
+
+import (
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrInvalidEventSequence = errs.New("PAYMENT.INVALID_EVENT_SEQUENCE", "event sequence does not describe a legal payment lifecycle")
+
+// FromEvents rebuilds a [Payment] by replaying its event stream in order. The stream
+// must start with a [CreatedEvent], followed by the events the state machine allows:
+// [TransactionCodeDefinedEvent] while pending, then [ApprovedEvent] or [RefusedEvent],
+// and [RefundedEvent] or [ChargebackEvent] only after approval, or [CancelledEvent]
+// while still pending. Every event must refer to the same payment.
+//
+// Returns [ErrInvalidEventSequence] on an empty stream, an unknown event, an event for
+// another payment or an illegal transition. The rebuilt payment has no pending events.
+func FromEvents(events []kernel.DomainEvent) (*Payment, error) {
+	if len(events) == 0 {
+		return nil, ErrInvalidEventSequence
+	}
+
+	created, ok := events[0].(CreatedEvent)
+	if !ok {
+		return nil, ErrInvalidEventSequence
+	}
+
+	p := &Payment{
+		AggregateRoot: kernel.AggregateRoot{ID: created.PaymentID},
+		OrderID:       created.OrderID,
+		Amount:        created.Amount,
+		Method:        created.Method,
+		Status:        StatusPending,
+		CreatedAt:     created.OccurredAt(),
+	}
+
+	for _, event := range events[1:] {
+		if err := p.apply(event); err != nil {
+			return nil, err
+		}
+		p.UpdatedAt = new(event.OccurredAt())
+	}
+	return p, nil
+}
+
+// apply mutates p for a single replayed event, enforcing the same transitions as the
+// command methods without raising new events.
+func (p *Payment) apply(event kernel.DomainEvent) error {
+	switch e := event.(type) {
+	case TransactionCodeDefinedEvent:
+		if e.PaymentID != p.ID || !p.Status.Equals(StatusPending) || p.TransactionCode != nil {
+			return ErrInvalidEventSequence
+		}
+		p.TransactionCode = new(e.TransactionCode)
+		p.CodeDefinedAt = new(e.OccurredAt())
+	case ApprovedEvent:
+		// free payments are approved without a transaction code (see NewFreePayment).
+		if e.PaymentID != p.ID || !p.Status.Equals(StatusPending) || (p.TransactionCode == nil && !p.Method.Equals(MethodFree)) {
+			return ErrInvalidEventSequence
+		}
+		p.Status = StatusAuthorized
+		p.PaidAt = new(e.OccurredAt())
+	case RefusedEvent:
+		if e.PaymentID != p.ID || !p.Status.Equals(StatusPending) || p.TransactionCode == nil {
+			return ErrInvalidEventSequence
+		}
+		p.Status = StatusRefused
+	case RefundedEvent:
+		if e.PaymentID != p.ID || !p.Status.Equals(StatusAuthorized) {
+			return ErrInvalidEventSequence
+		}
+		p.Status = StatusRefunded
+	case ChargebackEvent:
+		if e.PaymentID != p.ID || !p.Status.Equals(StatusAuthorized) {
+			return ErrInvalidEventSequence
+		}
+		p.Status = StatusChargedBack
+		p.ChargebackAt = new(e.OccurredAt())
+		p.ChargebackReason = e.Reason
+	case CancelledEvent:
+		if e.PaymentID != p.ID || !p.Status.Equals(StatusPending) {
+			return ErrInvalidEventSequence
+		}
+		p.Status = StatusCancelled
+	default:
+		return ErrInvalidEventSequence
+	}
+	return nil
+}
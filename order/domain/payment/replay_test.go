@@ -0,0 +1,185 @@
+package payment_test
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromEvents(t *testing.T) {
+	clock := kernel.NewFrozenClock(time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC))
+	t.Cleanup(kernel.SetClock(clock))
+
+	code := "TXN-123"
+	created := payment.NewCreatedEvent("pay-1", "order-123", 100.0, payment.MethodPix)
+	clock.Advance(time.Minute)
+	codeDefined := payment.NewTransactionCodeDefinedEvent("pay-1", "order-123", code)
+	clock.Advance(time.Minute)
+	approved := payment.NewApprovedEvent("pay-1", "order-123", 100.0, &code)
+	clock.Advance(time.Minute)
+	refused := payment.NewRefusedEvent("pay-1", "order-123", 100.0, &code)
+	refunded := payment.NewRefundedEvent("pay-1", "order-123", 100.0, &code)
+	chargeback := payment.NewChargebackEvent("pay-1", "order-123", 100.0, &code, "fraud claim")
+
+	t.Run("should rebuild an authorized payment from its event stream", func(t *testing.T) {
+		got, err := payment.FromEvents([]kernel.DomainEvent{created, codeDefined, approved})
+
+		require.NoError(t, err)
+		assert.Equal(t, "pay-1", got.ID)
+		assert.Equal(t, "order-123", got.OrderID)
+		assert.Equal(t, 100.0, got.Amount)
+		assert.Equal(t, payment.MethodPix, got.Method)
+		assert.Equal(t, payment.StatusAuthorized, got.Status)
+		assert.Equal(t, created.OccurredAt(), got.CreatedAt)
+		require.NotNil(t, got.PaidAt)
+		assert.Equal(t, approved.OccurredAt(), *got.PaidAt)
+		require.NotNil(t, got.UpdatedAt)
+		assert.Equal(t, approved.OccurredAt(), *got.UpdatedAt)
+		gotCode, ok := got.TransactionCodeValue()
+		assert.True(t, ok)
+		assert.Equal(t, code, gotCode)
+		assert.Empty(t, got.DomainEvents(), "replay should not raise new events")
+	})
+
+	t.Run("should rebuild a pending payment from its creation alone", func(t *testing.T) {
+		got, err := payment.FromEvents([]kernel.DomainEvent{created})
+
+		require.NoError(t, err)
+		assert.Equal(t, payment.StatusPending, got.Status)
+		assert.Nil(t, got.UpdatedAt)
+	})
+
+	t.Run("should rebuild a refunded payment", func(t *testing.T) {
+		got, err := payment.FromEvents([]kernel.DomainEvent{created, codeDefined, approved, refunded})
+
+		require.NoError(t, err)
+		assert.Equal(t, payment.StatusRefunded, got.Status)
+	})
+
+	t.Run("should rebuild a charged back payment", func(t *testing.T) {
+		got, err := payment.FromEvents([]kernel.DomainEvent{created, codeDefined, approved, chargeback})
+
+		require.NoError(t, err)
+		assert.Equal(t, payment.StatusChargedBack, got.Status)
+		assert.Equal(t, "fraud claim", got.ChargebackReason)
+		require.NotNil(t, got.ChargebackAt)
+		assert.Equal(t, chargeback.OccurredAt(), *got.ChargebackAt)
+	})
+
+	t.Run("should rebuild a payment from the events it raised", func(t *testing.T) {
+		p := createPaymentWithCode(t)
+		require.NoError(t, p.CancelPayment())
+
+		got, err := payment.FromEvents(p.PullDomainEvents())
+
+		require.NoError(t, err)
+		assert.Equal(t, p.ID, got.ID)
+		assert.Equal(t, payment.StatusCancelled, got.Status)
+		assert.Equal(t, p.TransactionCode, got.TransactionCode)
+	})
+
+	t.Run("should rebuild a free payment approved without a transaction code", func(t *testing.T) {
+		p := kernel.Must(payment.NewFreePayment("order-123"))
+
+		got, err := payment.FromEvents(p.PullDomainEvents())
+
+		require.NoError(t, err)
+		assert.Equal(t, payment.StatusAuthorized, got.Status)
+		assert.Equal(t, payment.MethodFree, got.Method)
+		assert.Nil(t, got.TransactionCode)
+	})
+
+	t.Run("should reject an illegal sequence", func(t *testing.T) {
+		other := payment.NewTransactionCodeDefinedEvent("pay-2", "order-123", code)
+		tests := []struct {
+			name   string
+			events []kernel.DomainEvent
+		}{
+			{name: "should reject an empty stream", events: nil},
+			{name: "should reject a stream not starting with creation", events: []kernel.DomainEvent{codeDefined, created}},
+			{name: "should reject approval before the code is defined", events: []kernel.DomainEvent{created, approved, codeDefined}},
+			{name: "should reject a refusal after approval", events: []kernel.DomainEvent{created, codeDefined, approved, refused}},
+			{name: "should reject a refund of a pending payment", events: []kernel.DomainEvent{created, codeDefined, refunded}},
+			{name: "should reject a second transaction code", events: []kernel.DomainEvent{created, codeDefined, codeDefined}},
+			{name: "should reject an event of another payment", events: []kernel.DomainEvent{created, other}},
+			{name: "should reject a repeated creation", events: []kernel.DomainEvent{created, created}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := payment.FromEvents(tt.events)
+
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, payment.ErrInvalidEventSequence)
+			})
+		}
+	})
+}
+
+// tickingClock moves one second forward on every reading, so any timestamp a command
+// takes apart from the event it raises shows up as a difference.
+type tickingClock struct{ now time.Time }
+
+func (c *tickingClock) Now() time.Time {
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+// This test drives payments through random sequences of valid commands and checks that
+// replaying the events they raised rebuilds exactly the same payment, i.e. that the event
+// stream is a faithful record of the state.
+func TestFromEvents_MatchesCommandState(t *testing.T) {
+	t.Cleanup(kernel.SetClock(&tickingClock{now: time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)}))
+
+	// next returns the commands valid for p in its current state.
+	next := func(p *payment.Payment) map[string]func() error {
+		switch _, hasCode := p.TransactionCodeValue(); {
+		case p.Status.Equals(payment.StatusPending) && !hasCode:
+			return map[string]func() error{
+				"DefineTransactionCode": func() error { return p.DefineTransactionCode("TXN-" + p.ID) },
+				"CancelPayment":         p.CancelPayment,
+			}
+		case p.Status.Equals(payment.StatusPending):
+			return map[string]func() error{
+				"ConfirmPayment": p.ConfirmPayment,
+				"RefusePayment":  p.RefusePayment,
+				"CancelPayment":  p.CancelPayment,
+			}
+		case p.Status.Equals(payment.StatusAuthorized):
+			return map[string]func() error{
+				"RefundPayment": p.RefundPayment,
+				"Chargeback":    func() error { return p.Chargeback("fraud claim") },
+			}
+		}
+		return nil
+	}
+
+	for seed := range uint64(200) {
+		rnd := rand.New(rand.NewPCG(seed, seed))
+
+		var p *payment.Payment
+		if rnd.IntN(5) == 0 {
+			p = kernel.Must(payment.NewFreePayment("order-123"))
+		} else {
+			p = kernel.Must(payment.NewPayment("order-123", float64(1+rnd.IntN(500)), payment.MethodPix))
+		}
+		var steps []string
+		for cmds := next(p); len(cmds) > 0 && rnd.IntN(4) != 0; cmds = next(p) {
+			names := slices.Sorted(maps.Keys(cmds))
+			name := names[rnd.IntN(len(names))]
+			require.NoError(t, cmds[name](), "seed %d: %s", seed, name)
+			steps = append(steps, name)
+		}
+
+		got, err := payment.FromEvents(p.PullDomainEvents())
+
+		require.NoError(t, err, "seed %d: %v", seed, steps)
+		assert.Equal(t, p, got, "seed %d: replaying %v should rebuild the same payment", seed, steps)
+	}
+}
@@ -0,0 +1,22 @@
+package payment
+
+import (
+	"context"
+	"time"
+)
+
+// ReportRow is a read-model projection used by payment reconciliation reports:
+// the number and total amount of payments sharing a Status and a Method.
+type ReportRow struct {
+	Status      Status
+	Method      Method
+	Count       int
+	TotalAmount float64
+}
+
+// ReportRepository is the read port that feeds payment reconciliation reports.
+type ReportRepository interface {
+	// SummarizeByStatusAndMethod groups the payments created within [from, to] (both
+	// bounds inclusive) by status and method, returning one row per existing group.
+	SummarizeByStatusAndMethod(ctx context.Context, from, to time.Time) ([]ReportRow, error)
+}
@@ -0,0 +1,35 @@
+package payment
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
+)
+
+// transactionCodePattern is the format gateway transaction codes must match, configured
+// with [SetTransactionCodePattern]. nil accepts any non-blank code.
+var transactionCodePattern kernel.Setting[*regexp.Regexp]
+
+// TransactionCodePattern returns the configured transaction code format, or nil when
+// any non-blank code is accepted.
+func TransactionCodePattern() *regexp.Regexp {
+	return transactionCodePattern.Load()
+}
+
+// SetTransactionCodePattern configures the format [Payment.DefineTransactionCode]
+// requires, e.g. `^TXN-\d+$` for a gateway issuing numeric codes; nil accepts any
+// non-blank code. Codes generated locally for payments settled outside a gateway are
+// not checked.
+func SetTransactionCodePattern(pattern *regexp.Regexp) {
+	transactionCodePattern.Store(pattern)
+}
+
+func checkTransactionCodeFormat(code string) error {
+	pattern := transactionCodePattern.Load()
+	if pattern == nil || strings.TrimSpace(code) == "" {
+		return nil
+	}
+	return guard.CheckMatchRegex(code, pattern, ErrInvalidTransactionCode)
+}
@@ -0,0 +1,44 @@
+package payment_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTransactionCodePattern(t *testing.T) {
+	t.Cleanup(func() { payment.SetTransactionCodePattern(nil) })
+	payment.SetTransactionCodePattern(regexp.MustCompile(`^TXN-\d+$`))
+
+	t.Run("should accept a code matching the pattern", func(t *testing.T) {
+		p := createValidPayment(t)
+
+		err := p.DefineTransactionCode("TXN-123")
+
+		require.NoError(t, err)
+		code, _ := p.TransactionCodeValue()
+		assert.Equal(t, "TXN-123", code)
+	})
+
+	t.Run("should reject a code not matching the pattern", func(t *testing.T) {
+		p := createValidPayment(t)
+
+		err := p.DefineTransactionCode("abc")
+
+		assert.ErrorIs(t, err, payment.ErrInvalidTransactionCode)
+		assert.Nil(t, p.TransactionCode, "TransactionCode should remain unset")
+	})
+
+	t.Run("should accept any non-blank code once the pattern is cleared", func(t *testing.T) {
+		payment.SetTransactionCodePattern(nil)
+		p := createValidPayment(t)
+
+		err := p.DefineTransactionCode("abc")
+
+		require.NoError(t, err)
+		assert.Nil(t, payment.TransactionCodePattern())
+	})
+}
@@ -0,0 +1,130 @@
+// Package promo holds the promotion value objects that can be applied to an order.
+package promo
+
+import (
+	"encoding/json"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
+)
+
+var (
+	ErrInvalidCouponCode     = errs.New("COUPON.INVALID_CODE", "coupon code cannot be null or whitespace")
+	ErrInvalidCouponValue    = errs.New("COUPON.INVALID_VALUE", "coupon value must be greater than zero")
+	ErrPercentageExceeds100  = errs.New("COUPON.PERCENTAGE_EXCEEDS_100", "percentage coupon value cannot be greater than 100")
+	ErrNegativeMinOrderTotal = errs.New("COUPON.NEGATIVE_MIN_ORDER_TOTAL", "coupon minimum order total cannot be negative")
+)
+
+// Coupon is an immutable value object describing a promotional discount identified by a code.
+// All fields are unexported to enforce construction through [NewCoupon]. Two Coupon values
+// are equal when every field is equal (see [Coupon.Equals]).
+type Coupon struct {
+	code          string
+	discountType  DiscountType
+	value         float64
+	minOrderTotal float64 // optional; zero means no minimum
+}
+
+// NewCoupon constructs and validates a [Coupon] value object.
+// code must be non-blank and value strictly positive; a percentage value cannot exceed 100.
+// minOrderTotal is optional (zero disables it) but cannot be negative.
+//
+// If multiple fields are invalid, all violations are collected and returned as a
+// single joined error, allowing callers to inspect every failure via [errors.Is].
+func NewCoupon(code string, discountType DiscountType, value, minOrderTotal float64) (*Coupon, error) {
+	c := Coupon{
+		code:          code,
+		discountType:  discountType,
+		value:         value,
+		minOrderTotal: minOrderTotal,
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Validate reports every invariant violated by c, joined into a single error.
+// It lets consumers reject an uninitialized Coupon{} that bypassed [NewCoupon].
+func (c Coupon) Validate() error {
+	var percentageErr error
+	if c.discountType.Equals(DiscountTypePercentage) && c.value > 100 {
+		percentageErr = ErrPercentageExceeds100
+	}
+
+	return errs.Join(
+		guard.CheckNotNullOrWhiteSpace(c.code, ErrInvalidCouponCode),
+		checkValidDiscountType(c.discountType),
+		guard.CheckNotZeroOrNegative(c.value, ErrInvalidCouponValue),
+		percentageErr,
+		guard.CheckNonNegative(c.minOrderTotal, ErrNegativeMinOrderTotal),
+	)
+}
+
+// Code returns the coupon code.
+func (c Coupon) Code() string { return c.code }
+
+// DiscountType returns how the coupon value is applied.
+func (c Coupon) DiscountType() DiscountType { return c.discountType }
+
+// Value returns the percentage or absolute amount of the discount.
+func (c Coupon) Value() float64 { return c.value }
+
+// MinOrderTotal returns the order total required to apply the coupon; zero means no minimum.
+func (c Coupon) MinOrderTotal() float64 { return c.minOrderTotal }
+
+// IsSatisfiedBy reports whether total reaches the coupon minimum order total.
+func (c Coupon) IsSatisfiedBy(total float64) bool {
+	return total >= c.minOrderTotal
+}
+
+// DiscountFor returns the discount the coupon grants on total. A percentage discount is
+// rounded to cents with [kernel.RoundCents]; an absolute discount is capped at total so
+// the discounted amount never becomes negative.
+func (c Coupon) DiscountFor(total float64) float64 {
+	if c.discountType.Equals(DiscountTypePercentage) {
+		return kernel.RoundCents(total * c.value / 100)
+	}
+	return min(c.value, total)
+}
+
+// couponJSON is the wire shape of a [Coupon].
+type couponJSON struct {
+	Code          string       `json:"code"`
+	DiscountType  DiscountType `json:"discount_type"`
+	Value         float64      `json:"value"`
+	MinOrderTotal float64      `json:"min_order_total"`
+}
+
+// MarshalJSON serializes the coupon with snake_case field names.
+func (c Coupon) MarshalJSON() ([]byte, error) {
+	return json.Marshal(couponJSON{c.code, c.discountType, c.value, c.minOrderTotal})
+}
+
+// UnmarshalJSON parses the shape written by MarshalJSON, validating it as [NewCoupon]
+// does. Invalid coupons return its error and leave c unchanged.
+func (c *Coupon) UnmarshalJSON(data []byte) error {
+	var v couponJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	parsed, err := NewCoupon(v.Code, v.DiscountType, v.Value, v.MinOrderTotal)
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// Equals reports whether c and other carry the same code, type, value and minimum.
+func (c Coupon) Equals(other Coupon) bool {
+	return c == other
+}
+
+func checkValidDiscountType(d DiscountType) error {
+	if _, ok := discountTypeToString[d]; !ok {
+		return ErrInvalidDiscountType
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package promo_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/promo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCoupon(t *testing.T) {
+	t.Run("should successfully create a coupon with valid input", func(t *testing.T) {
+		got, err := promo.NewCoupon("WELCOME10", promo.DiscountTypePercentage, 10, 50)
+
+		require.NoError(t, err)
+		assert.Equal(t, "WELCOME10", got.Code())
+		assert.Equal(t, promo.DiscountTypePercentage, got.DiscountType())
+		assert.Equal(t, 10.0, got.Value())
+		assert.Equal(t, 50.0, got.MinOrderTotal())
+	})
+
+	t.Run("should return an error when input is invalid", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			code          string
+			discountType  promo.DiscountType
+			value         float64
+			minOrderTotal float64
+			wantErr       error
+		}{
+			{name: "should return an error when code is blank", code: "  ", discountType: promo.DiscountTypeAbsolute, value: 10, wantErr: promo.ErrInvalidCouponCode},
+			{name: "should return an error when discount type is uninitialized", code: "X", discountType: promo.DiscountType{}, value: 10, wantErr: promo.ErrInvalidDiscountType},
+			{name: "should return an error when value is zero", code: "X", discountType: promo.DiscountTypeAbsolute, value: 0, wantErr: promo.ErrInvalidCouponValue},
+			{name: "should return an error when percentage exceeds 100", code: "X", discountType: promo.DiscountTypePercentage, value: 101, wantErr: promo.ErrPercentageExceeds100},
+			{name: "should return an error when minimum order total is negative", code: "X", discountType: promo.DiscountTypeAbsolute, value: 10, minOrderTotal: -1, wantErr: promo.ErrNegativeMinOrderTotal},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := promo.NewCoupon(tt.code, tt.discountType, tt.value, tt.minOrderTotal)
+
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, tt.wantErr)
+			})
+		}
+	})
+}
+
+func TestCoupon_Validate(t *testing.T) {
+	t.Run("should return every violation for an uninitialized coupon", func(t *testing.T) {
+		err := promo.Coupon{}.Validate()
+
+		assert.ErrorIs(t, err, promo.ErrInvalidCouponCode)
+		assert.ErrorIs(t, err, promo.ErrInvalidDiscountType)
+		assert.ErrorIs(t, err, promo.ErrInvalidCouponValue)
+	})
+}
+
+func TestCoupon_DiscountFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		coupon *promo.Coupon
+		total  float64
+		want   float64
+	}{
+		{name: "should take a percentage of the total", coupon: kernel.Must(promo.NewCoupon("P10", promo.DiscountTypePercentage, 10, 0)), total: 200, want: 20},
+		{name: "should round a percentage discount to cents", coupon: kernel.Must(promo.NewCoupon("P10", promo.DiscountTypePercentage, 10, 0)), total: 33.33, want: 3.33},
+		{name: "should take a fixed amount off the total", coupon: kernel.Must(promo.NewCoupon("A15", promo.DiscountTypeAbsolute, 15, 0)), total: 200, want: 15},
+		{name: "should cap an absolute discount at the total", coupon: kernel.Must(promo.NewCoupon("A15", promo.DiscountTypeAbsolute, 15, 0)), total: 10, want: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.coupon.DiscountFor(tt.total)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCoupon_Equals(t *testing.T) {
+	c := kernel.Must(promo.NewCoupon("P10", promo.DiscountTypePercentage, 10, 0))
+
+	assert.True(t, c.Equals(*kernel.Must(promo.NewCoupon("P10", promo.DiscountTypePercentage, 10, 0))))
+	assert.False(t, c.Equals(*kernel.Must(promo.NewCoupon("P10", promo.DiscountTypeAbsolute, 10, 0))))
+}
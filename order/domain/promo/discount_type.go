@@ -0,0 +1,66 @@
+package promo
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+
+var ErrInvalidDiscountType = errs.New("COUPON.INVALID_DISCOUNT_TYPE", "invalid discount type")
+
+// DiscountType represents how a [Coupon] value is applied to an order total.
+type DiscountType struct{ value int }
+
+// Define vars for each discount type, starting from 1 to avoid the zero value which can be used as a default or uninitialized state.
+var (
+	DiscountTypePercentage = DiscountType{1} // DiscountTypePercentage takes a percentage of the order total.
+	DiscountTypeAbsolute   = DiscountType{2} // DiscountTypeAbsolute takes a fixed amount off the order total.
+)
+
+// discountTypeToString maps DiscountType values to their string representations.
+var discountTypeToString = map[DiscountType]string{
+	DiscountTypePercentage: "percentage",
+	DiscountTypeAbsolute:   "absolute",
+}
+
+// String returns the string representation of the DiscountType.
+func (d DiscountType) String() string {
+	if str, ok := discountTypeToString[d]; ok {
+		return str
+	}
+	return "unknown"
+}
+
+// MarshalText provides support for logging and any marshal needs.
+func (d DiscountType) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidDiscountType] and leave d unchanged instead of decoding to an uninitialized DiscountType.
+func (d *DiscountType) UnmarshalText(text []byte) error {
+	for v, str := range discountTypeToString {
+		if str == string(text) {
+			*d = v
+			return nil
+		}
+	}
+	return ErrInvalidDiscountType
+}
+
+// Equals checks if two DiscountType values are equal.
+func (d DiscountType) Equals(other DiscountType) bool {
+	return d.value == other.value
+}
+
+// IsValid reports whether the DiscountType is one of the defined values; the zero value is not.
+func (d DiscountType) IsValid() bool {
+	_, ok := discountTypeToString[d]
+	return ok
+}
+
+// ParseDiscountType converts an int to the corresponding DiscountType value.
+// If the input does not match any known type, it returns an error and an empty DiscountType value.
+func ParseDiscountType(value int) (DiscountType, error) {
+	d := DiscountType{value}
+	if _, ok := discountTypeToString[d]; !ok {
+		return DiscountType{}, ErrInvalidDiscountType
+	}
+	return d, nil
+}
@@ -0,0 +1,131 @@
+package promo_test
+
+import (
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/promo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscountType_String(t *testing.T) {
+	tests := []struct {
+		name         string
+		discountType promo.DiscountType
+		want         string
+	}{
+		// ==================== Success cases ==================== //
+		{name: "should return 'percentage' for DiscountTypePercentage", discountType: promo.DiscountTypePercentage, want: "percentage"},
+		{name: "should return 'absolute' for DiscountTypeAbsolute", discountType: promo.DiscountTypeAbsolute, want: "absolute"},
+		// ==================== Failure cases ==================== //
+		{name: "should return 'unknown' for zero value (uninitialized)", discountType: promo.DiscountType{}, want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.discountType.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiscountType_UnmarshalText(t *testing.T) {
+	// ==================== Success cases ==================== //
+	successTests := []struct {
+		name string
+		text string
+		want promo.DiscountType
+	}{
+		{name: "should unmarshal 'percentage' to DiscountTypePercentage", text: "percentage", want: promo.DiscountTypePercentage},
+		{name: "should unmarshal 'absolute' to DiscountTypeAbsolute", text: "absolute", want: promo.DiscountTypeAbsolute},
+	}
+	for _, tt := range successTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got promo.DiscountType
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	failureTests := []struct {
+		name string
+		text string
+	}{
+		{name: "should return an error for a misspelled discount type", text: "percentage_typo"},
+		{name: "should return an error for the 'unknown' placeholder", text: "unknown"},
+		{name: "should return an error for an empty string", text: ""},
+	}
+	for _, tt := range failureTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := promo.DiscountTypeAbsolute
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			assert.ErrorIs(t, err, promo.ErrInvalidDiscountType)
+			assert.Equal(t, promo.DiscountTypeAbsolute, got, "value should be unchanged")
+		})
+	}
+}
+
+func TestDiscountType_Equals(t *testing.T) {
+	tests := []struct {
+		name         string
+		discountType promo.DiscountType
+		other        promo.DiscountType
+		want         bool
+	}{
+		// ==================== Success cases ==================== //
+		{name: "should return true when both types are the same", discountType: promo.DiscountTypeAbsolute, other: promo.DiscountTypeAbsolute, want: true},
+		// ==================== Failure cases ==================== //
+		{name: "should return false when types are different", discountType: promo.DiscountTypeAbsolute, other: promo.DiscountTypePercentage, want: false},
+		{name: "should return false when comparing with an uninitialized type", discountType: promo.DiscountTypeAbsolute, other: promo.DiscountType{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.discountType.Equals(tt.other)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseDiscountType(t *testing.T) {
+	// ==================== Success cases ==================== //
+	successTests := []struct {
+		name  string
+		value int
+		want  promo.DiscountType
+	}{
+		{name: "should parse 1 to DiscountTypePercentage", value: 1, want: promo.DiscountTypePercentage},
+		{name: "should parse 2 to DiscountTypeAbsolute", value: 2, want: promo.DiscountTypeAbsolute},
+	}
+	for _, tt := range successTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := promo.ParseDiscountType(tt.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	failureTests := []struct {
+		name  string
+		value int
+	}{
+		{name: "should return an error for zero value (uninitialized)", value: 0},
+		{name: "should return an error for an out-of-range value", value: 999},
+	}
+	for _, tt := range failureTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := promo.ParseDiscountType(tt.value)
+
+			assert.ErrorIs(t, err, promo.ErrInvalidDiscountType)
+			assert.Equal(t, promo.DiscountType{}, got)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package order
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrOrderNotFound = errs.NewNotFound("ORDER.NOT_FOUND", "order not found")
+
+// Page selects a slice of a query result. Number is 1-based; a non-positive Size
+// disables pagination and returns every match.
+type Page struct {
+	Number int
+	Size   int
+}
+
+// Repository is the persistence port of the [Order] aggregate. Implementations live in
+// the infrastructure layer and must return [ErrOrderNotFound] when an order is missing.
+type Repository interface {
+	// FindByID returns the order identified by id.
+	FindByID(ctx context.Context, id string) (*Order, error)
+
+	// FindByDateRange returns the orders whose CreatedAt falls within [from, to] (both
+	// bounds inclusive), sorted by CreatedAt, restricted to page, together with the total
+	// number of matches before pagination.
+	FindByDateRange(ctx context.Context, from, to time.Time, page Page) ([]*Order, int, error)
+
+	// Save inserts or replaces the order.
+	Save(ctx context.Context, order *Order) error
+}
@@ -0,0 +1,38 @@
+package order
+
+import (
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrInvalidReturnWindow = errs.New("ORDER.INVALID_RETURN_WINDOW", "return window must be greater than zero")
+
+// DefaultReturnWindow is how long after delivery a customer may request a return,
+// unless overridden with [SetReturnWindow].
+const DefaultReturnWindow = 7 * 24 * time.Hour
+
+var returnWindow = kernel.NewSetting(DefaultReturnWindow)
+
+// ReturnWindow returns the configured return window.
+func ReturnWindow() time.Duration {
+	return returnWindow.Load()
+}
+
+// SetReturnWindow configures how long after delivery [Order.RequestReturn] accepts a
+// request. window must be strictly positive.
+func SetReturnWindow(window time.Duration) error {
+	if window <= 0 {
+		return ErrInvalidReturnWindow
+	}
+	returnWindow.Store(window)
+	return nil
+}
+
+// ReturnRequest records a customer's request to return some items of a delivered order.
+type ReturnRequest struct {
+	ItemIDs     []string           `json:"item_ids"`
+	Reason      CancellationReason `json:"reason"`
+	RequestedAt time.Time          `json:"requested_at"`
+}
@@ -0,0 +1,28 @@
+package order_test
+
+import (
+	"testing"
+	"time"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetReturnWindow(t *testing.T) {
+	t.Run("should configure the return window", func(t *testing.T) {
+		t.Cleanup(func() { require.NoError(t, order.SetReturnWindow(order.DefaultReturnWindow)) })
+
+		err := order.SetReturnWindow(30 * 24 * time.Hour)
+
+		require.NoError(t, err)
+		assert.Equal(t, 30*24*time.Hour, order.ReturnWindow())
+	})
+
+	t.Run("should return an error when the window is not positive", func(t *testing.T) {
+		err := order.SetReturnWindow(0)
+
+		assert.ErrorIs(t, err, order.ErrInvalidReturnWindow)
+		assert.Equal(t, order.DefaultReturnWindow, order.ReturnWindow(), "window should be unchanged")
+	})
+}
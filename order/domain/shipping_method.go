@@ -0,0 +1,66 @@
+package order
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+
+var ErrInvalidShippingMethod = errs.New("ORDER.INVALID_SHIPPING_METHOD", "invalid shipping method")
+
+// ShippingMethod represents the delivery speed chosen by the customer for an [Order].
+type ShippingMethod struct {
+	value int
+}
+
+var (
+	ShippingMethodStandard = ShippingMethod{1}
+	ShippingMethodExpress  = ShippingMethod{2}
+)
+
+var shippingMethodToString = map[ShippingMethod]string{
+	ShippingMethodStandard: "standard",
+	ShippingMethodExpress:  "express",
+}
+
+// String returns the string representation of the ShippingMethod.
+func (m ShippingMethod) String() string {
+	if str, ok := shippingMethodToString[m]; ok {
+		return str
+	}
+	return "unknown"
+}
+
+// MarshalText provides support for logging and any marshal needs.
+func (m ShippingMethod) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText parses the string produced by MarshalText. Unknown strings return
+// [ErrInvalidShippingMethod] and leave m unchanged instead of decoding to an uninitialized ShippingMethod.
+func (m *ShippingMethod) UnmarshalText(text []byte) error {
+	for v, str := range shippingMethodToString {
+		if str == string(text) {
+			*m = v
+			return nil
+		}
+	}
+	return ErrInvalidShippingMethod
+}
+
+// Equals checks if two ShippingMethod values are equal.
+func (m ShippingMethod) Equals(other ShippingMethod) bool {
+	return m.value == other.value
+}
+
+// IsValid reports whether the ShippingMethod is one of the defined values; the zero value is not.
+func (m ShippingMethod) IsValid() bool {
+	_, ok := shippingMethodToString[m]
+	return ok
+}
+
+// ParseShippingMethod converts an int to the corresponding ShippingMethod value.
+// If the input does not match any known shipping method, it returns an error and an empty ShippingMethod value.
+func ParseShippingMethod(value int) (ShippingMethod, error) {
+	m := ShippingMethod{value: value}
+	if _, ok := shippingMethodToString[m]; !ok {
+		return ShippingMethod{}, ErrInvalidShippingMethod
+	}
+	return m, nil
+}
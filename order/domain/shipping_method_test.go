@@ -0,0 +1,155 @@
+package order_test
+
+import (
+	"testing"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShippingMethod_String(t *testing.T) {
+	// ==================== Success cases ==================== //
+	tests := []struct {
+		name   string
+		method order.ShippingMethod
+		want   string
+	}{
+		{name: "should return 'standard' for ShippingMethodStandard", method: order.ShippingMethodStandard, want: "standard"},
+		{name: "should return 'express' for ShippingMethodExpress", method: order.ShippingMethodExpress, want: "express"},
+		// ==================== Failure cases ==================== //
+		{name: "should return 'unknown' for an unrecognized method value", method: order.ShippingMethod{}, want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.method.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestShippingMethod_MarshalText(t *testing.T) {
+	tests := []struct {
+		name   string
+		method order.ShippingMethod
+		want   string
+	}{
+		// ==================== Success cases ==================== //
+		{name: "should marshal ShippingMethodStandard to 'standard'", method: order.ShippingMethodStandard, want: "standard"},
+		{name: "should marshal ShippingMethodExpress to 'express'", method: order.ShippingMethodExpress, want: "express"},
+		// ==================== Failure cases ==================== //
+		{name: "should marshal unknown method to 'unknown'", method: order.ShippingMethod{}, want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.method.MarshalText()
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestShippingMethod_UnmarshalText(t *testing.T) {
+	// ==================== Success cases ==================== //
+	successTests := []struct {
+		name string
+		text string
+		want order.ShippingMethod
+	}{
+		{name: "should unmarshal 'express' to ShippingMethodExpress", text: "express", want: order.ShippingMethodExpress},
+		{name: "should unmarshal 'standard' to ShippingMethodStandard", text: "standard", want: order.ShippingMethodStandard},
+	}
+	for _, tt := range successTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got order.ShippingMethod
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	failureTests := []struct {
+		name string
+		text string
+	}{
+		{name: "should return an error for a misspelled shipping method", text: "express_typo"},
+		{name: "should return an error for the 'unknown' placeholder", text: "unknown"},
+		{name: "should return an error for an empty string", text: ""},
+	}
+	for _, tt := range failureTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := order.ShippingMethodStandard
+
+			err := got.UnmarshalText([]byte(tt.text))
+
+			assert.ErrorIs(t, err, order.ErrInvalidShippingMethod)
+			assert.Equal(t, order.ShippingMethodStandard, got, "value should be unchanged")
+		})
+	}
+}
+
+func TestShippingMethod_Equals(t *testing.T) {
+	tests := []struct {
+		name   string
+		method order.ShippingMethod
+		other  order.ShippingMethod
+		want   bool
+	}{
+		// ==================== Success cases ==================== //
+		{name: "should return true when both methods are the same", method: order.ShippingMethodExpress, other: order.ShippingMethodExpress, want: true},
+		// ==================== Failure cases ==================== //
+		{name: "should return false when methods are different", method: order.ShippingMethodStandard, other: order.ShippingMethodExpress, want: false},
+		{name: "should return false when comparing with an uninitialized method", method: order.ShippingMethodStandard, other: order.ShippingMethod{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.method.Equals(tt.other)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseShippingMethod(t *testing.T) {
+	// ==================== Success cases ==================== //
+	successTests := []struct {
+		name       string
+		value      int
+		wantMethod order.ShippingMethod
+	}{
+		{name: "should parse 1 to ShippingMethodStandard", value: 1, wantMethod: order.ShippingMethodStandard},
+		{name: "should parse 2 to ShippingMethodExpress", value: 2, wantMethod: order.ShippingMethodExpress},
+	}
+	for _, tt := range successTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := order.ParseShippingMethod(tt.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMethod, got)
+		})
+	}
+
+	// ==================== Failure cases ==================== //
+	failureTests := []struct {
+		name    string
+		value   int
+		wantErr error
+	}{
+		{name: "should return an error for zero", value: 0, wantErr: order.ErrInvalidShippingMethod},
+		{name: "should return an error for an out-of-range value", value: 999, wantErr: order.ErrInvalidShippingMethod},
+	}
+	for _, tt := range failureTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := order.ParseShippingMethod(tt.value)
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, order.ShippingMethod{}, got)
+		})
+	}
+}
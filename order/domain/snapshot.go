@@ -0,0 +1,250 @@
+package order
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/promo"
+)
+
+// CurrentSnapshotVersion is the SchemaVersion written by [Order.Snapshot].
+const CurrentSnapshotVersion = 6
+
+// UnknownCustomerID is the CustomerID given to orders loaded from version 1 snapshots,
+// which predate the field.
+const UnknownCustomerID = "unknown"
+
+var ErrUnsupportedSnapshotVersion = errs.New("ORDER.UNSUPPORTED_SNAPSHOT_VERSION", "snapshot schema version is not supported")
+
+// Snapshot is the persisted shape of an Order, including the items, payments and tax
+// policy the aggregate keeps unexported. SchemaVersion records the shape it was written
+// with; a zero SchemaVersion stands for version 1, written before the field existed.
+type Snapshot struct {
+	SchemaVersion   int                   `json:"schema_version"`
+	ID              string                `json:"id"`
+	UpdatedAt       *time.Time            `json:"updated_at"`
+	CustomerID      string                `json:"customer_id"` // added in version 2
+	DeliveryAddress DeliveryAddress       `json:"delivery_address"`
+	TotalAmount     float64               `json:"total_amount"`
+	DiscountAmount  float64               `json:"discount_amount"`
+	Coupon          *promo.Coupon         `json:"coupon"`
+	Credits         []Credit              `json:"credits"`
+	TaxRate         float64               `json:"tax_rate"`
+	TaxPolicy       TaxPolicy             `json:"tax_policy"`
+	ShippingMethod  ShippingMethod        `json:"shipping_method"`
+	Status          Status                `json:"status"`
+	Number          string                `json:"number"`
+	IsGift          bool                  `json:"is_gift"`
+	OnHold          bool                  `json:"on_hold"`
+	HoldReason      string                `json:"hold_reason"`
+	Instructions    string                `json:"instructions"`   // added in version 3
+	TrackingCode    string                `json:"tracking_code"`  // added in version 4
+	ReservationID   string                `json:"reservation_id"` // added in version 5
+	ReturnRequest   *ReturnRequest        `json:"return_request"`
+	CreatedAt       time.Time             `json:"created_at"`
+	DeliveredAt     *time.Time            `json:"delivered_at"`
+	Items           []orderitem.OrderItem `json:"items"`
+	Payments        []payment.Payment     `json:"payments"`
+}
+
+// MarshalJSON serializes the snapshot for storage. Payments are written with
+// [payment.Payment.MarshalPersistence], so their transaction codes are kept in full
+// instead of masked.
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	type snapshotJSON Snapshot // drops the methods, so marshaling does not recurse
+	payments := make([]json.RawMessage, 0, len(s.Payments))
+	for _, p := range s.Payments {
+		data, err := p.MarshalPersistence()
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, data)
+	}
+	return json.Marshal(struct {
+		snapshotJSON
+		Payments []json.RawMessage `json:"payments"`
+	}{snapshotJSON(s), payments})
+}
+
+// snapshotUpgrades maps each past schema version to the function that upgrades a
+// snapshot of that version to the next one.
+var snapshotUpgrades = map[int]func(Snapshot) Snapshot{
+	1: upgradeSnapshotV1,
+	2: bumpSnapshotVersion(3),
+	3: bumpSnapshotVersion(4),
+	4: bumpSnapshotVersion(5),
+	5: upgradeSnapshotV5,
+}
+
+// upgradeSnapshotV1 defaults the CustomerID that version 1 snapshots lack.
+func upgradeSnapshotV1(s Snapshot) Snapshot {
+	if s.CustomerID == "" {
+		s.CustomerID = UnknownCustomerID
+	}
+	s.SchemaVersion = 2
+	return s
+}
+
+// bumpSnapshotVersion upgrades to version to by only setting the version. It serves
+// the versions that added Instructions (3), TrackingCode (4) and ReservationID (5):
+// orders saved before those fields existed had none, which the empty string expresses.
+func bumpSnapshotVersion(to int) func(Snapshot) Snapshot {
+	return func(s Snapshot) Snapshot {
+		s.SchemaVersion = to
+		return s
+	}
+}
+
+// upgradeSnapshotV5 puts the items of orders saved before tax categories existed in
+// [orderitem.TaxCategoryStandard], the category they were taxed under.
+func upgradeSnapshotV5(s Snapshot) Snapshot {
+	items := slices.Clone(s.Items)
+	for i := range items {
+		if !items[i].TaxCategory.IsValid() {
+			items[i].TaxCategory = orderitem.TaxCategoryStandard
+		}
+	}
+	s.Items = items
+	s.SchemaVersion = 6
+	return s
+}
+
+// Snapshot returns the current-version [Snapshot] of the order. Items, payments,
+// credits, the coupon, the tax policy and the return request are copied, so changes to
+// the snapshot do not affect the order.
+func (o *Order) Snapshot() Snapshot {
+	return Snapshot{
+		SchemaVersion:   CurrentSnapshotVersion,
+		ID:              o.ID,
+		UpdatedAt:       o.UpdatedAt,
+		CustomerID:      o.CustomerID,
+		DeliveryAddress: o.DeliveryAddress,
+		TotalAmount:     o.TotalAmount,
+		DiscountAmount:  o.DiscountAmount,
+		Coupon:          cloneCoupon(o.Coupon),
+		Credits:         slices.Clone(o.Credits),
+		TaxRate:         o.TaxRate,
+		TaxPolicy:       maps.Clone(o.taxPolicy),
+		ShippingMethod:  o.ShippingMethod,
+		Status:          o.Status,
+		Number:          o.Number,
+		IsGift:          o.IsGift,
+		OnHold:          o.OnHold,
+		HoldReason:      o.HoldReason,
+		Instructions:    o.Instructions,
+		TrackingCode:    o.TrackingCode,
+		ReservationID:   o.ReservationID,
+		ReturnRequest:   cloneReturnRequest(o.ReturnRequest),
+		CreatedAt:       o.CreatedAt,
+		DeliveredAt:     o.DeliveredAt,
+		Items:           o.Items(),
+		Payments:        o.Payments(),
+	}
+}
+
+// FromSnapshot rebuilds an Order from s, first upgrading it one version at a time to
+// [CurrentSnapshotVersion]. It returns [ErrUnsupportedSnapshotVersion] for versions it
+// cannot upgrade, [ErrInvalidOrderStatus] when the status does not parse with
+// [ParseStatus], [ErrInvalidShippingMethod] for an undefined shipping method, and the
+// error of [Order.Validate] when the payments break an invariant.
+//
+// Lines of legacy data that repeat a product are restored as they are, next to the
+// first line of that product; [Order.Deduplicate] merges them. No domain events are
+// raised and nothing is recalculated or timestamped; the order is restored, not changed.
+func FromSnapshot(s Snapshot) (*Order, error) {
+	if s.SchemaVersion == 0 {
+		s.SchemaVersion = 1
+	}
+	for s.SchemaVersion < CurrentSnapshotVersion {
+		upgrade, ok := snapshotUpgrades[s.SchemaVersion]
+		if !ok {
+			return nil, ErrUnsupportedSnapshotVersion
+		}
+		s = upgrade(s)
+	}
+	if s.SchemaVersion != CurrentSnapshotVersion {
+		return nil, ErrUnsupportedSnapshotVersion
+	}
+
+	status, statusErr := ParseStatus(s.Status.value)
+	if err := errs.Join(
+		statusErr,
+		guard.CheckValidEnum(s.ShippingMethod, ErrInvalidShippingMethod),
+	); err != nil {
+		return nil, err
+	}
+
+	o := &Order{
+		AggregateRoot:   kernel.AggregateRoot{ID: s.ID, UpdatedAt: s.UpdatedAt},
+		CustomerID:      s.CustomerID,
+		DeliveryAddress: s.DeliveryAddress,
+		TotalAmount:     s.TotalAmount,
+		DiscountAmount:  s.DiscountAmount,
+		Coupon:          cloneCoupon(s.Coupon),
+		Credits:         slices.Clone(s.Credits),
+		TaxRate:         s.TaxRate,
+		ShippingMethod:  s.ShippingMethod,
+		Status:          status,
+		Number:          s.Number,
+		IsGift:          s.IsGift,
+		OnHold:          s.OnHold,
+		HoldReason:      s.HoldReason,
+		Instructions:    s.Instructions,
+		TrackingCode:    s.TrackingCode,
+		ReservationID:   s.ReservationID,
+		ReturnRequest:   cloneReturnRequest(s.ReturnRequest),
+		CreatedAt:       s.CreatedAt,
+		DeliveredAt:     s.DeliveredAt,
+		items:           make(map[string]*orderitem.OrderItem, len(s.Items)),
+		payments:        make([]*payment.Payment, 0, len(s.Payments)),
+		taxPolicy:       maps.Clone(s.TaxPolicy),
+	}
+	for i, item := range s.Items {
+		key := item.ProductID
+		if _, repeated := o.items[key]; repeated {
+			key = repeatedLineKey(item.ProductID, i)
+		}
+		o.items[key] = &item
+	}
+	for _, p := range s.Payments {
+		o.payments = append(o.payments, &p)
+	}
+
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// cloneCoupon returns a copy of c, or nil when c is nil.
+func cloneCoupon(c *promo.Coupon) *promo.Coupon {
+	if c == nil {
+		return nil
+	}
+	copied := *c
+	return &copied
+}
+
+// cloneReturnRequest returns a copy of r with its own item IDs, or nil when r is nil.
+func cloneReturnRequest(r *ReturnRequest) *ReturnRequest {
+	if r == nil {
+		return nil
+	}
+	copied := *r
+	copied.ItemIDs = slices.Clone(r.ItemIDs)
+	return &copied
+}
+
+// repeatedLineKey returns the key under which the line at index i of a snapshot is kept
+// when an earlier line already holds productID.
+func repeatedLineKey(productID string, i int) string {
+	return productID + "#" + strconv.Itoa(i)
+}
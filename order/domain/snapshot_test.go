@@ -0,0 +1,256 @@
+package order_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/orderitem"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/promo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromSnapshot(t *testing.T) {
+	t.Run("should restore the order written by Snapshot", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.SetInstructions("leave at the door"))
+		_, err := o.StartPayment(payment.MethodPix)
+		require.NoError(t, err)
+		snapshot := o.Snapshot()
+
+		got, err := order.FromSnapshot(snapshot)
+
+		require.NoError(t, err)
+		assert.Equal(t, snapshot, got.Snapshot())
+		assert.Equal(t, o.Items(), got.Items())
+		assert.Equal(t, o.Total(), got.Total())
+		assert.Equal(t, "leave at the door", got.Instructions)
+		assert.Empty(t, got.PullDomainEvents(), "restoring should not raise events")
+	})
+
+	t.Run("should load a version 2 snapshot without instructions", func(t *testing.T) {
+		snapshot := createOrderWithItems(t).Snapshot()
+		snapshot.SchemaVersion = 2
+
+		got, err := order.FromSnapshot(snapshot)
+
+		require.NoError(t, err)
+		assert.Empty(t, got.Instructions)
+		assert.Equal(t, order.CurrentSnapshotVersion, got.Snapshot().SchemaVersion)
+	})
+
+	t.Run("should load a version 3 snapshot without a tracking code", func(t *testing.T) {
+		snapshot := createOrderWithItems(t).Snapshot()
+		snapshot.SchemaVersion = 3
+
+		got, err := order.FromSnapshot(snapshot)
+
+		require.NoError(t, err)
+		assert.Empty(t, got.TrackingCode)
+		assert.Equal(t, order.CurrentSnapshotVersion, got.Snapshot().SchemaVersion)
+	})
+
+	t.Run("should load a version 4 snapshot without a reservation", func(t *testing.T) {
+		snapshot := createOrderWithItems(t).Snapshot()
+		snapshot.SchemaVersion = 4
+
+		got, err := order.FromSnapshot(snapshot)
+
+		require.NoError(t, err)
+		assert.Empty(t, got.ReservationID)
+		assert.Equal(t, order.CurrentSnapshotVersion, got.Snapshot().SchemaVersion)
+	})
+
+	t.Run("should load a version 5 snapshot with its items in the standard tax category", func(t *testing.T) {
+		snapshot := createOrderWithItems(t).Snapshot()
+		snapshot.SchemaVersion = 5
+		snapshot.Items[0].TaxCategory = orderitem.TaxCategory{}
+
+		got, err := order.FromSnapshot(snapshot)
+
+		require.NoError(t, err)
+		assert.Equal(t, orderitem.TaxCategoryStandard, got.Items()[0].TaxCategory)
+		assert.Equal(t, order.CurrentSnapshotVersion, got.Snapshot().SchemaVersion)
+	})
+
+	t.Run("should upgrade a version 1 snapshot to the current shape", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			version int
+		}{
+			{name: "explicit version 1", version: 1},
+			{name: "snapshot written before the version field", version: 0},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				snapshot := createOrderWithItems(t).Snapshot()
+				snapshot.SchemaVersion = tt.version
+				snapshot.CustomerID = ""
+
+				got, err := order.FromSnapshot(snapshot)
+
+				require.NoError(t, err)
+				assert.Equal(t, order.UnknownCustomerID, got.CustomerID)
+				assert.Equal(t, order.CurrentSnapshotVersion, got.Snapshot().SchemaVersion)
+				assert.Len(t, got.Items(), 1)
+				assert.Equal(t, order.StatusPending, got.Status)
+			})
+		}
+	})
+
+	t.Run("should return an error for a version newer than the current one", func(t *testing.T) {
+		snapshot := createValidOrder(t).Snapshot()
+		snapshot.SchemaVersion = order.CurrentSnapshotVersion + 1
+
+		got, err := order.FromSnapshot(snapshot)
+
+		assert.ErrorIs(t, err, order.ErrUnsupportedSnapshotVersion)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return an error for uninitialized enum values", func(t *testing.T) {
+		snapshot := createValidOrder(t).Snapshot()
+		snapshot.Status = order.Status{}
+		snapshot.ShippingMethod = order.ShippingMethod{}
+
+		got, err := order.FromSnapshot(snapshot)
+
+		assert.ErrorIs(t, err, order.ErrInvalidOrderStatus)
+		assert.ErrorIs(t, err, order.ErrInvalidShippingMethod)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should restore every defined status", func(t *testing.T) {
+		for _, status := range []order.Status{order.StatusPending, order.StatusPaid, order.StatusSeparating, order.StatusShipped, order.StatusDelivered, order.StatusCancelled} {
+			snapshot := createValidOrder(t).Snapshot()
+			snapshot.Status = status
+
+			got, err := order.FromSnapshot(snapshot)
+
+			require.NoError(t, err, status.String())
+			assert.Equal(t, status, got.Status)
+		}
+	})
+
+	t.Run("should reject a persisted status outside the defined range", func(t *testing.T) {
+		var snapshot order.Snapshot
+		err := json.Unmarshal([]byte(`{"status":"99"}`), &snapshot)
+		require.ErrorIs(t, err, order.ErrInvalidOrderStatus, "decoding should not accept the unknown status")
+
+		got, err := order.FromSnapshot(snapshot)
+
+		assert.ErrorIs(t, err, order.ErrInvalidOrderStatus)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should restore lines that repeat a product without changing the order", func(t *testing.T) {
+		snapshot := createOrderWithItems(t).Snapshot()
+		duplicate := *kernel.Must(orderitem.NewOrderItem("prod-1", "Widget", 40.0, 1))
+		snapshot.Items = append(snapshot.Items, duplicate)
+
+		got, err := order.FromSnapshot(snapshot)
+
+		require.NoError(t, err)
+		assert.Equal(t, snapshot.Items, got.Items(), "every line should be kept as it was")
+		assert.Equal(t, snapshot.TotalAmount, got.TotalAmount, "the total should not be recalculated")
+		assert.Equal(t, snapshot.UpdatedAt, got.UpdatedAt, "the order should not be touched")
+	})
+
+	t.Run("should return an error when more than one payment is active", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		snapshot := o.Snapshot()
+		snapshot.Payments = []payment.Payment{
+			*kernel.Must(payment.NewPayment(o.ID, o.TotalAmount, payment.MethodPix)),
+			*kernel.Must(payment.NewPayment(o.ID, o.TotalAmount, payment.MethodPix)),
+		}
+
+		got, err := order.FromSnapshot(snapshot)
+
+		assert.ErrorIs(t, err, order.ErrMultipleActivePayments)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSnapshot_MarshalJSON(t *testing.T) {
+	t.Run("should survive a JSON round trip with every field intact", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		coupon := kernel.Must(promo.NewCoupon("SAVE10", promo.DiscountTypePercentage, 10, 0))
+		require.NoError(t, o.ApplyCoupon(*coupon))
+		require.NoError(t, o.AddCredit(5, "late delivery"))
+		require.NoError(t, o.ApplyTaxPolicy(order.TaxPolicy{"SP": 0.1}))
+		p, err := o.StartPayment(payment.MethodPix)
+		require.NoError(t, err)
+		require.NoError(t, p.DefineTransactionCode("TX-123456789"))
+		snapshot := o.Snapshot()
+
+		data, err := json.Marshal(snapshot)
+		require.NoError(t, err)
+		var decoded order.Snapshot
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		got, err := order.FromSnapshot(decoded)
+
+		require.NoError(t, err)
+		assert.True(t, o.DeliveryAddress.Equals(&got.DeliveryAddress), "delivery address should be kept")
+		require.NotNil(t, got.Coupon)
+		assert.True(t, coupon.Equals(*got.Coupon), "coupon should be kept")
+		assert.Equal(t, o.Credits, got.Credits)
+		assert.Equal(t, o.Total(), got.Total())
+		code, ok := got.Payments()[0].TransactionCodeValue()
+		assert.True(t, ok)
+		assert.Equal(t, "TX-123456789", code, "transaction code should be stored unmasked")
+		again, err := json.Marshal(got.Snapshot())
+		require.NoError(t, err)
+		assert.JSONEq(t, string(data), string(again))
+	})
+}
+
+func TestOrder_Snapshot(t *testing.T) {
+	t.Run("should not share the return request with the order", func(t *testing.T) {
+		o := driveOrderToDelivered(t)
+		require.NoError(t, o.RequestReturn([]string{o.Items()[0].ID}, order.CancellationReasonCustomerCancelled))
+		snapshot := o.Snapshot()
+
+		snapshot.ReturnRequest.ItemIDs[0] = "changed"
+		snapshot.ReturnRequest.Reason = order.CancellationReasonOther
+
+		assert.Equal(t, o.Items()[0].ID, o.ReturnRequest.ItemIDs[0])
+		assert.Equal(t, order.CancellationReasonCustomerCancelled, o.ReturnRequest.Reason)
+	})
+
+	t.Run("should not share the coupon or tax policy with the order", func(t *testing.T) {
+		o := createOrderWithItems(t)
+		require.NoError(t, o.ApplyCoupon(*kernel.Must(promo.NewCoupon("SAVE10", promo.DiscountTypePercentage, 10, 0))))
+		require.NoError(t, o.ApplyTaxPolicy(order.TaxPolicy{"SP": 0.1}))
+		snapshot := o.Snapshot()
+
+		snapshot.TaxPolicy["SP"] = 0.5
+		*snapshot.Coupon = *kernel.Must(promo.NewCoupon("OTHER", promo.DiscountTypeAbsolute, 1, 0))
+
+		assert.Equal(t, "SAVE10", o.Coupon.Code())
+		assert.Equal(t, 0.1, o.Snapshot().TaxPolicy["SP"])
+	})
+}
+
+func TestSnapshot_UnmarshalJSON(t *testing.T) {
+	t.Run("should decode the enums written by Snapshot", func(t *testing.T) {
+		var got order.Snapshot
+
+		err := json.Unmarshal([]byte(`{"status":"shipped","shipping_method":"express"}`), &got)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusShipped, got.Status)
+		assert.Equal(t, order.ShippingMethodExpress, got.ShippingMethod)
+	})
+
+	t.Run("should return an error for an unknown status instead of a zero value", func(t *testing.T) {
+		var got order.Snapshot
+
+		err := json.Unmarshal([]byte(`{"status":"shipped_typo"}`), &got)
+
+		assert.ErrorIs(t, err, order.ErrInvalidOrderStatus)
+		assert.Equal(t, order.Status{}, got.Status, "status should not be decoded")
+	})
+}
@@ -0,0 +1,56 @@
+package order
+
+import (
+	"slices"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+var ErrCorruptHistory = errs.New("ORDER.CORRUPT_HISTORY", "status history does not describe a legal order lifecycle")
+
+// StatusChange is one entry of an order status audit trail: the order moved From one
+// status To another At the given time.
+type StatusChange struct {
+	From Status    `json:"from"`
+	To   Status    `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+// statusTransitions lists, for each status, the statuses the [Order] methods can move
+// it to.
+var statusTransitions = map[Status][]Status{
+	StatusPending:    {StatusPaid, StatusCancelled},
+	StatusPaid:       {StatusSeparating, StatusCancelled},
+	StatusSeparating: {StatusShipped, StatusCancelled},
+	StatusShipped:    {StatusDelivered, StatusCancelled},
+	StatusDelivered:  {StatusCancelled},
+}
+
+// CanTransitionTo reports whether an order in status s can move to next.
+func (s Status) CanTransitionTo(next Status) bool {
+	return slices.Contains(statusTransitions[s], next)
+}
+
+// ReplayStatus returns a copy of history sorted chronologically by At; entries recorded
+// at the same instant keep their relative order.
+func ReplayStatus(history []StatusChange) []StatusChange {
+	replayed := slices.Clone(history)
+	slices.SortStableFunc(replayed, func(a, b StatusChange) int { return a.At.Compare(b.At) })
+	return replayed
+}
+
+// ValidateStatusHistory replays history (see [ReplayStatus]) and checks that it forms a
+// legal path: it starts from [StatusPending], every change starts where the previous one
+// ended, and every change is allowed by [Status.CanTransitionTo]. Otherwise, e.g. when an
+// audit log was tampered with, it returns [ErrCorruptHistory]. An empty history is legal.
+func ValidateStatusHistory(history []StatusChange) error {
+	current := StatusPending
+	for _, change := range ReplayStatus(history) {
+		if !change.From.Equals(current) || !change.From.CanTransitionTo(change.To) {
+			return ErrCorruptHistory
+		}
+		current = change.To
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package order_test
+
+import (
+	"testing"
+	"time"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var historyStart = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestReplayStatus(t *testing.T) {
+	t.Run("should sort the history chronologically without touching the input", func(t *testing.T) {
+		paid := order.StatusChange{From: order.StatusPending, To: order.StatusPaid, At: historyStart}
+		separating := order.StatusChange{From: order.StatusPaid, To: order.StatusSeparating, At: historyStart.Add(time.Hour)}
+		history := []order.StatusChange{separating, paid}
+
+		got := order.ReplayStatus(history)
+
+		assert.Equal(t, []order.StatusChange{paid, separating}, got)
+		assert.Equal(t, separating, history[0], "the input should not be reordered")
+	})
+}
+
+func TestValidateStatusHistory(t *testing.T) {
+	at := func(hours int) time.Time { return historyStart.Add(time.Duration(hours) * time.Hour) }
+
+	t.Run("should accept a legal history recorded out of order", func(t *testing.T) {
+		history := []order.StatusChange{
+			{From: order.StatusShipped, To: order.StatusDelivered, At: at(3)},
+			{From: order.StatusPending, To: order.StatusPaid, At: at(0)},
+			{From: order.StatusSeparating, To: order.StatusShipped, At: at(2)},
+			{From: order.StatusPaid, To: order.StatusSeparating, At: at(1)},
+		}
+
+		assert.NoError(t, order.ValidateStatusHistory(history))
+	})
+
+	t.Run("should accept an empty history", func(t *testing.T) {
+		assert.NoError(t, order.ValidateStatusHistory(nil))
+	})
+
+	t.Run("should return ErrCorruptHistory for an illegal history", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			history []order.StatusChange
+		}{
+			{
+				name:    "jump from Pending to Delivered",
+				history: []order.StatusChange{{From: order.StatusPending, To: order.StatusDelivered, At: at(0)}},
+			},
+			{
+				name:    "history not starting from Pending",
+				history: []order.StatusChange{{From: order.StatusPaid, To: order.StatusSeparating, At: at(0)}},
+			},
+			{
+				name: "change not starting where the previous one ended",
+				history: []order.StatusChange{
+					{From: order.StatusPending, To: order.StatusPaid, At: at(0)},
+					{From: order.StatusSeparating, To: order.StatusShipped, At: at(1)},
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := order.ValidateStatusHistory(tt.history)
+
+				assert.ErrorIs(t, err, order.ErrCorruptHistory)
+			})
+		}
+	})
+}
+
+func TestStatus_CanTransitionTo(t *testing.T) {
+	assert.True(t, order.StatusPending.CanTransitionTo(order.StatusPaid))
+	assert.True(t, order.StatusShipped.CanTransitionTo(order.StatusCancelled))
+	assert.True(t, order.StatusPaid.CanTransitionTo(order.StatusCancelled))
+	assert.False(t, order.StatusPending.CanTransitionTo(order.StatusDelivered))
+	assert.False(t, order.StatusCancelled.CanTransitionTo(order.StatusPending), "Cancelled is final")
+}
@@ -0,0 +1,28 @@
+package order
+
+import "time"
+
+// Summary is a lightweight read-side projection of an Order, carrying just enough
+// to list orders without loading their items and payments.
+type Summary struct {
+	OrderID     string     `json:"order_id"`
+	Number      string     `json:"number"`
+	CustomerID  string     `json:"customer_id"`
+	Status      Status     `json:"status"`
+	ItemCount   int        `json:"item_count"` // number of lines, not units
+	TotalAmount float64    `json:"total_amount"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+// Summary returns the current [Summary] projection of the order.
+func (o *Order) Summary() Summary {
+	return Summary{
+		OrderID:     o.ID,
+		Number:      o.Number,
+		CustomerID:  o.CustomerID,
+		Status:      o.Status,
+		ItemCount:   len(o.items),
+		TotalAmount: o.TotalAmount,
+		UpdatedAt:   o.UpdatedAt,
+	}
+}
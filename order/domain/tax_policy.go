@@ -0,0 +1,26 @@
+package order
+
+import "github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+
+var ErrInvalidTaxRate = errs.New("ORDER.INVALID_TAX_RATE", "tax rate must be in the range [0, 1)")
+
+// TaxPolicy maps each destination state (UF code) to the tax rate applied to orders
+// delivered there, expressed as a fraction of the taxable base (e.g. 0.18 means 18%).
+// States absent from the policy are not taxed. The rate applies in full to standard
+// items only, see [Order.TaxAmount].
+type TaxPolicy map[string]float64
+
+// Validate reports [ErrInvalidTaxRate] if any rate is outside [0, 1), NaN included.
+func (p TaxPolicy) Validate() error {
+	for _, rate := range p {
+		if !(rate >= 0 && rate < 1) {
+			return ErrInvalidTaxRate
+		}
+	}
+	return nil
+}
+
+// RateFor returns the tax rate for state, or 0 if the policy does not cover it.
+func (p TaxPolicy) RateFor(state string) float64 {
+	return p[state]
+}
@@ -0,0 +1,43 @@
+package order_test
+
+import (
+	"math"
+	"testing"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  order.TaxPolicy
+		wantErr error
+	}{
+		{name: "should accept an empty policy", policy: order.TaxPolicy{}},
+		{name: "should accept rates within [0, 1)", policy: order.TaxPolicy{"SP": 0, "RJ": 0.2}},
+		{name: "should return an error when a rate is negative", policy: order.TaxPolicy{"SP": -0.1}, wantErr: order.ErrInvalidTaxRate},
+		{name: "should return an error when a rate is one", policy: order.TaxPolicy{"SP": 1}, wantErr: order.ErrInvalidTaxRate},
+		{name: "should return an error when a rate is NaN", policy: order.TaxPolicy{"SP": math.NaN()}, wantErr: order.ErrInvalidTaxRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTaxPolicy_RateFor(t *testing.T) {
+	policy := order.TaxPolicy{"SP": 0.18}
+
+	t.Run("should return the rate of a covered state", func(t *testing.T) {
+		assert.Equal(t, 0.18, policy.RateFor("SP"))
+	})
+
+	t.Run("should return zero for a state not in the policy", func(t *testing.T) {
+		assert.Equal(t, 0.0, policy.RateFor("RJ"))
+	})
+}
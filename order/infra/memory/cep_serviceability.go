@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"context"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+var _ order.CEPServiceability = (*CEPServiceability)(nil)
+
+// CEPServiceability is an in-memory implementation of [order.CEPServiceability] backed
+// by a fixed set of serviced CEPs; every other CEP is reported as unserviced. It is safe
+// for concurrent use since the set is never modified after construction.
+type CEPServiceability struct {
+	serviced map[string]struct{}
+}
+
+// NewCEPServiceability creates a [CEPServiceability] that services exactly the given CEPs.
+func NewCEPServiceability(serviced ...string) *CEPServiceability {
+	set := make(map[string]struct{}, len(serviced))
+	for _, cep := range serviced {
+		set[cep] = struct{}{}
+	}
+	return &CEPServiceability{serviced: set}
+}
+
+// IsServiceable reports whether cep is in the serviced set.
+func (s *CEPServiceability) IsServiceable(ctx context.Context, cep string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	_, ok := s.serviced[cep]
+	return ok, nil
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+var _ order.InventoryReserver = (*Inventory)(nil)
+
+// Inventory is an in-memory implementation of [order.InventoryReserver] keeping the
+// available stock of each product; products never stocked have none. Reserving takes
+// the units out of the available stock until they are released. It is safe for
+// concurrent use.
+type Inventory struct {
+	mu           sync.Mutex
+	stock        map[string]int
+	reservations map[string][]order.ReservationLine
+	released     map[string]struct{}
+}
+
+// NewInventory creates an [Inventory] holding stock, the available units by product ID.
+func NewInventory(stock map[string]int) *Inventory {
+	s := make(map[string]int, len(stock))
+	for productID, units := range stock {
+		s[productID] = units
+	}
+	return &Inventory{stock: s, reservations: make(map[string][]order.ReservationLine), released: make(map[string]struct{})}
+}
+
+// Reserve takes the units of every line out of the available stock under a new
+// reservation ID, or returns [order.ErrInsufficientStock] leaving the stock untouched
+// when any product, counting every line for it, lacks units.
+func (i *Inventory) Reserve(ctx context.Context, items []order.ReservationLine) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	wanted := make(map[string]int, len(items))
+	for _, line := range items {
+		wanted[line.ProductID] += line.Quantity
+	}
+	for productID, units := range wanted {
+		if i.stock[productID] < units {
+			return "", order.ErrInsufficientStock
+		}
+	}
+
+	for productID, units := range wanted {
+		i.stock[productID] -= units
+	}
+	id := kernel.GenerateID()
+	i.reservations[id] = append([]order.ReservationLine(nil), items...)
+	return id, nil
+}
+
+// Release puts the units of reservationID back into the available stock. Releasing it
+// again does nothing; [order.ErrReservationNotFound] is returned for an unknown ID.
+func (i *Inventory) Release(ctx context.Context, reservationID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, done := i.released[reservationID]; done {
+		return nil
+	}
+	lines, ok := i.reservations[reservationID]
+	if !ok {
+		return order.ErrReservationNotFound
+	}
+	for _, line := range lines {
+		i.stock[line.ProductID] += line.Quantity
+	}
+	delete(i.reservations, reservationID)
+	i.released[reservationID] = struct{}{}
+	return nil
+}
+
+// Available returns the units of productID not reserved yet.
+func (i *Inventory) Available(productID string) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.stock[productID]
+}
@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+var _ order.NumberGenerator = (*SequentialNumbers)(nil)
+
+// SequentialNumbers is an in-memory implementation of [order.NumberGenerator] producing
+// prefix-000001, prefix-000002, and so on from an atomic counter, so concurrent calls
+// never share a number and each call gets a higher number than the calls completed
+// before it. The sequence restarts with the process, so numbers repeat across restarts
+// and between processes. It is safe for concurrent use.
+type SequentialNumbers struct {
+	prefix string
+	n      atomic.Uint64
+}
+
+// NewSequentialNumbers creates a [SequentialNumbers] starting at prefix-000001.
+func NewSequentialNumbers(prefix string) *SequentialNumbers {
+	return &SequentialNumbers{prefix: prefix}
+}
+
+// Next returns the next number of the sequence.
+func (s *SequentialNumbers) Next(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%06d", s.prefix, s.n.Add(1)), nil
+}
@@ -0,0 +1,59 @@
+package memory_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequentialNumbers_Next(t *testing.T) {
+	t.Run("should produce zero-padded numbers in sequence", func(t *testing.T) {
+		numbers := memory.NewSequentialNumbers("PED")
+
+		assert.Equal(t, "PED-000001", kernel.Must(numbers.Next(context.Background())))
+		assert.Equal(t, "PED-000002", kernel.Must(numbers.Next(context.Background())))
+	})
+
+	t.Run("should produce unique increasing numbers across goroutines", func(t *testing.T) {
+		const goroutines, perGoroutine = 50, 200
+		numbers := memory.NewSequentialNumbers("PED")
+
+		results := make([][]string, goroutines)
+		var wg sync.WaitGroup
+		for g := range goroutines {
+			wg.Go(func() {
+				for range perGoroutine {
+					results[g] = append(results[g], kernel.Must(numbers.Next(context.Background())))
+				}
+			})
+		}
+		wg.Wait()
+
+		seen := make(map[string]bool, goroutines*perGoroutine)
+		for _, drawn := range results {
+			for i, number := range drawn {
+				require.False(t, seen[number], "duplicate number %s", number)
+				seen[number] = true
+				if i > 0 {
+					assert.Greater(t, number, drawn[i-1], "numbers drawn by one goroutine should increase")
+				}
+			}
+		}
+		assert.Len(t, seen, goroutines*perGoroutine)
+		assert.True(t, seen["PED-010000"], "the sequence should have no gaps")
+	})
+
+	t.Run("should return the context error once it is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := memory.NewSequentialNumbers("PED").Next(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
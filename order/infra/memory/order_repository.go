@@ -0,0 +1,212 @@
+// Package memory provides in-memory adapters for the order bounded context ports.
+// They are intended for tests and local development and keep no state across restarts.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/guard"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+)
+
+var ErrInvalidRange = errs.New("INFRA.INVALID_RANGE", "invalid range: from must not be after to")
+
+var (
+	_ order.Repository         = (*OrderRepository)(nil)
+	_ payment.ReportRepository = (*OrderRepository)(nil)
+)
+
+// OrderRepository is an in-memory implementation of [order.Repository]. It also serves
+// the [payment.ReportRepository] read model by projecting the payments of stored orders.
+// It is safe for concurrent use.
+//
+// Orders are stored as snapshots: Save keeps a copy of the order and every read rebuilds
+// a fresh one, so stored orders only change through Save.
+type OrderRepository struct {
+	mu     sync.RWMutex
+	orders map[string]order.Snapshot
+}
+
+// NewOrderRepository creates an empty [OrderRepository].
+func NewOrderRepository() *OrderRepository {
+	return &OrderRepository{orders: make(map[string]order.Snapshot)}
+}
+
+// FindByID returns a copy of the order identified by id, or [order.ErrOrderNotFound].
+func (r *OrderRepository) FindByID(ctx context.Context, id string) (*order.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.orders[id]
+	if !ok {
+		return nil, order.ErrOrderNotFound
+	}
+	if w := workFrom(ctx); w != nil {
+		w.touch(id, &s)
+	}
+	return order.FromSnapshot(s)
+}
+
+// FindByDateRange returns copies of the orders created within [from, to], both bounds
+// inclusive, sorted by CreatedAt and restricted to page, along with the total number of
+// matches.
+// Returns [ErrInvalidRange] when from is after to.
+func (r *OrderRepository) FindByDateRange(ctx context.Context, from, to time.Time, page order.Page) ([]*order.Order, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	if err := guard.CheckBefore(from, to, ErrInvalidRange); err != nil {
+		return nil, 0, err
+	}
+
+	r.mu.RLock()
+	matches := make([]order.Snapshot, 0)
+	for _, s := range r.orders {
+		if !s.CreatedAt.Before(from) && !s.CreatedAt.After(to) {
+			matches = append(matches, s)
+		}
+	}
+	if w := workFrom(ctx); w != nil {
+		for _, s := range matches {
+			w.touch(s.ID, &s)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(matches, func(a, b order.Snapshot) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	snapshots := paginate(matches, page)
+	orders := make([]*order.Order, 0, len(snapshots))
+	for _, s := range snapshots {
+		o, err := order.FromSnapshot(s)
+		if err != nil {
+			return nil, 0, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, len(matches), nil
+}
+
+// Save inserts or replaces the order, keyed by its ID. It stores a copy, so later changes
+// to o do not reach the repository until it is saved again; pending domain events are
+// not stored.
+func (r *OrderRepository) Save(ctx context.Context, o *order.Order) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if w := workFrom(ctx); w != nil {
+		if s, ok := r.orders[o.ID]; ok {
+			w.touch(o.ID, &s)
+		} else {
+			w.touch(o.ID, nil)
+		}
+	}
+	r.orders[o.ID] = snapshotOf(o)
+	return nil
+}
+
+// snapshotOf returns the snapshot of o without the pending events of its payments, so
+// that orders rebuilt from it do not raise them again.
+func snapshotOf(o *order.Order) order.Snapshot {
+	s := o.Snapshot()
+	for i := range s.Payments {
+		s.Payments[i].ClearDomainEvent()
+	}
+	return s
+}
+
+// SummarizeByStatusAndMethod groups the payments of every stored order created within
+// [from, to], both bounds inclusive, by status and method. Rows are sorted by status and
+// then method name. Returns [ErrInvalidRange] when from is after to.
+func (r *OrderRepository) SummarizeByStatusAndMethod(ctx context.Context, from, to time.Time) ([]payment.ReportRow, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := guard.CheckBefore(from, to, ErrInvalidRange); err != nil {
+		return nil, err
+	}
+
+	type groupKey struct {
+		status payment.Status
+		method payment.Method
+	}
+	groups := make(map[groupKey]*payment.ReportRow)
+
+	r.mu.RLock()
+	for _, s := range r.orders {
+		for _, p := range s.Payments {
+			if p.CreatedAt.Before(from) || p.CreatedAt.After(to) {
+				continue
+			}
+			key := groupKey{status: p.Status, method: p.Method}
+			row, ok := groups[key]
+			if !ok {
+				row = &payment.ReportRow{Status: p.Status, Method: p.Method}
+				groups[key] = row
+			}
+			row.Count++
+			row.TotalAmount += p.Amount
+		}
+	}
+	r.mu.RUnlock()
+
+	rows := make([]payment.ReportRow, 0, len(groups))
+	for _, row := range groups {
+		rows = append(rows, *row)
+	}
+	slices.SortFunc(rows, func(a, b payment.ReportRow) int {
+		if c := cmp.Compare(a.Status.String(), b.Status.String()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Method.String(), b.Method.String())
+	})
+	return rows, nil
+}
+
+// restore puts back the snapshots, keyed by ID, and removes the orders whose snapshot is
+// nil, i.e. that did not exist before.
+func (r *OrderRepository) restore(snapshots map[string]*order.Snapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, s := range snapshots {
+		if s == nil {
+			delete(r.orders, id)
+			continue
+		}
+		r.orders[id] = *s
+	}
+}
+
+func paginate[T any](items []T, page order.Page) []T {
+	if page.Size <= 0 {
+		return items
+	}
+
+	number := max(page.Number, 1)
+	start := (number - 1) * page.Size
+	if start >= len(items) {
+		return []T{}
+	}
+	end := min(start+page.Size, len(items))
+	return items[start:end]
+}
@@ -0,0 +1,181 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==================== Helpers ==================== //
+
+var baseDate = time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func createOrderAt(t *testing.T, createdAt time.Time) *order.Order {
+	t.Helper()
+	addr := kernel.Must(order.NewDeliveryAddress("12345-678", "Rua das Flores", "100", "", "Centro", "São Paulo", "SP", "Brasil"))
+	o := kernel.Must(order.NewOrder("cust-123", addr))
+	o.CreatedAt = createdAt
+	return o
+}
+
+func seedRepository(t *testing.T, orders ...*order.Order) *memory.OrderRepository {
+	t.Helper()
+	repo := memory.NewOrderRepository()
+	for _, o := range orders {
+		require.NoError(t, repo.Save(context.Background(), o))
+	}
+	return repo
+}
+
+func orderIDs(orders []*order.Order) []string {
+	ids := make([]string, 0, len(orders))
+	for _, o := range orders {
+		ids = append(ids, o.ID)
+	}
+	return ids
+}
+
+// ==================== Tests ==================== //
+
+func TestOrderRepository_FindByID(t *testing.T) {
+	t.Run("should return a saved order", func(t *testing.T) {
+		o := createOrderAt(t, baseDate)
+		repo := seedRepository(t, o)
+
+		got, err := repo.FindByID(context.Background(), o.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, o.Snapshot(), got.Snapshot())
+	})
+
+	t.Run("should keep the stored order apart from the ones saved and returned", func(t *testing.T) {
+		o := createOrderAt(t, baseDate)
+		repo := seedRepository(t, o)
+
+		require.NoError(t, o.Hold("fraud review"))
+		got := kernel.Must(repo.FindByID(context.Background(), o.ID))
+		got.Instructions = "leave at the door"
+
+		stored := kernel.Must(repo.FindByID(context.Background(), o.ID))
+		assert.NotSame(t, got, stored)
+		assert.False(t, stored.OnHold, "a change to the saved order should not reach the store")
+		assert.Empty(t, stored.Instructions, "a change to a returned order should not reach the store")
+	})
+
+	t.Run("should return an error when order does not exist", func(t *testing.T) {
+		repo := seedRepository(t)
+
+		got, err := repo.FindByID(context.Background(), "unknown-id")
+
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, order.ErrOrderNotFound)
+	})
+}
+
+func TestOrderRepository_FindByDateRange(t *testing.T) {
+	day1 := createOrderAt(t, baseDate)
+	day2 := createOrderAt(t, baseDate.AddDate(0, 0, 1))
+	day3 := createOrderAt(t, baseDate.AddDate(0, 0, 2))
+	day10 := createOrderAt(t, baseDate.AddDate(0, 0, 9))
+	repo := seedRepository(t, day10, day3, day1, day2)
+
+	t.Run("should return orders within the range with inclusive bounds", func(t *testing.T) {
+		got, total, err := repo.FindByDateRange(context.Background(), day1.CreatedAt, day3.CreatedAt, order.Page{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, orderIDs([]*order.Order{day1, day2, day3}), orderIDs(got), "orders should be sorted by CreatedAt")
+	})
+
+	t.Run("should paginate the matches and report the total", func(t *testing.T) {
+		got, total, err := repo.FindByDateRange(context.Background(), day1.CreatedAt, day10.CreatedAt, order.Page{Number: 2, Size: 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		assert.Equal(t, []string{day10.ID}, orderIDs(got))
+	})
+
+	t.Run("should return no orders when the range matches nothing", func(t *testing.T) {
+		from := baseDate.AddDate(0, 0, 3)
+		to := baseDate.AddDate(0, 0, 8)
+
+		got, total, err := repo.FindByDateRange(context.Background(), from, to, order.Page{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return an error when the range is inverted", func(t *testing.T) {
+		got, total, err := repo.FindByDateRange(context.Background(), day3.CreatedAt, day1.CreatedAt, order.Page{})
+
+		assert.Nil(t, got)
+		assert.Equal(t, 0, total)
+		assert.ErrorIs(t, err, memory.ErrInvalidRange)
+	})
+}
+
+func TestOrderRepository_SummarizeByStatusAndMethod(t *testing.T) {
+	startPayment := func(t *testing.T, o *order.Order, method payment.Method) *payment.Payment {
+		t.Helper()
+		p, err := o.StartPayment(method)
+		require.NoError(t, err)
+		require.NoError(t, p.DefineTransactionCode("TXN-"+p.ID))
+		return p
+	}
+
+	clock := testfixtures.Clock(t)
+	from := kernel.Now()
+
+	// order 1: a refused card payment followed by an authorized one
+	o1 := testfixtures.ValidOrder(t)
+	require.NoError(t, startPayment(t, o1, payment.MethodCreditCard).RefusePayment())
+	require.NoError(t, startPayment(t, o1, payment.MethodCreditCard).ConfirmPayment())
+	// order 2: an authorized card payment
+	o2 := testfixtures.ValidOrder(t, testfixtures.WithItem("prod-2", "Gadget", 30.0, 1))
+	require.NoError(t, startPayment(t, o2, payment.MethodCreditCard).ConfirmPayment())
+	// order 3: a pending Pix payment
+	o3 := testfixtures.ValidOrder(t)
+	startPayment(t, o3, payment.MethodPix)
+	to := kernel.Now()
+	// order 4: created after the reporting window
+	clock.Advance(time.Hour)
+	o4 := testfixtures.ValidOrder(t)
+	startPayment(t, o4, payment.MethodPix)
+
+	repo := seedRepository(t, o1, o2, o3, o4)
+
+	t.Run("should group and sum the payments created within the range", func(t *testing.T) {
+		got, err := repo.SummarizeByStatusAndMethod(context.Background(), from, to)
+
+		require.NoError(t, err)
+		want := []payment.ReportRow{
+			{Status: payment.StatusAuthorized, Method: payment.MethodCreditCard, Count: 2, TotalAmount: 130.0},
+			{Status: payment.StatusPending, Method: payment.MethodPix, Count: 1, TotalAmount: 100.0},
+			{Status: payment.StatusRefused, Method: payment.MethodCreditCard, Count: 1, TotalAmount: 100.0},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("should return no rows when the range matches nothing", func(t *testing.T) {
+		got, err := repo.SummarizeByStatusAndMethod(context.Background(), to.Add(time.Minute), to.Add(time.Minute*2))
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return an error when the range is inverted", func(t *testing.T) {
+		got, err := repo.SummarizeByStatusAndMethod(context.Background(), to, from.Add(-time.Minute))
+
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, memory.ErrInvalidRange)
+	})
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+)
+
+var _ app.Outbox = (*Outbox)(nil)
+
+// Outbox is an in-memory implementation of [app.Outbox] that keeps every event in the
+// order it was added. It is safe for concurrent use.
+type Outbox struct {
+	mu     sync.Mutex
+	events []kernel.DomainEvent
+}
+
+// NewOutbox creates an empty [Outbox].
+func NewOutbox() *Outbox {
+	return &Outbox{}
+}
+
+// Add appends events to the outbox.
+func (o *Outbox) Add(ctx context.Context, events ...kernel.DomainEvent) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if w := workFrom(ctx); w != nil {
+		w.add(events)
+	}
+	o.events = append(o.events, events...)
+	return nil
+}
+
+// Events returns a copy of the events added so far.
+func (o *Outbox) Events() []kernel.DomainEvent {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return append([]kernel.DomainEvent(nil), o.events...)
+}
+
+// remove drops the events whose IDs are in ids.
+func (o *Outbox) remove(ids map[string]struct{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.events = slices.DeleteFunc(o.events, func(e kernel.DomainEvent) bool {
+		_, ok := ids[e.EventID()]
+		return ok
+	})
+}
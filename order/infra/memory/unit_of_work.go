@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/app"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+)
+
+var _ app.UnitOfWork = (*UnitOfWork)(nil)
+
+// UnitOfWork is an in-memory implementation of [app.UnitOfWork] over an
+// [OrderRepository] and an [Outbox]. Units run one at a time.
+//
+// The repository and the outbox record, through the context passed to fn, every order
+// the unit loads or saves and every event it adds. Rolling back puts back the stored
+// state of those orders as it was when the unit first touched them and drops those
+// events; writes made outside the unit are kept.
+type UnitOfWork struct {
+	mu     sync.Mutex
+	orders *OrderRepository
+	outbox *Outbox
+}
+
+// NewUnitOfWork creates a [UnitOfWork] over orders and outbox.
+func NewUnitOfWork(orders *OrderRepository, outbox *Outbox) *UnitOfWork {
+	return &UnitOfWork{orders: orders, outbox: outbox}
+}
+
+// Do runs fn and rolls back what it did to the repository and the outbox when it
+// returns an error.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	w := &work{orders: make(map[string]*order.Snapshot), events: make(map[string]struct{})}
+	if err := fn(context.WithValue(ctx, workKey{}, w)); err != nil {
+		u.outbox.remove(w.events)
+		u.orders.restore(w.orders)
+		return err
+	}
+	return nil
+}
+
+// workKey is the context key under which [UnitOfWork.Do] passes its [work].
+type workKey struct{}
+
+// work records what a running unit touched.
+type work struct {
+	mu     sync.Mutex
+	orders map[string]*order.Snapshot // state before the unit touched each order; nil when it did not exist
+	events map[string]struct{}        // IDs of the events the unit added
+}
+
+// workFrom returns the work of the unit running in ctx, or nil outside a unit.
+func workFrom(ctx context.Context) *work {
+	w, _ := ctx.Value(workKey{}).(*work)
+	return w
+}
+
+// touch records the stored snapshot of the order identified by id before the unit first
+// touched it; stored is nil when no such order exists yet.
+func (w *work) touch(id string, stored *order.Snapshot) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, seen := w.orders[id]; seen {
+		return
+	}
+	w.orders[id] = stored
+}
+
+// add records events as added by the unit.
+func (w *work) add(events []kernel.DomainEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, e := range events {
+		w.events[e.EventID()] = struct{}{}
+	}
+}
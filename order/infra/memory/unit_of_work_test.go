@@ -0,0 +1,76 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnitFailed = errors.New("unit failed")
+
+func TestUnitOfWork_Do(t *testing.T) {
+	t.Run("should restore the orders the unit loaded and drop the ones it created", func(t *testing.T) {
+		loaded := createOrderAt(t, baseDate)
+		repo := seedRepository(t, loaded)
+		uow := memory.NewUnitOfWork(repo, memory.NewOutbox())
+		created := createOrderAt(t, baseDate)
+
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			o := kernel.Must(repo.FindByID(ctx, loaded.ID))
+			require.NoError(t, o.Hold("fraud review"))
+			require.NoError(t, repo.Save(ctx, created))
+			return errUnitFailed
+		})
+
+		assert.ErrorIs(t, err, errUnitFailed)
+		assert.False(t, kernel.Must(repo.FindByID(context.Background(), loaded.ID)).OnHold)
+		_, err = repo.FindByID(context.Background(), created.ID)
+		assert.ErrorIs(t, err, order.ErrOrderNotFound)
+	})
+
+	t.Run("should keep the writes made outside the unit while it ran", func(t *testing.T) {
+		repo := seedRepository(t)
+		outbox := memory.NewOutbox()
+		uow := memory.NewUnitOfWork(repo, outbox)
+		inside, outside := createOrderAt(t, baseDate), createOrderAt(t, baseDate)
+		outsideEvents := outside.PullDomainEvents()
+
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			require.NoError(t, repo.Save(ctx, inside))
+			require.NoError(t, outbox.Add(ctx, inside.PullDomainEvents()...))
+			require.NoError(t, repo.Save(context.Background(), outside))
+			require.NoError(t, outbox.Add(context.Background(), outsideEvents...))
+			return errUnitFailed
+		})
+
+		assert.ErrorIs(t, err, errUnitFailed)
+		_, err = repo.FindByID(context.Background(), outside.ID)
+		assert.NoError(t, err)
+		events := outbox.Events()
+		require.Len(t, events, 1, "only the event added by the unit should be dropped")
+		assert.Equal(t, outsideEvents[0].EventID(), events[0].EventID())
+	})
+
+	t.Run("should keep every write of a unit that succeeds", func(t *testing.T) {
+		repo := seedRepository(t)
+		outbox := memory.NewOutbox()
+		uow := memory.NewUnitOfWork(repo, outbox)
+		o := createOrderAt(t, baseDate)
+
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			require.NoError(t, repo.Save(ctx, o))
+			return outbox.Add(ctx, o.PullDomainEvents()...)
+		})
+
+		require.NoError(t, err)
+		_, err = repo.FindByID(context.Background(), o.ID)
+		assert.NoError(t, err)
+		assert.Len(t, outbox.Events(), 1)
+	})
+}
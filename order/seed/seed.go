@@ -0,0 +1,70 @@
+// Package seed builds realistic orders for demo and staging environments and for
+// integration tests. Unlike testfixtures it runs the real domain flow end to end and
+// persists the result through an [order.Repository], so it does not depend on testing.
+package seed
+
+import (
+	"context"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+)
+
+// Item is a line of a [PaidOrder].
+type Item struct {
+	ProductID   string
+	ProductName string
+	UnitPrice   float64
+	Quantity    int
+}
+
+// PaidOrder describes the order built by [CreatePaidOrder].
+type PaidOrder struct {
+	CustomerID      string
+	DeliveryAddress *order.DeliveryAddress
+	Items           []Item
+	Method          payment.Method
+	TransactionCode string // gateway code recorded on the payment before it is confirmed
+}
+
+// CreatePaidOrder places the order described by spec, numbers it with the next number of
+// numbers, starts its payment, records the transaction code, confirms the payment, marks
+// the order as paid and saves it to orders. Payments are persisted as part of their order. The first error of any step is
+// returned and nothing is saved in that case.
+func CreatePaidOrder(ctx context.Context, orders order.Repository, numbers order.NumberGenerator, spec PaidOrder) (*order.Order, error) {
+	o, err := order.NewOrder(spec.CustomerID, spec.DeliveryAddress)
+	if err != nil {
+		return nil, err
+	}
+	number, err := numbers.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.AssignNumber(number); err != nil {
+		return nil, err
+	}
+	for _, item := range spec.Items {
+		if err := o.AddItem(item.ProductID, item.ProductName, item.UnitPrice, item.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	p, err := o.StartPayment(spec.Method)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := o.DefineTransactionCode(p.ID, spec.TransactionCode); err != nil {
+		return nil, err
+	}
+	if err := p.ConfirmPayment(); err != nil {
+		return nil, err
+	}
+	if err := o.HandleApprovedPaymentEvent(p.ID); err != nil {
+		return nil, err
+	}
+
+	if err := orders.Save(ctx, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
@@ -0,0 +1,63 @@
+package seed_test
+
+import (
+	"context"
+	"testing"
+
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/infra/memory"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/seed"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validSpec(t *testing.T) seed.PaidOrder {
+	t.Helper()
+	testfixtures.Clock(t)
+	return seed.PaidOrder{
+		CustomerID:      "cust-123",
+		DeliveryAddress: testfixtures.ValidAddress(t),
+		Items: []seed.Item{
+			{ProductID: "prod-1", ProductName: "Widget", UnitPrice: 50.0, Quantity: 2},
+			{ProductID: "prod-2", ProductName: "Gadget", UnitPrice: 10.0, Quantity: 1},
+		},
+		Method:          payment.MethodPix,
+		TransactionCode: "TXN-0001-ABCD",
+	}
+}
+
+func TestCreatePaidOrder(t *testing.T) {
+	t.Run("should persist a paid order with an authorized payment", func(t *testing.T) {
+		repo := memory.NewOrderRepository()
+
+		got, err := seed.CreatePaidOrder(context.Background(), repo, memory.NewSequentialNumbers("PED"), validSpec(t))
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusPaid, got.Status)
+		assert.Equal(t, "PED-000001", got.Number)
+		assert.Equal(t, 110.0, got.Total())
+		p, ok := got.ActivePayment()
+		require.True(t, ok)
+		assert.Equal(t, payment.StatusAuthorized, p.Status)
+		assert.Equal(t, got.Total(), p.Amount)
+		stored, err := repo.FindByID(context.Background(), got.ID)
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusPaid, stored.Status)
+	})
+
+	t.Run("should return the error of the failing step and save nothing", func(t *testing.T) {
+		repo := memory.NewOrderRepository()
+		spec := validSpec(t)
+		spec.TransactionCode = " "
+
+		got, err := seed.CreatePaidOrder(context.Background(), repo, memory.NewSequentialNumbers("PED"), spec)
+
+		assert.ErrorIs(t, err, payment.ErrInvalidTransactionCode)
+		assert.Nil(t, got)
+		_, total, err := repo.FindByDateRange(context.Background(), testfixtures.Epoch.AddDate(-1, 0, 0), testfixtures.Epoch.AddDate(1, 0, 0), order.Page{Number: 1, Size: 10})
+		require.NoError(t, err)
+		assert.Zero(t, total, "no order should be saved")
+	})
+}
@@ -0,0 +1,34 @@
+package testfixtures
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+)
+
+// AssertUniqueCodes fails t when two of the given sentinel errors share an
+// [errs.ErrorCode], since [errors.Is] matches domain errors by code and could no longer
+// tell them apart. It also fails for sentinels that are not an [errs.DomainError].
+// Reports whether all codes are unique.
+func AssertUniqueCodes(t testing.TB, sentinels ...error) bool {
+	t.Helper()
+
+	ok := true
+	seen := make(map[errs.ErrorCode]error, len(sentinels))
+	for _, sentinel := range sentinels {
+		var domErr *errs.DomainError
+		if !errors.As(sentinel, &domErr) {
+			t.Errorf("testfixtures: %v is not a domain error", sentinel)
+			ok = false
+			continue
+		}
+		if first, dup := seen[domErr.Code]; dup {
+			t.Errorf("testfixtures: code %s is shared by %q and %q", domErr.Code, first, sentinel)
+			ok = false
+			continue
+		}
+		seen[domErr.Code] = sentinel
+	}
+	return ok
+}
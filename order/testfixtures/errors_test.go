@@ -0,0 +1,69 @@
+package testfixtures_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel/errs"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder captures failures instead of failing the enclosing test.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertUniqueCodes(t *testing.T) {
+	errA := errs.New("TEST.A", "first failure")
+	errB := errs.New("TEST.B", "second failure")
+
+	tests := []struct {
+		name         string
+		sentinels    []error
+		want         bool
+		wantFailures int
+	}{
+		// ==================== Success cases ==================== //
+		{
+			name:      "should pass when every code is unique",
+			sentinels: []error{errA, errB},
+			want:      true,
+		},
+		{
+			name: "should pass for an empty list",
+			want: true,
+		},
+		// ==================== Failure cases ==================== //
+		{
+			name:         "should fail when two sentinels share a code",
+			sentinels:    []error{errA, errB, errs.New("TEST.A", "another first failure")},
+			want:         false,
+			wantFailures: 1,
+		},
+		{
+			name:         "should fail for a sentinel that is not a domain error",
+			sentinels:    []error{errA, errors.New("plain error")},
+			want:         false,
+			wantFailures: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+
+			got := testfixtures.AssertUniqueCodes(r, tt.sentinels...)
+
+			assert.Equal(t, tt.want, got)
+			assert.Len(t, r.failures, tt.wantFailures)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package testfixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// Golden compares the JSON document got, indented, with testdata/<name>.golden.json in
+// the package under test, failing t on any difference. Run the tests with -update to
+// rewrite the file after an intended change of the serialized shape.
+func Golden(t testing.TB, name string, got []byte) {
+	t.Helper()
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, got, "", "  "); err != nil {
+		t.Fatalf("testfixtures: invalid JSON for golden %q: %v", name, err)
+	}
+	indented.WriteByte('\n')
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("testfixtures: %v", err)
+		}
+		if err := os.WriteFile(path, indented.Bytes(), 0o644); err != nil {
+			t.Fatalf("testfixtures: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("testfixtures: %v (run with -update to create it)", err)
+	}
+	if !bytes.Equal(want, indented.Bytes()) {
+		t.Errorf("testfixtures: %s does not match the golden file\n--- want\n%s\n--- got\n%s", path, want, indented.Bytes())
+	}
+}
@@ -0,0 +1,245 @@
+// Package testfixtures builds valid order domain objects for tests across packages.
+// Every builder starts from a known-good default that can be tweaked with option
+// functions, and runs under a frozen [kernel.Clock] and a sequential ID generator so
+// timestamps and IDs are reproducible.
+package testfixtures
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	order "github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+)
+
+// Epoch is the instant the fixture clock is frozen at.
+var Epoch = time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+var (
+	clocks sync.Map // testing.TB -> *kernel.FrozenClock
+	seeded sync.Map // testing.TB -> struct{}
+)
+
+// Clock installs a [kernel.FrozenClock] stopped at [Epoch] for the duration of t and
+// returns it, so the test can advance time. Later calls within the same test return the
+// same clock. The previous clock is restored when t finishes.
+func Clock(t testing.TB) *kernel.FrozenClock {
+	t.Helper()
+	if c, ok := clocks.Load(t); ok {
+		return c.(*kernel.FrozenClock)
+	}
+
+	c := kernel.NewFrozenClock(Epoch)
+	restore := kernel.SetClock(c)
+	clocks.Store(t, c)
+	t.Cleanup(func() {
+		restore()
+		clocks.Delete(t)
+	})
+	return c
+}
+
+// SeedIDs installs [kernel.SequentialIDGenerator] with the "id" prefix for the duration
+// of t, so generated IDs are "id-1", "id-2", and so on. Later calls within the same test
+// keep the running sequence. The previous generator is restored when t finishes.
+func SeedIDs(t testing.TB) {
+	t.Helper()
+	if _, loaded := seeded.LoadOrStore(t, struct{}{}); loaded {
+		return
+	}
+
+	restore := kernel.SetIDGenerator(kernel.SequentialIDGenerator("id"))
+	t.Cleanup(func() {
+		restore()
+		seeded.Delete(t)
+	})
+}
+
+func setup(t testing.TB) {
+	t.Helper()
+	Clock(t)
+	SeedIDs(t)
+}
+
+// ==================== DeliveryAddress ==================== //
+
+type addressConfig struct {
+	cep, street, number, complement, district, city, state, country string
+}
+
+// AddressOption tweaks the fields used by [ValidAddress].
+type AddressOption func(*addressConfig)
+
+// WithCEP overrides the address CEP.
+func WithCEP(cep string) AddressOption {
+	return func(c *addressConfig) { c.cep = cep }
+}
+
+// WithState overrides the address state (UF).
+func WithState(state string) AddressOption {
+	return func(c *addressConfig) { c.state = state }
+}
+
+// WithCity overrides the address city.
+func WithCity(city string) AddressOption {
+	return func(c *addressConfig) { c.city = city }
+}
+
+// WithComplement overrides the address complement.
+func WithComplement(complement string) AddressOption {
+	return func(c *addressConfig) { c.complement = complement }
+}
+
+// ValidAddress returns a valid [order.DeliveryAddress] in São Paulo, failing t if the
+// options make it invalid.
+func ValidAddress(t testing.TB, opts ...AddressOption) *order.DeliveryAddress {
+	t.Helper()
+	c := addressConfig{
+		cep:      "12345-678",
+		street:   "Rua das Flores",
+		number:   "100",
+		district: "Centro",
+		city:     "São Paulo",
+		state:    "SP",
+		country:  "Brasil",
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	addr, err := order.NewDeliveryAddress(c.cep, c.street, c.number, c.complement, c.district, c.city, c.state, c.country)
+	if err != nil {
+		t.Fatalf("testfixtures: invalid delivery address: %v", err)
+	}
+	return addr
+}
+
+// ==================== Order ==================== //
+
+type item struct {
+	productID, productName string
+	unitPrice              float64
+	quantity               int
+}
+
+type orderConfig struct {
+	customerID string
+	address    *order.DeliveryAddress
+	items      []item
+}
+
+// OrderOption tweaks the order built by [ValidOrder].
+type OrderOption func(*orderConfig)
+
+// WithCustomerID overrides the order customer ID.
+func WithCustomerID(customerID string) OrderOption {
+	return func(c *orderConfig) { c.customerID = customerID }
+}
+
+// WithAddress overrides the order delivery address.
+func WithAddress(address *order.DeliveryAddress) OrderOption {
+	return func(c *orderConfig) { c.address = address }
+}
+
+// WithItem adds a line item to the order. When no WithItem option is given the order
+// gets a single default line ("prod-1", "Widget", 50.0 × 2).
+func WithItem(productID, productName string, unitPrice float64, quantity int) OrderOption {
+	return func(c *orderConfig) {
+		c.items = append(c.items, item{productID, productName, unitPrice, quantity})
+	}
+}
+
+// WithoutItems builds the order with no line items.
+func WithoutItems() OrderOption {
+	return func(c *orderConfig) { c.items = []item{} }
+}
+
+// ValidOrder returns a pending [order.Order] with a default line item, failing t if the
+// options make it invalid.
+func ValidOrder(t testing.TB, opts ...OrderOption) *order.Order {
+	t.Helper()
+	setup(t)
+
+	c := orderConfig{customerID: "cust-123"}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.address == nil {
+		c.address = ValidAddress(t)
+	}
+	if c.items == nil {
+		c.items = []item{{"prod-1", "Widget", 50.0, 2}}
+	}
+
+	o, err := order.NewOrder(c.customerID, c.address)
+	if err != nil {
+		t.Fatalf("testfixtures: invalid order: %v", err)
+	}
+	for _, it := range c.items {
+		if err := o.AddItem(it.productID, it.productName, it.unitPrice, it.quantity); err != nil {
+			t.Fatalf("testfixtures: invalid order item: %v", err)
+		}
+	}
+	return o
+}
+
+// ==================== Payment ==================== //
+
+type paymentConfig struct {
+	orderID         string
+	amount          float64
+	method          payment.Method
+	transactionCode string
+}
+
+// PaymentOption tweaks the payment built by [ValidPayment].
+type PaymentOption func(*paymentConfig)
+
+// WithOrderID overrides the ID of the order the payment belongs to.
+func WithOrderID(orderID string) PaymentOption {
+	return func(c *paymentConfig) { c.orderID = orderID }
+}
+
+// WithAmount overrides the payment amount.
+func WithAmount(amount float64) PaymentOption {
+	return func(c *paymentConfig) { c.amount = amount }
+}
+
+// WithMethod overrides the payment method.
+func WithMethod(method payment.Method) PaymentOption {
+	return func(c *paymentConfig) { c.method = method }
+}
+
+// WithTransactionCode defines the transaction code right after creation.
+func WithTransactionCode(code string) PaymentOption {
+	return func(c *paymentConfig) { c.transactionCode = code }
+}
+
+// ValidPayment returns a pending [payment.Payment] of 100.0 by credit card, failing t
+// if the options make it invalid.
+func ValidPayment(t testing.TB, opts ...PaymentOption) *payment.Payment {
+	t.Helper()
+	setup(t)
+
+	c := paymentConfig{
+		orderID: "order-123",
+		amount:  100.0,
+		method:  payment.MethodCreditCard,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	p, err := payment.NewPayment(c.orderID, c.amount, c.method)
+	if err != nil {
+		t.Fatalf("testfixtures: invalid payment: %v", err)
+	}
+	if c.transactionCode != "" {
+		if err := p.DefineTransactionCode(c.transactionCode); err != nil {
+			t.Fatalf("testfixtures: invalid transaction code: %v", err)
+		}
+	}
+	return p
+}
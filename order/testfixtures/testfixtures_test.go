@@ -0,0 +1,99 @@
+package testfixtures_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/kernel"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/domain/payment"
+	"github.com/marcosvieirajr/sales-ddd-hexagonal/order/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClock(t *testing.T) {
+	t.Run("should freeze time at Epoch and reuse the clock within a test", func(t *testing.T) {
+		c := testfixtures.Clock(t)
+
+		assert.Equal(t, testfixtures.Epoch, kernel.Now())
+		assert.Same(t, c, testfixtures.Clock(t))
+
+		c.Advance(time.Minute)
+		assert.Equal(t, testfixtures.Epoch.Add(time.Minute), kernel.Now())
+	})
+
+	t.Run("should restore the previous clock after the test", func(t *testing.T) {
+		assert.NotEqual(t, testfixtures.Epoch.Add(time.Minute), kernel.Now())
+	})
+}
+
+func TestSeedIDs(t *testing.T) {
+	testfixtures.SeedIDs(t)
+	testfixtures.SeedIDs(t)
+
+	assert.Equal(t, "id-1", kernel.GenerateID())
+	assert.Equal(t, "id-2", kernel.GenerateID(), "a second SeedIDs call should keep the sequence")
+}
+
+func TestValidAddress(t *testing.T) {
+	got := testfixtures.ValidAddress(t, testfixtures.WithState("RJ"), testfixtures.WithCity("Rio de Janeiro"))
+
+	want := testfixtures.ValidAddress(t, testfixtures.WithState("RJ"), testfixtures.WithCity("Rio de Janeiro"))
+	assert.True(t, got.Equals(want))
+	assert.False(t, got.Equals(testfixtures.ValidAddress(t)))
+}
+
+func TestValidOrder(t *testing.T) {
+	t.Run("should build a pending order with a default item at the frozen time", func(t *testing.T) {
+		got := testfixtures.ValidOrder(t)
+
+		assert.Equal(t, "cust-123", got.CustomerID)
+		assert.Equal(t, 100.0, got.TotalAmount)
+		assert.Equal(t, testfixtures.Epoch, got.CreatedAt)
+		assert.Equal(t, "id-1", got.ID, "IDs should come from the seeded generator")
+	})
+
+	t.Run("should apply the given options", func(t *testing.T) {
+		got := testfixtures.ValidOrder(t,
+			testfixtures.WithCustomerID("cust-999"),
+			testfixtures.WithItem("prod-a", "A", 10.0, 1),
+			testfixtures.WithItem("prod-b", "B", 5.0, 2),
+		)
+
+		assert.Equal(t, "cust-999", got.CustomerID)
+		assert.Len(t, got.Items(), 2)
+		assert.Equal(t, 20.0, got.TotalAmount)
+	})
+
+	t.Run("should build an order without items", func(t *testing.T) {
+		got := testfixtures.ValidOrder(t, testfixtures.WithoutItems())
+
+		assert.Empty(t, got.Items())
+	})
+}
+
+func TestValidPayment(t *testing.T) {
+	t.Run("should build a pending payment with default values", func(t *testing.T) {
+		got := testfixtures.ValidPayment(t)
+
+		assert.Equal(t, "order-123", got.OrderID)
+		assert.Equal(t, 100.0, got.Amount)
+		assert.Equal(t, payment.StatusPending, got.Status)
+		assert.Nil(t, got.TransactionCode)
+	})
+
+	t.Run("should apply the given options", func(t *testing.T) {
+		got := testfixtures.ValidPayment(t,
+			testfixtures.WithOrderID("order-9"),
+			testfixtures.WithAmount(42.0),
+			testfixtures.WithMethod(payment.MethodPix),
+			testfixtures.WithTransactionCode("TXN-1"),
+		)
+
+		assert.Equal(t, "order-9", got.OrderID)
+		assert.Equal(t, 42.0, got.Amount)
+		assert.Equal(t, payment.MethodPix, got.Method)
+		require.NotNil(t, got.TransactionCode)
+		assert.Equal(t, "TXN-1", *got.TransactionCode)
+	})
+}